@@ -3,37 +3,199 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the generator configuration
 type Config struct {
-	Generator   GeneratorConfig `yaml:"generator"`
-	Resources   []Resource      `yaml:"resources"`
-	DataSources []DataSource    `yaml:"data_sources"`
+	Generator   GeneratorConfig    `yaml:"generator"`
+	Defaults    []ResourceDefaults `yaml:"defaults"` // Shared settings merged into matching resources
+	Naming      NamingConfig       `yaml:"naming"`   // Rules for Terraform type names and service packages
+	Resources   []Resource         `yaml:"resources"`
+	DataSources []DataSource       `yaml:"data_sources"`
 }
 
 // GeneratorConfig contains global generator settings
 type GeneratorConfig struct {
-	OpenAPISchema  string   `yaml:"openapi_schema"`
+	OpenAPISchema  string   `yaml:"openapi_schema"`  // Path or HTTP(S) URL of the OpenAPI schema
+	OpenAPISchemas []string `yaml:"openapi_schemas"` // Additional schema documents merged into openapi_schema
 	OutputDir      string   `yaml:"output_dir"`
 	ProviderName   string   `yaml:"provider_name"`
 	ExcludedFields []string `yaml:"excluded_fields"`
 	SetFields      []string `yaml:"set_fields"`
+
+	OpenAPIAuthEnv string `yaml:"openapi_auth_env"` // Environment variable holding the Authorization header for a remote schema
+	SchemaCacheDir string `yaml:"schema_cache_dir"` // Where downloaded schemas are cached (default: .cache)
+
+	ProviderAttributes []ProviderAttributeConfig `yaml:"provider_attributes"` // Extra provider attributes sent as HTTP headers
+
+	UnionStrategy string `yaml:"union_strategy"` // How oneOf/anyOf unions of objects are modelled: "first", "merge" or "discriminator" (default: "discriminator" when the schema declares one, else "first")
+	CircularRefs  string `yaml:"circular_refs"`  // How schema references back to an enclosing schema are exposed: "truncate" or "url" (default: "truncate")
+
+	IgnoreDefaults bool `yaml:"ignore_defaults"` // Don't turn OpenAPI default values into schema defaults of optional attributes
+
+	DynamicObjects string `yaml:"dynamic_objects"` // How top-level objects with arbitrary properties are exposed: "map" or "json" (default: "map")
+
+	TypeCoercions map[string]string `yaml:"type_coercions"` // Field names or dotted paths mapped to the scalar type the API actually uses
+}
+
+// Union strategies
+const (
+	UnionFirst         = "first"         // Use the first variant only
+	UnionMerge         = "merge"         // One nested object holding the fields of every variant, all optional
+	UnionDiscriminator = "discriminator" // One optional nested block per variant, selected by the OpenAPI discriminator
+)
+
+// Circular reference handling
+const (
+	CircularRefsTruncate = "truncate" // Leave the attribute out
+	CircularRefsURL      = "url"      // Expose the URL of the referenced object as a string attribute
+)
+
+// Dynamic object handling
+const (
+	DynamicObjectsMap  = "map"  // Map of strings; nested values are not supported
+	DynamicObjectsJSON = "json" // Normalized JSON string holding the whole object
+)
+
+// Normalizations under which a top-level string attribute is compared with the value returned by the server
+const (
+	NormalizeURL                   = "url"                    // Scheme and host are case-insensitive and a trailing slash is ignored (default for uri fields)
+	NormalizeCaseInsensitive       = "case_insensitive"       // Letter case is ignored
+	NormalizeWhitespaceInsensitive = "whitespace_insensitive" // Leading and trailing whitespace is ignored, and inner runs of whitespace match a single space
+	NormalizeRFC3339               = "rfc3339"                // Timestamps are equal when they denote the same instant (default for date-time fields)
+	NormalizeNone                  = "none"                   // Values are compared as written
+)
+
+// Transformations of top-level field values between the Terraform attribute and the API
+const (
+	TransformMBToGB         = "mb_to_gb"          // Integer sizes the API reports in megabytes are exposed in gigabytes
+	TransformLowercase      = "lowercase"         // Strings are sent and read back in lower case, and compared case-insensitively
+	TransformStripURLToUUID = "strip_url_to_uuid" // URLs returned by the API are exposed as the UUID they end with; either is sent as given
+)
+
+// Handling of top-level fields missing from a response, e.g. of an API version without them
+const (
+	OnMissingKeep = "keep" // The value in state is kept (default)
+	OnMissingNull = "null" // The attribute is set to null, as when the response holds null
+)
+
+// ReferenceNone disables the detection of the objects a URL field refers to
+const ReferenceNone = "none"
+
+// Resolutions of a field whose request and response definitions have different types
+const (
+	PreferRequest  = "request"  // Keep the request definition; the value is not read back from responses (top-level fields only)
+	PreferResponse = "response" // Keep the response definition as a computed attribute that is not sent (top-level fields only)
+	PreferExpand   = "expand"   // Keep the request URL; the response may expand it into the object it identifies
+)
+
+// NamingConfig controls how configured names map to Terraform type names and service packages
+type NamingConfig struct {
+	Prefix    string            `yaml:"prefix"`     // Prepended to every Terraform type name after the provider name
+	Services  map[string]string `yaml:"services"`   // Name prefixes mapped to service packages (longest match wins)
+	TypeNames map[string]string `yaml:"type_names"` // Explicit Terraform type names per resource or data source
+	Plurals   map[string]string `yaml:"plurals"`    // Plurals of words the built-in rules get wrong, e.g. "person": "people"
+}
+
+// TypeName returns the Terraform type name of a resource or data source, without the provider prefix
+func (n NamingConfig) TypeName(name string) string {
+	if typeName, ok := n.TypeNames[name]; ok {
+		return typeName
+	}
+	return n.Prefix + name
+}
+
+// Plural returns the plural of a name by pluralizing its last word, using the configured plurals first
+func (n NamingConfig) Plural(name string) string {
+	head, word := splitLastWord(name)
+	if plural, ok := n.Plurals[word]; ok {
+		return head + plural
+	}
+	return Pluralize(name)
+}
+
+// irregularPlurals are English plurals not formed by the suffix rules of Pluralize
+var irregularPlurals = map[string]string{
+	"child":     "children",
+	"person":    "people",
+	"data":      "data",
+	"info":      "info",
+	"metadata":  "metadata",
+	"equipment": "equipment",
+}
+
+// Pluralize returns the plural of a name by pluralizing its last word, separated by "_" or "-"
+// ("policy" becomes "policies", "address" becomes "addresses", "floating_ip" becomes "floating_ips")
+func Pluralize(name string) string {
+	head, word := splitLastWord(name)
+	if plural, ok := irregularPlurals[word]; ok {
+		return head + plural
+	}
+	switch {
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return head + strings.TrimSuffix(word, "y") + "ies"
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return head + word + "es"
+	}
+	return head + word + "s"
+}
+
+// splitLastWord splits a name before its last word, separated by "_" or "-"
+func splitLastWord(name string) (string, string) {
+	i := strings.LastIndexAny(name, "_-") + 1
+	return name[:i], name[i:]
+}
+
+// ProviderAttributeConfig defines a string provider attribute injected as an HTTP header on every request
+type ProviderAttributeConfig struct {
+	Name        string `yaml:"name"`        // Provider attribute name (e.g., "impersonated_user")
+	Header      string `yaml:"header"`      // HTTP header carrying the value (e.g., "X-Impersonated-User-Uuid")
+	EnvVar      string `yaml:"env_var"`     // Environment variable used when the attribute is not set
+	Description string `yaml:"description"` // Attribute description shown in the provider docs
+	Sensitive   bool   `yaml:"sensitive"`   // Hide the value in plan output
 }
 
 // Resource defines a Terraform resource to generate
 type Resource struct {
-	Name                  string                        `yaml:"name"`
-	BaseOperationID       string                        `yaml:"base_operation_id"`
-	Plugin                string                        `yaml:"plugin"`
-	OfferingType          string                        `yaml:"offering_type"`
-	UpdateActions         map[string]UpdateActionConfig `yaml:"update_actions"`
-	TerminationAttributes []ParameterConfig             `yaml:"termination_attributes"`
-	SkipOperations        []string                      `yaml:"skip_operations"`  // Operations to skip validation for
-	CreateOperation       *CreateOperationConfig        `yaml:"create_operation"` // Custom create operation (for nested resources)
-	CompositeKeys         []string                      `yaml:"composite_keys"`   // Fields that together form a unique identifier
+	Name                  string                            `yaml:"name"`
+	BaseOperationID       string                            `yaml:"base_operation_id"`
+	Plugin                string                            `yaml:"plugin"`
+	OfferingType          string                            `yaml:"offering_type"`
+	UpdateActions         map[string]UpdateActionConfig     `yaml:"update_actions"`
+	TerminationAttributes []ParameterConfig                 `yaml:"termination_attributes"`
+	SkipOperations        []string                          `yaml:"skip_operations"`      // Operations to skip validation for
+	CreateOperation       *CreateOperationConfig            `yaml:"create_operation"`     // Custom create operation (for nested resources)
+	DeleteOperation       *DeleteOperationConfig            `yaml:"delete_operation"`     // Custom delete operation (e.g., a POST terminate action)
+	CompositeKeys         []string                          `yaml:"composite_keys"`       // Fields that together form a unique identifier
+	IDField               string                            `yaml:"id_field"`             // Field identifying the resource on import (default: uuid)
+	Polling               *PollingConfig                    `yaml:"polling"`              // Custom polling behavior for async operations
+	Timeouts              *TimeoutsConfig                   `yaml:"timeouts"`             // Default operation timeouts
+	Deprecated            string                            `yaml:"deprecated"`           // Deprecation message shown to users of the resource
+	Steps                 []StepConfig                      `yaml:"steps"`                // Additional API calls executed after creation
+	FeatureFlag           string                            `yaml:"feature_flag"`         // Only generated when this feature is enabled
+	SkipFieldsInState     []string                          `yaml:"skip_fields_in_state"` // Volatile response fields (or nested paths) not refreshed in state
+	VirtualFields         []VirtualFieldConfig              `yaml:"virtual_fields"`       // Computed attributes derived from the API response
+	Validators            *ValidatorsConfig                 `yaml:"validators"`           // Cross-attribute configuration validators
+	GenerateDataSource    *bool                             `yaml:"generate_data_source"` // Set to false to share the SDK with data sources without generating them
+	Aliases               []string                          `yaml:"aliases"`              // Previous type names kept for backward compatibility
+	BulkOperation         string                            `yaml:"bulk_operation"`       // Bulk create operation of "bulk" resources (default: detected from base_operation_id)
+	SchemaVersion         int64                             `yaml:"schema_version"`       // Version of the schema, increased when the state layout changes
+	StateUpgrades         []StateUpgradeConfig              `yaml:"state_upgrades"`       // State layout changes from each prior schema version to the next
+	Migrations            []MigrationConfig                 `yaml:"migrations"`           // Shorthand for state_upgrades that only move attributes, merged into them when loaded
+	DeletionProtection    bool                              `yaml:"deletion_protection"`  // Adds a deletion_protection attribute that blocks Delete unless it is false
+	ActionTriggers        []string                          `yaml:"action_triggers"`      // Actions also run during update when their <action>_trigger attribute changes
+	Preflight             []PreflightCheck                  `yaml:"preflight"`            // Validation endpoints called while planning, reporting failures before apply
+	NestedOperations      map[string]NestedOperationsConfig `yaml:"nested_operations"`    // Nested lists updated item by item through add and remove operations, keyed by attribute
+	OfferingTypes         []string                          `yaml:"offering_types"`       // Offering types ordered by an order resource, each with its own block of specific attributes
 	// Link Plugin Fields
 	Source         *LinkResourceConfig    `yaml:"source"`
 	Target         *LinkResourceConfig    `yaml:"target"`
@@ -41,6 +203,7 @@ type Resource struct {
 	UnlinkOp       string                 `yaml:"unlink_op"`
 	LinkCheckKey   string                 `yaml:"link_check_key"` // Key in source resource to check for target presence
 	LinkParams     []ParameterConfig      `yaml:"link_params"`    // Additional parameters for link operation
+	Members        *LinkMembersConfig     `yaml:"members"`        // Manages all sources linked to one target as a set
 	Actions        []string               `yaml:"actions"`        // List of actions to generate (for "actions" plugin)
 	SetFields      map[string]FieldConfig `yaml:"set_fields"`
 	ExcludedFields []string               `yaml:"excluded_fields"`
@@ -48,26 +211,228 @@ type Resource struct {
 
 // FieldConfig defines overrides for a field
 type FieldConfig struct {
-	Computed      bool `yaml:"computed"`
-	Optional      bool `yaml:"optional"`
-	Required      bool `yaml:"required"`
-	ForceNew      bool `yaml:"force_new"`
-	Set           bool `yaml:"set"` // True if field should be a Set instead of List
-	UnknownIfNull bool `yaml:"unknown_if_null"`
+	Computed      bool     `yaml:"computed"`
+	Optional      bool     `yaml:"optional"`
+	Required      bool     `yaml:"required"`
+	ForceNew      *bool    `yaml:"force_new"` // Overrides replacement inference: true forces it, false suppresses it
+	Set           *bool    `yaml:"set"`       // True forces a Set, false forces a List (overrides generator set_fields)
+	UnknownIfNull bool     `yaml:"unknown_if_null"`
+	WriteOnly     bool     `yaml:"write_only"`     // Sent on create but never stored in state (e.g., initial passwords)
+	Union         string   `yaml:"union"`          // Overrides generator union_strategy for this field
+	IgnoreDefault bool     `yaml:"ignore_default"` // Leaves the OpenAPI default of this field to the server
+	DynamicObject string   `yaml:"dynamic_object"` // Overrides generator dynamic_objects for this field
+	Normalize     string   `yaml:"normalize"`      // How values rewritten by the server are compared: "url", "case_insensitive", "whitespace_insensitive", "rfc3339" or "none" (default: from the format)
+	Prefer        string   `yaml:"prefer"`         // Resolves request and response definitions of different types: "request", "response" or "expand"
+	Transform     string   `yaml:"transform"`      // Converts values sent to and read from the API: "mb_to_gb", "lowercase" or "strip_url_to_uuid"
+	Reference     string   `yaml:"reference"`      // Retrieve operation of the objects a URL field refers to, whose UUIDs are accepted too (default: detected from the field name); "none" disables it
+	AllowMissing  bool     `yaml:"allow_missing"`  // Only warns when a field the create request requires cannot be set (e.g., excluded or read-only)
+	Keys          []string `yaml:"keys"`           // Attributes identifying the elements of a set of objects, whose computed attributes are kept from state
+	AsJSON        bool     `yaml:"as_json"`        // Exposes a top-level field of any type as one normalized JSON string attribute
+	ReplaceIf     string   `yaml:"replace_if"`     // Condition on the old and new values under which a change replaces the resource (e.g., "new < old")
+	OnMissing     string   `yaml:"on_missing"`     // How a top-level field missing from a response is read: "keep" the value in state (default) or "null"
+}
+
+// StepConfig defines an additional API call executed, in order, after the resource is created
+type StepConfig struct {
+	Name              string            `yaml:"name"`               // Step name; the created object's UUID is exposed as <name>_uuid
+	Operation         string            `yaml:"operation"`          // OpenAPI operation ID of the POST action (e.g., "openstack_tenants_create_network")
+	Parent            string            `yaml:"parent"`             // Attribute holding the {uuid} path parameter (defaults to the resource itself)
+	Params            map[string]string `yaml:"params"`             // Request body keys mapped to resource attributes
+	RollbackOperation string            `yaml:"rollback_operation"` // OpenAPI operation ID that undoes the step (e.g., "openstack_networks_destroy")
+}
+
+// PreflightCheck defines a validation endpoint, such as a name availability or quota check, called with the
+// planned values of a resource
+type PreflightCheck struct {
+	Operation    string            `yaml:"operation"`     // OpenAPI operation ID of the GET or POST check
+	Parent       string            `yaml:"parent"`        // Attribute holding the {uuid} path parameter, if the operation has one
+	Params       map[string]string `yaml:"params"`        // Query parameters of GET checks, or request body keys of POST checks, mapped to resource attributes
+	SuccessField string            `yaml:"success_field"` // Boolean response field that is false when the check fails; without it, only rejected requests fail
+	Message      string            `yaml:"message"`       // Reported when success_field is false
+	OnUpdate     bool              `yaml:"on_update"`     // Also check updates that change the referenced attributes, not only creation
+}
+
+// NestedOperationsConfig defines the operations adding and removing one item of a nested list, so that changes
+// to the list update the resource in place instead of replacing it
+type NestedOperationsConfig struct {
+	Add          string            `yaml:"add"`           // OpenAPI operation ID of the POST action adding an item, whose request body holds item attributes
+	Remove       string            `yaml:"remove"`        // OpenAPI operation ID of the POST action removing an item
+	RemoveParams map[string]string `yaml:"remove_params"` // Request body keys of remove mapped to item attributes (default: uuid: uuid)
+}
+
+// StateUpgradeConfig describes how the state layout changed from a prior schema version to the next one
+type StateUpgradeConfig struct {
+	Version int64             `yaml:"version"` // Prior schema version
+	Renamed map[string]string `yaml:"renamed"` // Attribute paths in that version (e.g., "rules.port") mapped to their names in the next one
+	Moved   map[string]string `yaml:"moved"`   // Attribute paths in that version mapped to their paths in the next one (e.g., "cores": "limits.cores")
+}
+
+// MigrationConfig describes the attributes that moved from a prior schema version to the next one. It is
+// merged into the state_upgrades entry of the same version.
+type MigrationConfig struct {
+	Version int64             `yaml:"version"` // Prior schema version
+	Moved   map[string]string `yaml:"moved"`   // Attribute paths in that version mapped to their paths in the next one
+}
+
+// VirtualFieldConfig defines a computed attribute whose value is taken from a path into the API response
+type VirtualFieldConfig struct {
+	Name        string `yaml:"name"`        // Attribute name
+	Type        string `yaml:"type"`        // Expected OpenAPI type of the value (optional, inferred from the expression)
+	Expression  string `yaml:"expression"`  // Path into the response (e.g., "ports[0].fixed_ips[0].ip_address")
+	Description string `yaml:"description"` // Attribute description
+}
+
+// ValidatorsConfig defines groups of attributes validated together
+type ValidatorsConfig struct {
+	ConflictsWith [][]string `yaml:"conflicts_with"`  // At most one attribute of each group may be set
+	RequiredWith  [][]string `yaml:"required_with"`   // Attributes of each group must be set together
+	ExactlyOneOf  [][]string `yaml:"exactly_one_of"`  // Exactly one attribute of each group must be set
+	AtLeastOneOf  [][]string `yaml:"at_least_one_of"` // At least one attribute of each group must be set
+}
+
+// Validate checks that every validator group references at least two attributes
+func (v *ValidatorsConfig) Validate() error {
+	groups := []struct {
+		name   string
+		groups [][]string
+	}{
+		{"conflicts_with", v.ConflictsWith},
+		{"required_with", v.RequiredWith},
+		{"exactly_one_of", v.ExactlyOneOf},
+		{"at_least_one_of", v.AtLeastOneOf},
+	}
+	for _, g := range groups {
+		for i, attrs := range g.groups {
+			if len(attrs) < 2 {
+				return fmt.Errorf("validators.%s[%d] must list at least two attributes", g.name, i)
+			}
+		}
+	}
+	return nil
+}
+
+// validateLinkMembers checks that a members link resource has the operations to link, unlink and read
+// its sources one by one
+func validateLinkMembers(r *Resource) error {
+	switch {
+	case r.LinkOp == "" || r.UnlinkOp == "":
+		return fmt.Errorf("members require link_op and unlink_op")
+	case r.Source == nil || r.Source.Param == "":
+		return fmt.Errorf("members require source.param")
+	case r.Target == nil || r.Target.Param == "" || r.Target.RetrieveOp == "":
+		return fmt.Errorf("members require target.param and target.retrieve_op")
+	case r.Members.Attribute == "" || r.Members.Key == "":
+		return fmt.Errorf("members require an attribute and a key")
+	case r.Members.Attribute == r.Target.Param:
+		return fmt.Errorf("members attribute %s conflicts with the target parameter", r.Members.Attribute)
+	case len(r.LinkParams) > 0:
+		return fmt.Errorf("link_params are not supported with members")
+	}
+	return nil
+}
+
+// validateStateUpgrades checks that state upgrades describe distinct prior schema versions and rename
+// attributes to plain names
+func validateStateUpgrades(version int64, upgrades []StateUpgradeConfig) error {
+	if version < 0 {
+		return fmt.Errorf("schema_version cannot be negative")
+	}
+	versions := make(map[int64]bool)
+	for _, u := range upgrades {
+		if u.Version < 0 || u.Version >= version {
+			return fmt.Errorf("state_upgrades: version %d must be lower than schema_version %d", u.Version, version)
+		}
+		if versions[u.Version] {
+			return fmt.Errorf("state_upgrades: duplicate version %d", u.Version)
+		}
+		versions[u.Version] = true
+		for from, to := range u.Renamed {
+			if from == "" || slices.Contains(strings.Split(from, "."), "") {
+				return fmt.Errorf("state_upgrades: version %d: invalid attribute path %q", u.Version, from)
+			}
+			if to == "" || strings.Contains(to, ".") {
+				return fmt.Errorf("state_upgrades: version %d: %s must be renamed to an attribute name, got %q", u.Version, from, to)
+			}
+		}
+		for from, to := range u.Moved {
+			for _, p := range []string{from, to} {
+				if p == "" || slices.Contains(strings.Split(p, "."), "") {
+					return fmt.Errorf("state_upgrades: version %d: invalid attribute path %q", u.Version, p)
+				}
+			}
+			if from == to {
+				return fmt.Errorf("state_upgrades: version %d: %s is moved to itself", u.Version, from)
+			}
+		}
+	}
+	return nil
+}
+
+// ResourceDefaults defines settings shared by all resources whose name matches Match
+type ResourceDefaults struct {
+	Match          string                 `yaml:"match"` // Resource name pattern (e.g., "openstack_*"); empty matches all
+	ExcludedFields []string               `yaml:"excluded_fields"`
+	SetFields      map[string]FieldConfig `yaml:"set_fields"`
+	Polling        *PollingConfig         `yaml:"polling"`
+	Timeouts       *TimeoutsConfig        `yaml:"timeouts"`
+}
+
+// Matches reports whether the defaults apply to the named resource
+func (d *ResourceDefaults) Matches(name string) bool {
+	if d.Match == "" {
+		return true
+	}
+	ok, err := path.Match(d.Match, name)
+	return err == nil && ok
+}
+
+// TimeoutsConfig defines default timeouts for resource operations
+type TimeoutsConfig struct {
+	Create string `yaml:"create"` // e.g., "30m"
+	Update string `yaml:"update"`
+	Delete string `yaml:"delete"`
+}
+
+// PollingConfig defines how the generated provider waits for asynchronous operations
+type PollingConfig struct {
+	Interval      string   `yaml:"interval"`       // Delay between refreshes (e.g., "10s")
+	MaxAttempts   int      `yaml:"max_attempts"`   // Maximum number of refreshes before giving up (0 means unlimited)
+	Backoff       string   `yaml:"backoff"`        // "exponential" (default) or "constant"
+	PendingStates []string `yaml:"pending_states"` // States that mean the operation is still in progress
+	SuccessStates []string `yaml:"success_states"` // States that mean the operation has completed
+	FailureStates []string `yaml:"failure_states"` // States that mean the operation has failed
 }
 
+// Polling backoff strategies
+const (
+	BackoffExponential = "exponential"
+	BackoffConstant    = "constant"
+)
+
 // LinkResourceConfig defines configuration for a linked resource
 type LinkResourceConfig struct {
 	Param      string `yaml:"param"`       // Parameter name in link operation
 	RetrieveOp string `yaml:"retrieve_op"` // Operation to retrieve the resource state
 }
 
+// LinkMembersConfig defines a link resource managing every source linked to one target
+type LinkMembersConfig struct {
+	Attribute string `yaml:"attribute"` // Set attribute holding the UUIDs of the linked sources
+	Key       string `yaml:"key"`       // Field of the target listing the linked sources
+}
+
 // CreateOperationConfig defines a custom create operation for nested resources
 type CreateOperationConfig struct {
 	OperationID string            `yaml:"operation_id"` // The OpenAPI operation ID (e.g., "openstack_tenants_create_floating_ip")
 	PathParams  map[string]string `yaml:"path_params"`  // Path parameters mapping (e.g., uuid: tenant)
 }
 
+// DeleteOperationConfig defines a custom delete operation
+type DeleteOperationConfig struct {
+	OperationID string            `yaml:"operation_id"` // The OpenAPI operation ID (e.g., "openstack_tenants_terminate")
+	Params      map[string]string `yaml:"params"`       // Request body keys mapped to resource attributes from state
+}
+
 // UpdateActionConfig defines a custom update action
 type UpdateActionConfig struct {
 	Operation  string `yaml:"operation"`   // The OpenAPI operation ID (e.g., "marketplace_resources_update_limits")
@@ -85,6 +450,52 @@ type ParameterConfig struct {
 type DataSource struct {
 	Name            string `yaml:"name"`
 	BaseOperationID string `yaml:"base_operation_id"`
+	Deprecated      string `yaml:"deprecated"`   // Deprecation message shown to users of the data source
+	FeatureFlag     string `yaml:"feature_flag"` // Only generated when this feature is enabled
+	ResourceRef     string `yaml:"resource_ref"` // Resource whose SDK and model are shared (defaults to the resource with the same name)
+	Ephemeral       bool   `yaml:"ephemeral"`    // Generated as an ephemeral resource, so values such as tokens are never stored in state
+	List            bool   `yaml:"list"`         // Also generates a plural data source returning all matching objects
+}
+
+// ResourceName returns the name of the resource whose SDK the data source shares
+func (d *DataSource) ResourceName() string {
+	if d.ResourceRef != "" {
+		return d.ResourceRef
+	}
+	return d.Name
+}
+
+// ListTypeName returns the Terraform type name of the plural data source, without the provider prefix
+func (d *DataSource) ListTypeName(n NamingConfig) string {
+	return n.Plural(n.TypeName(d.Name))
+}
+
+// DataSourceEnabled reports whether data sources sharing the resource's SDK are generated
+func (r *Resource) DataSourceEnabled() bool {
+	return r.GenerateDataSource == nil || *r.GenerateDataSource
+}
+
+// OrderAttributeSchemas returns the names of the CreateOrderAttributes schemas of an order resource's offering types
+func (r *Resource) OrderAttributeSchemas() []string {
+	types := r.OfferingTypes
+	if len(types) == 0 {
+		types = []string{r.OfferingType}
+	}
+	schemas := make([]string, len(types))
+	for i, offeringType := range types {
+		schemas[i] = strings.ReplaceAll(offeringType, ".", "") + "CreateOrderAttributes"
+	}
+	return schemas
+}
+
+// DataSourceEnabled reports whether a data source is generated, honoring generate_data_source on its resource
+func (c *Config) DataSourceEnabled(d *DataSource) bool {
+	for i := range c.Resources {
+		if c.Resources[i].Name == d.ResourceName() {
+			return c.Resources[i].DataSourceEnabled()
+		}
+	}
+	return true
 }
 
 // OperationIDs returns the inferred operation IDs for a resource
@@ -122,19 +533,250 @@ func LoadConfig(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if err := expandEnv(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if err := validateSchema(&doc); err != nil {
+		return nil, err
+	}
+
 	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	if err := doc.Decode(&config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	if err := config.applyDefaults(); err != nil {
+		return nil, err
+	}
+	if err := config.mergeMigrations(); err != nil {
+		return nil, err
+	}
+
 	// Set defaults
 	if config.Generator.OutputDir == "" {
 		config.Generator.OutputDir = "./output/terraform-provider-waldur"
 	}
+	if config.Generator.SchemaCacheDir == "" {
+		config.Generator.SchemaCacheDir = ".cache"
+	}
 
 	return &config, nil
 }
 
+// FilterFeatures drops resources and data sources gated behind a feature flag that is not enabled
+func (c *Config) FilterFeatures(features []string) {
+	enabled := make(map[string]bool, len(features))
+	for _, f := range features {
+		enabled[f] = true
+	}
+
+	resources := c.Resources[:0]
+	for _, r := range c.Resources {
+		if r.FeatureFlag == "" || enabled[r.FeatureFlag] {
+			resources = append(resources, r)
+		}
+	}
+	c.Resources = resources
+
+	dataSources := c.DataSources[:0]
+	for _, d := range c.DataSources {
+		if d.FeatureFlag == "" || enabled[d.FeatureFlag] {
+			dataSources = append(dataSources, d)
+		}
+	}
+	c.DataSources = dataSources
+}
+
+// applyDefaults merges matching defaults entries into each resource.
+// Entries are applied in order; settings on the resource itself take precedence.
+func (c *Config) applyDefaults() error {
+	for i := range c.Defaults {
+		if _, err := path.Match(c.Defaults[i].Match, ""); err != nil {
+			return fmt.Errorf("defaults[%d]: invalid match pattern %q: %w", i, c.Defaults[i].Match, err)
+		}
+	}
+
+	for i := range c.Resources {
+		r := &c.Resources[i]
+		var excluded []string
+		setFields := make(map[string]FieldConfig)
+		var polling *PollingConfig
+		timeouts := TimeoutsConfig{}
+
+		for _, d := range c.Defaults {
+			if !d.Matches(r.Name) {
+				continue
+			}
+			excluded = append(excluded, d.ExcludedFields...)
+			for k, v := range d.SetFields {
+				setFields[k] = v
+			}
+			if d.Polling != nil {
+				polling = d.Polling
+			}
+			if d.Timeouts != nil {
+				timeouts.merge(d.Timeouts)
+			}
+		}
+
+		r.ExcludedFields = append(excluded, r.ExcludedFields...)
+		for k, v := range r.SetFields {
+			setFields[k] = v
+		}
+		if len(setFields) > 0 {
+			r.SetFields = setFields
+		}
+		if r.Polling == nil && polling != nil {
+			p := *polling
+			r.Polling = &p
+		}
+		if r.Timeouts != nil {
+			timeouts.merge(r.Timeouts)
+		}
+		if timeouts != (TimeoutsConfig{}) {
+			r.Timeouts = &timeouts
+		}
+	}
+	return nil
+}
+
+// mergeMigrations turns the migrations of each resource into state_upgrades steps. Moves of a version that
+// already has a state upgrade are added to it, and schema_version is raised above the migrated versions.
+func (c *Config) mergeMigrations() error {
+	for i := range c.Resources {
+		r := &c.Resources[i]
+		versions := make(map[int64]bool)
+		for _, m := range r.Migrations {
+			if m.Version < 0 {
+				return fmt.Errorf("resource %s: migrations: version cannot be negative, got %d", r.Name, m.Version)
+			}
+			if versions[m.Version] {
+				return fmt.Errorf("resource %s: migrations: duplicate version %d", r.Name, m.Version)
+			}
+			versions[m.Version] = true
+			for _, from := range sortedKeys(m.Moved) {
+				for _, p := range []string{from, m.Moved[from]} {
+					if p == "" || slices.Contains(strings.Split(p, "."), "") {
+						return fmt.Errorf("resource %s: migrations: version %d: invalid attribute path %q", r.Name, m.Version, p)
+					}
+				}
+			}
+
+			idx := slices.IndexFunc(r.StateUpgrades, func(u StateUpgradeConfig) bool { return u.Version == m.Version })
+			if idx < 0 {
+				r.StateUpgrades = append(r.StateUpgrades, StateUpgradeConfig{Version: m.Version})
+				idx = len(r.StateUpgrades) - 1
+			}
+			u := &r.StateUpgrades[idx]
+			if u.Moved == nil {
+				u.Moved = make(map[string]string)
+			}
+			for _, from := range sortedKeys(m.Moved) {
+				if _, ok := u.Moved[from]; ok {
+					return fmt.Errorf("resource %s: migrations: version %d: %s is also moved by state_upgrades", r.Name, m.Version, from)
+				}
+				u.Moved[from] = m.Moved[from]
+			}
+			r.SchemaVersion = max(r.SchemaVersion, m.Version+1)
+		}
+		r.Migrations = nil
+	}
+	return nil
+}
+
+// merge overrides timeouts with the non-empty values of other
+func (t *TimeoutsConfig) merge(other *TimeoutsConfig) {
+	if other.Create != "" {
+		t.Create = other.Create
+	}
+	if other.Update != "" {
+		t.Update = other.Update
+	}
+	if other.Delete != "" {
+		t.Delete = other.Delete
+	}
+}
+
+// Validate checks that all timeouts are valid durations
+func (t *TimeoutsConfig) Validate() error {
+	values := []struct{ name, value string }{{"create", t.Create}, {"update", t.Update}, {"delete", t.Delete}}
+	for _, v := range values {
+		if v.value == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(v.value); err != nil {
+			return fmt.Errorf("timeouts.%s: %w", v.name, err)
+		}
+	}
+	return nil
+}
+
+// envVarPattern matches ${VAR} and ${VAR:-default} references
+var envVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// expandEnv replaces ${VAR} references in the scalar values of a parsed document with environment
+// variable values. Values are substituted after parsing, so they cannot change the document structure.
+func expandEnv(doc *yaml.Node) error {
+	var missing []string
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		if n.Kind == yaml.ScalarNode {
+			expanded := envVarPattern.ReplaceAllStringFunc(n.Value, func(match string) string {
+				groups := envVarPattern.FindStringSubmatch(match)
+				if value, ok := os.LookupEnv(groups[1]); ok {
+					return value
+				}
+				if strings.Contains(match, ":-") {
+					return groups[2]
+				}
+				missing = append(missing, groups[1])
+				return match
+			})
+			if expanded != n.Value {
+				n.Value = expanded
+				// Unquoted values are resolved again, so numbers and booleans keep their type
+				if n.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+					n.Tag = ""
+				}
+			}
+		}
+		for _, c := range n.Content {
+			walk(c)
+		}
+	}
+	walk(doc)
+	if len(missing) > 0 {
+		return fmt.Errorf("environment variable %s is not set", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Validate checks if the polling configuration is valid
+func (p *PollingConfig) Validate() error {
+	if p.Interval != "" {
+		d, err := time.ParseDuration(p.Interval)
+		if err != nil {
+			return fmt.Errorf("polling.interval: %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("polling.interval must be positive")
+		}
+	}
+	if p.MaxAttempts < 0 {
+		return fmt.Errorf("polling.max_attempts cannot be negative")
+	}
+	switch p.Backoff {
+	case "", BackoffExponential, BackoffConstant:
+	default:
+		return fmt.Errorf("polling.backoff must be %q or %q, got %q", BackoffExponential, BackoffConstant, p.Backoff)
+	}
+	return nil
+}
+
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
 	if c.Generator.OpenAPISchema == "" {
@@ -143,9 +785,30 @@ func (c *Config) Validate() error {
 	if c.Generator.ProviderName == "" {
 		return fmt.Errorf("provider_name is required")
 	}
+	if err := validateProviderAttributes(c.Generator.ProviderAttributes); err != nil {
+		return err
+	}
+	if err := validateUnionStrategy(c.Generator.UnionStrategy); err != nil {
+		return fmt.Errorf("union_strategy: %w", err)
+	}
+	switch c.Generator.CircularRefs {
+	case "", CircularRefsTruncate, CircularRefsURL:
+	default:
+		return fmt.Errorf("circular_refs: must be %q or %q, got %q", CircularRefsTruncate, CircularRefsURL, c.Generator.CircularRefs)
+	}
+	if err := validateDynamicObjects(c.Generator.DynamicObjects); err != nil {
+		return fmt.Errorf("dynamic_objects: %w", err)
+	}
+	if err := validateFieldPatterns(c.Generator.ExcludedFields); err != nil {
+		return err
+	}
+	if err := validateTypeCoercions(c.Generator.TypeCoercions); err != nil {
+		return fmt.Errorf("type_coercions: %w", err)
+	}
 
 	// Check for duplicate resource names
 	resourceNames := make(map[string]bool)
+	aliasOwners := make(map[string]string)
 	for _, r := range c.Resources {
 		if r.Name == "" {
 			return fmt.Errorf("resource name cannot be empty")
@@ -156,6 +819,147 @@ func (c *Config) Validate() error {
 		if resourceNames[r.Name] {
 			return fmt.Errorf("duplicate resource name: %s", r.Name)
 		}
+		for _, alias := range r.Aliases {
+			if alias == "" {
+				return fmt.Errorf("resource %s: alias cannot be empty", r.Name)
+			}
+			if aliasOwners[alias] != "" {
+				return fmt.Errorf("resource %s: alias %s is already used by resource %s", r.Name, alias, aliasOwners[alias])
+			}
+			aliasOwners[alias] = r.Name
+		}
+		if r.Polling != nil {
+			if err := r.Polling.Validate(); err != nil {
+				return fmt.Errorf("resource %s: %w", r.Name, err)
+			}
+		}
+		if r.Timeouts != nil {
+			if err := r.Timeouts.Validate(); err != nil {
+				return fmt.Errorf("resource %s: %w", r.Name, err)
+			}
+		}
+		if r.DeleteOperation != nil {
+			if r.DeleteOperation.OperationID == "" {
+				return fmt.Errorf("resource %s: delete_operation.operation_id cannot be empty", r.Name)
+			}
+			if r.Plugin != "" || r.LinkOp != "" {
+				return fmt.Errorf("resource %s: delete_operation is only supported by standard resources", r.Name)
+			}
+		}
+		if r.IDField != "" && r.IDField != "uuid" {
+			if r.Plugin != "" || r.LinkOp != "" {
+				return fmt.Errorf("resource %s: id_field is only supported by standard resources", r.Name)
+			}
+			if len(r.CompositeKeys) > 0 {
+				return fmt.Errorf("resource %s: id_field cannot be combined with composite_keys", r.Name)
+			}
+		}
+		if r.Validators != nil {
+			if err := r.Validators.Validate(); err != nil {
+				return fmt.Errorf("resource %s: %w", r.Name, err)
+			}
+		}
+		if err := validateWriteOnly(r.SetFields); err != nil {
+			return fmt.Errorf("resource %s: %w", r.Name, err)
+		}
+		if err := validateFieldStrategies(r.SetFields); err != nil {
+			return fmt.Errorf("resource %s: %w", r.Name, err)
+		}
+		if err := validateFieldPatterns(r.ExcludedFields); err != nil {
+			return fmt.Errorf("resource %s: %w", r.Name, err)
+		}
+		if err := validateStateUpgrades(r.SchemaVersion, r.StateUpgrades); err != nil {
+			return fmt.Errorf("resource %s: %w", r.Name, err)
+		}
+		if r.BulkOperation != "" && r.Plugin != "bulk" {
+			return fmt.Errorf("resource %s: bulk_operation is only supported by bulk resources", r.Name)
+		}
+		if r.Members != nil {
+			if err := validateLinkMembers(&r); err != nil {
+				return fmt.Errorf("resource %s: %w", r.Name, err)
+			}
+		}
+		if len(r.Steps) > 0 && (r.Plugin != "" || r.LinkOp != "") {
+			return fmt.Errorf("resource %s: steps are only supported by standard resources", r.Name)
+		}
+		if r.DeletionProtection && ((r.Plugin != "" && r.Plugin != "order") || r.LinkOp != "") {
+			return fmt.Errorf("resource %s: deletion_protection is only supported by standard and order resources", r.Name)
+		}
+		if len(r.ActionTriggers) > 0 && ((r.Plugin != "" && r.Plugin != "order") || r.LinkOp != "") {
+			return fmt.Errorf("resource %s: action_triggers are only supported by standard and order resources", r.Name)
+		}
+		triggers := make(map[string]bool)
+		for _, trigger := range r.ActionTriggers {
+			if !slices.Contains(r.Actions, trigger) {
+				return fmt.Errorf("resource %s: action trigger %s is not one of the actions", r.Name, trigger)
+			}
+			if trigger == "unlink" {
+				return fmt.Errorf("resource %s: unlink cannot be an action trigger, as it removes the resource from Waldur", r.Name)
+			}
+			if triggers[trigger] {
+				return fmt.Errorf("resource %s: duplicate action trigger: %s", r.Name, trigger)
+			}
+			triggers[trigger] = true
+		}
+		if len(r.Preflight) > 0 && ((r.Plugin != "" && r.Plugin != "order") || r.LinkOp != "") {
+			return fmt.Errorf("resource %s: preflight checks are only supported by standard and order resources", r.Name)
+		}
+		for _, check := range r.Preflight {
+			if check.Operation == "" {
+				return fmt.Errorf("resource %s: preflight checks require an operation", r.Name)
+			}
+			if check.Message != "" && check.SuccessField == "" {
+				return fmt.Errorf("resource %s: preflight check %s: message requires success_field", r.Name, check.Operation)
+			}
+		}
+		if len(r.NestedOperations) > 0 && ((r.Plugin != "" && r.Plugin != "order") || r.LinkOp != "") {
+			return fmt.Errorf("resource %s: nested operations are only supported by standard and order resources", r.Name)
+		}
+		for attr, ops := range r.NestedOperations {
+			if ops.Add == "" || ops.Remove == "" {
+				return fmt.Errorf("resource %s: nested operations of %s require add and remove", r.Name, attr)
+			}
+			for _, action := range r.UpdateActions {
+				if action.Param == attr {
+					return fmt.Errorf("resource %s: nested operations of %s conflict with update action %s", r.Name, attr, action.Operation)
+				}
+			}
+		}
+		if len(r.OfferingTypes) > 0 {
+			if r.Plugin != "order" {
+				return fmt.Errorf("resource %s: offering_types are only supported by order resources", r.Name)
+			}
+			if r.OfferingType != "" {
+				return fmt.Errorf("resource %s: offering_type and offering_types are mutually exclusive", r.Name)
+			}
+			seen := make(map[string]bool)
+			for _, offeringType := range r.OfferingTypes {
+				if seen[offeringType] {
+					return fmt.Errorf("resource %s: duplicate offering type: %s", r.Name, offeringType)
+				}
+				seen[offeringType] = true
+			}
+		}
+		virtualNames := make(map[string]bool)
+		for _, v := range r.VirtualFields {
+			if v.Name == "" || v.Expression == "" {
+				return fmt.Errorf("resource %s: virtual_fields require name and expression", r.Name)
+			}
+			if virtualNames[v.Name] {
+				return fmt.Errorf("resource %s: duplicate virtual field: %s", r.Name, v.Name)
+			}
+			virtualNames[v.Name] = true
+		}
+		stepNames := make(map[string]bool)
+		for _, step := range r.Steps {
+			if step.Name == "" || step.Operation == "" {
+				return fmt.Errorf("resource %s: steps require name and operation", r.Name)
+			}
+			if stepNames[step.Name] {
+				return fmt.Errorf("resource %s: duplicate step name: %s", r.Name, step.Name)
+			}
+			stepNames[step.Name] = true
+		}
 		resourceNames[r.Name] = true
 	}
 
@@ -171,8 +975,302 @@ func (c *Config) Validate() error {
 		if dataSourceNames[d.Name] {
 			return fmt.Errorf("duplicate data source name: %s", d.Name)
 		}
+		if d.ResourceRef != "" && !resourceNames[d.ResourceRef] {
+			return fmt.Errorf("data source %s: resource_ref %s does not match any resource", d.Name, d.ResourceRef)
+		}
+		if d.List && d.Ephemeral {
+			return fmt.Errorf("data source %s: list cannot be used with ephemeral", d.Name)
+		}
 		dataSourceNames[d.Name] = true
 	}
 
+	return c.validateNaming(resourceNames, dataSourceNames)
+}
+
+var (
+	typeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	packagePattern  = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+)
+
+// validateNaming checks that naming rules produce valid, unique Terraform type names and packages
+func (c *Config) validateNaming(resourceNames, dataSourceNames map[string]bool) error {
+	n := c.Naming
+	if n.Prefix != "" && !typeNamePattern.MatchString(n.Prefix) {
+		return fmt.Errorf("naming: invalid prefix %q", n.Prefix)
+	}
+	for _, prefix := range sortedKeys(n.Services) {
+		if prefix == "" || !packagePattern.MatchString(n.Services[prefix]) {
+			return fmt.Errorf("naming: invalid service mapping %q: %q", prefix, n.Services[prefix])
+		}
+	}
+	for _, word := range sortedKeys(n.Plurals) {
+		if !typeNamePattern.MatchString(word) || strings.Contains(word, "_") || !typeNamePattern.MatchString(n.Plurals[word]) {
+			return fmt.Errorf("naming: invalid plural %q: %q", word, n.Plurals[word])
+		}
+	}
+	for _, name := range sortedKeys(n.TypeNames) {
+		if !resourceNames[name] && !dataSourceNames[name] {
+			return fmt.Errorf("naming: type_names entry %s does not match any resource or data source", name)
+		}
+		if !typeNamePattern.MatchString(n.TypeNames[name]) {
+			return fmt.Errorf("naming: invalid type name %q for %s", n.TypeNames[name], name)
+		}
+	}
+
+	resourceTypes := make(map[string]string)
+	for _, r := range c.Resources {
+		typeName := n.TypeName(r.Name)
+		if owner, ok := resourceTypes[typeName]; ok {
+			return fmt.Errorf("resource %s: type name %s is already used by resource %s", r.Name, typeName, owner)
+		}
+		resourceTypes[typeName] = r.Name
+	}
+	for _, r := range c.Resources {
+		for _, alias := range r.Aliases {
+			if owner, ok := resourceTypes[alias]; ok {
+				return fmt.Errorf("resource %s: alias %s conflicts with an existing resource %s", r.Name, alias, owner)
+			}
+		}
+	}
+
+	dataSourceTypes := make(map[string]string)
+	for _, d := range c.DataSources {
+		typeName := n.TypeName(d.Name)
+		if owner, ok := dataSourceTypes[typeName]; ok {
+			return fmt.Errorf("data source %s: type name %s is already used by data source %s", d.Name, typeName, owner)
+		}
+		dataSourceTypes[typeName] = d.Name
+	}
+	for _, d := range c.DataSources {
+		if !d.List {
+			continue
+		}
+		typeName := d.ListTypeName(n)
+		if owner, ok := dataSourceTypes[typeName]; ok {
+			return fmt.Errorf("data source %s: list type name %s is already used by data source %s", d.Name, typeName, owner)
+		}
+		dataSourceTypes[typeName] = d.Name
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of a string map in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateProviderAttributes checks that extra provider attributes are unique and map to a header
+func validateProviderAttributes(attrs []ProviderAttributeConfig) error {
+	names := make(map[string]bool)
+	for _, a := range attrs {
+		if a.Name == "" {
+			return fmt.Errorf("provider attribute name cannot be empty")
+		}
+		if a.Name == "endpoint" || a.Name == "token" || a.Name == "max_retries" || a.Name == "rate_limit" ||
+			a.Name == "max_concurrent_requests" || a.Name == "default_project" || a.Name == "default_customer" {
+			return fmt.Errorf("provider attribute %s is reserved", a.Name)
+		}
+		if names[a.Name] {
+			return fmt.Errorf("duplicate provider attribute: %s", a.Name)
+		}
+		if a.Header == "" {
+			return fmt.Errorf("provider attribute %s: header is required", a.Name)
+		}
+		names[a.Name] = true
+	}
+	return nil
+}
+
+// validateWriteOnly checks that write-only overrides target top-level, non-computed fields
+func validateWriteOnly(fields map[string]FieldConfig) error {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := fields[name]
+		if !f.WriteOnly {
+			continue
+		}
+		if f.Computed {
+			return fmt.Errorf("field %s: write_only cannot be combined with computed", name)
+		}
+		if strings.Contains(name, ".") {
+			return fmt.Errorf("field %s: write_only is only supported on top-level fields", name)
+		}
+	}
+	return nil
+}
+
+// validateUnionStrategy checks that a union strategy is one of the supported values
+func validateUnionStrategy(strategy string) error {
+	switch strategy {
+	case "", UnionFirst, UnionMerge, UnionDiscriminator:
+		return nil
+	}
+	return fmt.Errorf("must be %q, %q or %q, got %q", UnionFirst, UnionMerge, UnionDiscriminator, strategy)
+}
+
+// validateDynamicObjects checks that a dynamic object strategy is one of the supported values
+func validateDynamicObjects(strategy string) error {
+	switch strategy {
+	case "", DynamicObjectsMap, DynamicObjectsJSON:
+		return nil
+	}
+	return fmt.Errorf("must be %q or %q, got %q", DynamicObjectsMap, DynamicObjectsJSON, strategy)
+}
+
+// validateNormalize checks that a normalization is one of the supported values
+func validateNormalize(normalize string) error {
+	switch normalize {
+	case "", NormalizeURL, NormalizeCaseInsensitive, NormalizeWhitespaceInsensitive, NormalizeRFC3339, NormalizeNone:
+		return nil
+	}
+	return fmt.Errorf("must be %q, %q, %q, %q or %q, got %q", NormalizeURL, NormalizeCaseInsensitive, NormalizeWhitespaceInsensitive, NormalizeRFC3339, NormalizeNone, normalize)
+}
+
+// validatePrefer checks that a type conflict resolution is one of the supported values
+func validatePrefer(prefer string) error {
+	switch prefer {
+	case "", PreferRequest, PreferResponse, PreferExpand:
+		return nil
+	}
+	return fmt.Errorf("must be %q, %q or %q, got %q", PreferRequest, PreferResponse, PreferExpand, prefer)
+}
+
+// validateTransform checks that a transformation is one of the supported values
+func validateTransform(transform string) error {
+	switch transform {
+	case "", TransformMBToGB, TransformLowercase, TransformStripURLToUUID:
+		return nil
+	}
+	return fmt.Errorf("must be %q, %q or %q, got %q", TransformMBToGB, TransformLowercase, TransformStripURLToUUID, transform)
+}
+
+// validateOnMissing checks that the handling of missing fields is one of the supported values
+func validateOnMissing(onMissing string) error {
+	switch onMissing {
+	case "", OnMissingKeep, OnMissingNull:
+		return nil
+	}
+	return fmt.Errorf("must be %q or %q, got %q", OnMissingKeep, OnMissingNull, onMissing)
+}
+
+// validateTypeCoercions checks that fields are coerced into scalar OpenAPI types
+func validateTypeCoercions(coercions map[string]string) error {
+	for _, name := range sortedKeys(coercions) {
+		switch coercions[name] {
+		case "string", "integer", "number", "boolean":
+		default:
+			return fmt.Errorf("field %s: must be \"string\", \"integer\", \"number\" or \"boolean\", got %q", name, coercions[name])
+		}
+	}
+	return nil
+}
+
+// validateFieldStrategies checks the union, dynamic object, normalization, conflict and transformation strategies of field overrides
+func validateFieldStrategies(fields map[string]FieldConfig) error {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := validateUnionStrategy(fields[name].Union); err != nil {
+			return fmt.Errorf("field %s: union %w", name, err)
+		}
+		if err := validateDynamicObjects(fields[name].DynamicObject); err != nil {
+			return fmt.Errorf("field %s: dynamic_object %w", name, err)
+		}
+		if err := validateNormalize(fields[name].Normalize); err != nil {
+			return fmt.Errorf("field %s: normalize %w", name, err)
+		}
+		if err := validatePrefer(fields[name].Prefer); err != nil {
+			return fmt.Errorf("field %s: prefer %w", name, err)
+		}
+		if err := validateTransform(fields[name].Transform); err != nil {
+			return fmt.Errorf("field %s: transform %w", name, err)
+		}
+		if fields[name].Transform != "" && strings.Contains(name, ".") {
+			return fmt.Errorf("field %s: transform is only supported on top-level fields", name)
+		}
+		if fields[name].Reference != "" && strings.Contains(name, ".") {
+			return fmt.Errorf("field %s: reference is only supported on top-level fields", name)
+		}
+		if fields[name].AsJSON && strings.Contains(name, ".") {
+			return fmt.Errorf("field %s: as_json is only supported on top-level fields", name)
+		}
+		if fields[name].AllowMissing && strings.Contains(name, ".") {
+			return fmt.Errorf("field %s: allow_missing is only supported on top-level fields", name)
+		}
+		if err := validateOnMissing(fields[name].OnMissing); err != nil {
+			return fmt.Errorf("field %s: on_missing %w", name, err)
+		}
+		if fields[name].OnMissing != "" && strings.Contains(name, ".") {
+			return fmt.Errorf("field %s: on_missing is only supported on top-level fields", name)
+		}
+		for _, key := range fields[name].Keys {
+			if key == "" || strings.Contains(key, ".") {
+				return fmt.Errorf("field %s: keys must name attributes of the set elements, got %q", name, key)
+			}
+		}
+		if len(fields[name].Keys) > 0 && fields[name].Set != nil && !*fields[name].Set {
+			return fmt.Errorf("field %s: keys cannot be combined with set: false", name)
+		}
+	}
+	return nil
+}
+
+// MatchFieldPattern reports whether an excluded_fields entry matches a field. Entries without a dot
+// match the plain field name at any nesting level, entries with a dot match the dotted path.
+// Entries are glob patterns (e.g., "marketplace_*") or regular expressions between slashes
+// (e.g., "/_error_traceback$/"), which match the field name or the dotted path.
+func MatchFieldPattern(pattern, fieldPath, name string) bool {
+	if expr, ok := fieldRegexp(pattern); ok {
+		re, err := regexp.Compile(expr)
+		return err == nil && (re.MatchString(name) || re.MatchString(fieldPath))
+	}
+	target := name
+	if strings.Contains(pattern, ".") {
+		target = fieldPath
+	}
+	ok, err := path.Match(pattern, target)
+	return err == nil && ok
+}
+
+// IsFieldPattern reports whether an excluded_fields entry is a glob pattern or a regular expression
+// rather than a plain field name or path
+func IsFieldPattern(pattern string) bool {
+	_, ok := fieldRegexp(pattern)
+	return ok || strings.ContainsAny(pattern, `*?[\`)
+}
+
+// fieldRegexp returns the regular expression of an entry written between slashes
+func fieldRegexp(pattern string) (string, bool) {
+	if len(pattern) > 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		return pattern[1 : len(pattern)-1], true
+	}
+	return "", false
+}
+
+// validateFieldPatterns checks that excluded_fields entries are valid patterns
+func validateFieldPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if expr, ok := fieldRegexp(pattern); ok {
+			if _, err := regexp.Compile(expr); err != nil {
+				return fmt.Errorf("excluded_fields: invalid regular expression %q: %w", pattern, err)
+			}
+		} else if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("excluded_fields: invalid pattern %q: %w", pattern, err)
+		}
+	}
 	return nil
 }
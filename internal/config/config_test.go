@@ -3,7 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -101,47 +104,1393 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			err := tt.config.Validate()
-			if (err != nil) != tt.wantErr {
-				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
-	}
-}
-
-func TestOperationIDs(t *testing.T) {
-	resource := Resource{
-		Name:            "structure_project",
-		BaseOperationID: "projects",
-	}
-
-	ops := resource.OperationIDs()
-
-	expected := map[string]string{
-		"List":          "projects_list",
-		"Create":        "projects_create",
-		"Retrieve":      "projects_retrieve",
-		"PartialUpdate": "projects_partial_update",
-		"Destroy":       "projects_destroy",
-	}
-
-	if ops.List != expected["List"] {
-		t.Errorf("Expected List='%s', got '%s'", expected["List"], ops.List)
-	}
-	if ops.Create != expected["Create"] {
-		t.Errorf("Expected Create='%s', got '%s'", expected["Create"], ops.Create)
-	}
-	if ops.Retrieve != expected["Retrieve"] {
-		t.Errorf("Expected Retrieve='%s', got '%s'", expected["Retrieve"], ops.Retrieve)
-	}
-	if ops.PartialUpdate != expected["PartialUpdate"] {
-		t.Errorf("Expected PartialUpdate='%s', got '%s'", expected["PartialUpdate"], ops.PartialUpdate)
-	}
-	if ops.Destroy != expected["Destroy"] {
-		t.Errorf("Expected Destroy='%s', got '%s'", expected["Destroy"], ops.Destroy)
+		{
+			name: "valid polling config",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_volume", BaseOperationID: "openstack_volumes", Polling: &PollingConfig{Interval: "5s", MaxAttempts: 10, Backoff: BackoffConstant}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid polling interval",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_volume", BaseOperationID: "openstack_volumes", Polling: &PollingConfig{Interval: "soon"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "steps on order resource",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_tenant", BaseOperationID: "openstack_tenants", Plugin: "order", Steps: []StepConfig{{Name: "network", Operation: "openstack_tenants_create_network"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bulk resource",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "marketplace_course_accounts_bulk", BaseOperationID: "marketplace_course_accounts", Plugin: "bulk", BulkOperation: "marketplace_course_accounts_create_bulk"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "bulk operation on standard resource",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "marketplace_course_account", BaseOperationID: "marketplace_course_accounts", BulkOperation: "marketplace_course_accounts_create_bulk"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate step name",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_network", BaseOperationID: "openstack_networks", Steps: []StepConfig{
+						{Name: "subnet", Operation: "openstack_networks_create_subnet"},
+						{Name: "subnet", Operation: "openstack_networks_create_subnet"},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "delete operation without operation id",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_tenant", BaseOperationID: "openstack_tenants", DeleteOperation: &DeleteOperationConfig{Params: map[string]string{"force": "force"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "computed write-only field",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_instance", BaseOperationID: "openstack_instances", SetFields: map[string]FieldConfig{"user_password": {WriteOnly: true, Computed: true}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nested write-only field",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_instance", BaseOperationID: "openstack_instances", SetFields: map[string]FieldConfig{"ports.password": {WriteOnly: true}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "id field with composite keys",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_volume", BaseOperationID: "openstack_volumes", IDField: "backend_id", CompositeKeys: []string{"tenant", "name"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "virtual field without expression",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_instance", BaseOperationID: "openstack_instances", VirtualFields: []VirtualFieldConfig{{Name: "fixed_ip"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validator group with a single attribute",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_instance", BaseOperationID: "openstack_instances", Validators: &ValidatorsConfig{ConflictsWith: [][]string{{"image"}}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "data source referencing unknown resource",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				DataSources: []DataSource{
+					{Name: "structure_project_lookup", BaseOperationID: "projects", ResourceRef: "structure_project"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "list data source",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				DataSources: []DataSource{
+					{Name: "openstack_flavor", BaseOperationID: "openstack_flavors", List: true},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ephemeral list data source",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				DataSources: []DataSource{
+					{Name: "structure_project", BaseOperationID: "projects", List: true, Ephemeral: true},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "list data source conflicts with data source",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				DataSources: []DataSource{
+					{Name: "openstack_flavor", BaseOperationID: "openstack_flavors", List: true},
+					{Name: "openstack_flavors", BaseOperationID: "openstack_flavors"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "alias conflicts with resource",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "structure_project", BaseOperationID: "projects", Aliases: []string{"structure_customer"}},
+					{Name: "structure_customer", BaseOperationID: "customers"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid provider attributes",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+					ProviderAttributes: []ProviderAttributeConfig{
+						{Name: "impersonated_user", Header: "X-Impersonated-User-Uuid", EnvVar: "WALDUR_IMPERSONATED_USER"},
+						{Name: "api_version", Header: "X-Api-Version"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "provider attribute without header",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema:      "schema.yaml",
+					ProviderName:       "waldur",
+					ProviderAttributes: []ProviderAttributeConfig{{Name: "api_version"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reserved provider attribute",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema:      "schema.yaml",
+					ProviderName:       "waldur",
+					ProviderAttributes: []ProviderAttributeConfig{{Name: "token", Header: "X-Token"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reserved retry provider attribute",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema:      "schema.yaml",
+					ProviderName:       "waldur",
+					ProviderAttributes: []ProviderAttributeConfig{{Name: "max_retries", Header: "X-Max-Retries"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid naming",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Naming: NamingConfig{
+					Services:  map[string]string{"marketplace_public": "marketplace"},
+					TypeNames: map[string]string{"marketplace_public_offering": "marketplace_offering"},
+				},
+				Resources: []Resource{
+					{Name: "marketplace_public_offering", BaseOperationID: "marketplace_public_offerings", Aliases: []string{"marketplace_public_offering"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "naming type name for unknown resource",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Naming: NamingConfig{
+					TypeNames: map[string]string{"marketplace_offering": "offering"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "naming produces duplicate type names",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Naming: NamingConfig{
+					TypeNames: map[string]string{"structure_project": "structure_customer"},
+				},
+				Resources: []Resource{
+					{Name: "structure_project", BaseOperationID: "projects"},
+					{Name: "structure_customer", BaseOperationID: "customers"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "naming invalid plural",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Naming: NamingConfig{
+					Plurals: map[string]string{"floating_ip": "floating_ips"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "naming invalid service package",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Naming: NamingConfig{
+					Services: map[string]string{"marketplace_public": "Marketplace-Public"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid union strategy",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+					UnionStrategy: "flatten",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid circular refs",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+					CircularRefs:  "expand",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid dynamic objects",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema:  "schema.yaml",
+					ProviderName:   "waldur",
+					DynamicObjects: "string",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid field dynamic object",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "marketplace_resource",
+						BaseOperationID: "marketplace_resources",
+						SetFields:       map[string]FieldConfig{"attributes": {DynamicObject: "yaml"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "field normalizations",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "openstack_tenant",
+						BaseOperationID: "openstack_tenants",
+						SetFields: map[string]FieldConfig{
+							"access_url": {Normalize: NormalizeURL},
+							"name":       {Normalize: NormalizeCaseInsensitive},
+							"created":    {Normalize: NormalizeNone},
+							"project":    {Prefer: PreferExpand},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid field normalization",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "openstack_tenant",
+						BaseOperationID: "openstack_tenants",
+						SetFields:       map[string]FieldConfig{"access_url": {Normalize: "trailing_slash"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "field transformations",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "openstack_instance",
+						BaseOperationID: "openstack_instances",
+						SetFields: map[string]FieldConfig{
+							"ram":   {Transform: TransformMBToGB},
+							"name":  {Transform: TransformLowercase},
+							"image": {Transform: TransformStripURLToUUID},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid field transformation",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "openstack_instance",
+						BaseOperationID: "openstack_instances",
+						SetFields:       map[string]FieldConfig{"ram": {Transform: "mb_to_tb"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nested field transformation",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "openstack_instance",
+						BaseOperationID: "openstack_instances",
+						SetFields:       map[string]FieldConfig{"volumes.size": {Transform: TransformMBToGB}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nested field reference",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "openstack_instance",
+						BaseOperationID: "openstack_instances",
+						SetFields:       map[string]FieldConfig{"ports.subnet": {Reference: "openstack_subnets_retrieve"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nested field allow_missing",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "openstack_instance",
+						BaseOperationID: "openstack_instances",
+						SetFields:       map[string]FieldConfig{"ports.subnet": {AllowMissing: true}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid on_missing",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "openstack_instance",
+						BaseOperationID: "openstack_instances",
+						SetFields:       map[string]FieldConfig{"runtime_state": {OnMissing: "skip"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "set keys on a list",
+			config: func() *Config {
+				list := false
+				return &Config{
+					Generator: GeneratorConfig{
+						OpenAPISchema: "schema.yaml",
+						ProviderName:  "waldur",
+					},
+					Resources: []Resource{
+						{
+							Name:            "openstack_security_group",
+							BaseOperationID: "openstack_security_groups",
+							SetFields:       map[string]FieldConfig{"rules": {Set: &list, Keys: []string{"protocol"}}},
+						},
+					},
+				}
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "nested field as_json",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "marketplace_offering",
+						BaseOperationID: "marketplace_provider_offerings",
+						SetFields:       map[string]FieldConfig{"options.order": {AsJSON: true}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "state upgrades",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "openstack_instance",
+						BaseOperationID: "openstack_instances",
+						SchemaVersion:   2,
+						StateUpgrades: []StateUpgradeConfig{
+							{Version: 0, Renamed: map[string]string{"flavor_name": "flavor"}},
+							{Version: 1, Renamed: map[string]string{"rules.port": "port_range"}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "state upgrade of the current version",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "openstack_instance",
+						BaseOperationID: "openstack_instances",
+						SchemaVersion:   1,
+						StateUpgrades:   []StateUpgradeConfig{{Version: 1}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "state upgrade renaming to a path",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "openstack_instance",
+						BaseOperationID: "openstack_instances",
+						SchemaVersion:   1,
+						StateUpgrades:   []StateUpgradeConfig{{Version: 0, Renamed: map[string]string{"flavor_name": "flavor.name"}}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "state upgrades moving attributes",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "openstack_instance",
+						BaseOperationID: "openstack_instances",
+						SchemaVersion:   2,
+						StateUpgrades: []StateUpgradeConfig{
+							{Version: 0, Renamed: map[string]string{"flavor_name": "flavor"}},
+							{Version: 1, Moved: map[string]string{"cores": "limits.cores", "rules.port": "rules.port_range"}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "state upgrade moving to an invalid path",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "openstack_instance",
+						BaseOperationID: "openstack_instances",
+						SchemaVersion:   1,
+						StateUpgrades:   []StateUpgradeConfig{{Version: 0, Moved: map[string]string{"cores": "limits..cores"}}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid field conflict resolution",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "openstack_port",
+						BaseOperationID: "openstack_ports",
+						SetFields:       map[string]FieldConfig{"tenant": {Prefer: "primary"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "excluded field patterns",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema:  "schema.yaml",
+					ProviderName:   "waldur",
+					ExcludedFields: []string{"created", "marketplace_*", "/_error_traceback$/"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid excluded field regexp",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "marketplace_resource",
+						BaseOperationID: "marketplace_resources",
+						ExcludedFields:  []string{"/backend_(/"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid type coercion",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+					TypeCoercions: map[string]string{"total": "number", "prices": "map"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "field union override",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+					UnionStrategy: UnionMerge,
+				},
+				Resources: []Resource{
+					{
+						Name:            "marketplace_offering",
+						BaseOperationID: "marketplace_provider_offerings",
+						SetFields:       map[string]FieldConfig{"attributes": {Union: UnionDiscriminator}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid field union",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{
+						Name:            "marketplace_offering",
+						BaseOperationID: "marketplace_provider_offerings",
+						SetFields:       map[string]FieldConfig{"attributes": {Union: "oneof"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid polling backoff",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_volume", BaseOperationID: "openstack_volumes", Polling: &PollingConfig{Backoff: "linear"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "deletion protection of order resource",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_tenant", BaseOperationID: "openstack_tenants", Plugin: "order", DeletionProtection: true},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "deletion protection of link resource",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_volume_attachment", BaseOperationID: "openstack_volumes", Plugin: "link", DeletionProtection: true},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "action triggers",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_tenant", BaseOperationID: "openstack_tenants", Plugin: "order", Actions: []string{"pull", "unlink"}, ActionTriggers: []string{"pull"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "action trigger of unknown action",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_tenant", BaseOperationID: "openstack_tenants", Plugin: "order", Actions: []string{"pull"}, ActionTriggers: []string{"restart"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "preflight check",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_tenant", BaseOperationID: "openstack_tenants", Plugin: "order", Preflight: []PreflightCheck{
+						{Operation: "marketplace_provider_offerings_check_unique_backend_id", Parent: "offering", Params: map[string]string{"backend_id": "backend_id"}, SuccessField: "is_unique", Message: "backend_id is taken"},
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "preflight message without success field",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_tenant", BaseOperationID: "openstack_tenants", Plugin: "order", Preflight: []PreflightCheck{
+						{Operation: "marketplace_provider_offerings_check_unique_backend_id", Message: "backend_id is taken"},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nested operations",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "marketplace_provider_offering", BaseOperationID: "marketplace_provider_offerings", NestedOperations: map[string]NestedOperationsConfig{
+						"endpoints": {Add: "marketplace_provider_offerings_add_endpoint", Remove: "marketplace_provider_offerings_delete_endpoint"},
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "nested operations without remove operation",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "marketplace_provider_offering", BaseOperationID: "marketplace_provider_offerings", NestedOperations: map[string]NestedOperationsConfig{
+						"endpoints": {Add: "marketplace_provider_offerings_add_endpoint"},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "offering types",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "marketplace_resource", BaseOperationID: "marketplace_resources", Plugin: "order", OfferingTypes: []string{"OpenStack.Instance", "OpenStack.Volume"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "offering types with offering type",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "marketplace_resource", BaseOperationID: "marketplace_resources", Plugin: "order", OfferingType: "OpenStack.Instance", OfferingTypes: []string{"OpenStack.Volume"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "link members",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_instance_volumes", BaseOperationID: "openstack_volumes", LinkOp: "openstack_volumes_attach", UnlinkOp: "openstack_volumes_detach",
+						Source: &LinkResourceConfig{Param: "volume"}, Target: &LinkResourceConfig{Param: "instance", RetrieveOp: "openstack_instances_retrieve"},
+						Members: &LinkMembersConfig{Attribute: "volumes", Key: "volumes"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "link members without target retrieve operation",
+			config: &Config{
+				Generator: GeneratorConfig{
+					OpenAPISchema: "schema.yaml",
+					ProviderName:  "waldur",
+				},
+				Resources: []Resource{
+					{Name: "openstack_instance_volumes", BaseOperationID: "openstack_volumes", LinkOp: "openstack_volumes_attach", UnlinkOp: "openstack_volumes_detach",
+						Source: &LinkResourceConfig{Param: "volume"}, Target: &LinkResourceConfig{Param: "instance"},
+						Members: &LinkMembersConfig{Attribute: "volumes", Key: "volumes"}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOperationIDs(t *testing.T) {
+	resource := Resource{
+		Name:            "structure_project",
+		BaseOperationID: "projects",
+	}
+
+	ops := resource.OperationIDs()
+
+	expected := map[string]string{
+		"List":          "projects_list",
+		"Create":        "projects_create",
+		"Retrieve":      "projects_retrieve",
+		"PartialUpdate": "projects_partial_update",
+		"Destroy":       "projects_destroy",
+	}
+
+	if ops.List != expected["List"] {
+		t.Errorf("Expected List='%s', got '%s'", expected["List"], ops.List)
+	}
+	if ops.Create != expected["Create"] {
+		t.Errorf("Expected Create='%s', got '%s'", expected["Create"], ops.Create)
+	}
+	if ops.Retrieve != expected["Retrieve"] {
+		t.Errorf("Expected Retrieve='%s', got '%s'", expected["Retrieve"], ops.Retrieve)
+	}
+	if ops.PartialUpdate != expected["PartialUpdate"] {
+		t.Errorf("Expected PartialUpdate='%s', got '%s'", expected["PartialUpdate"], ops.PartialUpdate)
+	}
+	if ops.Destroy != expected["Destroy"] {
+		t.Errorf("Expected Destroy='%s', got '%s'", expected["Destroy"], ops.Destroy)
+	}
+}
+
+func TestPluralize(t *testing.T) {
+	tests := map[string]string{
+		"offering":         "offerings",
+		"policy":           "policies",
+		"key":              "keys",
+		"address":          "addresses",
+		"box":              "boxes",
+		"patch":            "patches",
+		"floating_ip":      "floating_ips",
+		"security_policy":  "security_policies",
+		"ip-address":       "ip-addresses",
+		"structure_person": "structure_people",
+		"metadata":         "metadata",
+	}
+	for name, want := range tests {
+		if got := Pluralize(name); got != want {
+			t.Errorf("Pluralize(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestListTypeName(t *testing.T) {
+	n := NamingConfig{
+		TypeNames: map[string]string{"marketplace_public_offering": "marketplace_offering"},
+		Plurals:   map[string]string{"quota": "quota"},
+	}
+	tests := map[string]string{
+		"openstack_flavor":            "openstack_flavors",
+		"marketplace_category_policy": "marketplace_category_policies",
+		"marketplace_public_offering": "marketplace_offerings",
+		"structure_quota":             "structure_quota",
+	}
+	for name, want := range tests {
+		d := DataSource{Name: name}
+		if got := d.ListTypeName(n); got != want {
+			t.Errorf("ListTypeName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestLoadConfigEnvInterpolation(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "test-config.yaml")
+
+	configContent := `generator:
+  openapi_schema: "${WALDUR_TEST_SCHEMA_DIR}/waldur_api.yaml"
+  output_dir: "${WALDUR_TEST_OUTPUT_DIR:-./output}"
+  provider_name: "waldur"
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+
+	t.Setenv("WALDUR_TEST_SCHEMA_DIR", "/schemas")
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Generator.OpenAPISchema != "/schemas/waldur_api.yaml" {
+		t.Errorf("Expected openapi_schema '/schemas/waldur_api.yaml', got '%s'", cfg.Generator.OpenAPISchema)
+	}
+	if cfg.Generator.OutputDir != "./output" {
+		t.Errorf("Expected default output_dir './output', got '%s'", cfg.Generator.OutputDir)
+	}
+
+	t.Setenv("WALDUR_TEST_OUTPUT_DIR", "/tmp/provider")
+	cfg, err = LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Generator.OutputDir != "/tmp/provider" {
+		t.Errorf("Expected output_dir '/tmp/provider', got '%s'", cfg.Generator.OutputDir)
+	}
+
+	os.Unsetenv("WALDUR_TEST_SCHEMA_DIR")
+	if _, err := LoadConfig(configPath); err == nil {
+		t.Error("Expected error for unset environment variable")
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	injected := "a: b # c\n\"d\"\nprovider_name: injected"
+	t.Setenv("WALDUR_TEST_VALUE", injected)
+	t.Setenv("WALDUR_TEST_RETRIES", "5")
+
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			name:  "value with YAML metacharacters",
+			input: "provider_name: ${WALDUR_TEST_VALUE}\n",
+			want:  map[string]any{"provider_name": injected},
+		},
+		{
+			name:  "quoted value with YAML metacharacters",
+			input: "provider_name: \"x-${WALDUR_TEST_VALUE}\"\n",
+			want:  map[string]any{"provider_name": "x-" + injected},
+		},
+		{
+			name:  "default value",
+			input: "output_dir: ${WALDUR_TEST_UNSET:-./output}\n",
+			want:  map[string]any{"output_dir": "./output"},
+		},
+		{
+			name:  "unquoted number keeps its type",
+			input: "max_retries: ${WALDUR_TEST_RETRIES}\n",
+			want:  map[string]any{"max_retries": 5},
+		},
+		{
+			name:  "comments are not expanded, block scalars are expanded as a whole",
+			input: "# ${WALDUR_TEST_UNSET}\ndescription: |\n  ${WALDUR_TEST_RETRIES}\n",
+			want:  map[string]any{"description": "5\n"},
+		},
+		{
+			name:    "undefined variable without default",
+			input:   "provider_name: ${WALDUR_TEST_UNSET}\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc yaml.Node
+			if err := yaml.Unmarshal([]byte(tt.input), &doc); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			err := expandEnv(&doc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expandEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var got map[string]any
+			if err := doc.Decode(&got); err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandEnv() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigDefaults(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "test-config.yaml")
+
+	configContent := `generator:
+  openapi_schema: "schema.yaml"
+  provider_name: "waldur"
+
+defaults:
+  - excluded_fields: ["created"]
+    timeouts:
+      create: 30m
+  - match: "openstack_*"
+    excluded_fields: ["backend_id"]
+    set_fields:
+      tags:
+        set: true
+    polling:
+      interval: 5s
+    timeouts:
+      delete: 1h
+
+resources:
+  - name: "structure_project"
+    base_operation_id: "projects"
+  - name: "openstack_volume"
+    base_operation_id: "openstack_volumes"
+    excluded_fields: ["image"]
+    set_fields:
+      tags:
+        computed: true
+    timeouts:
+      create: 45m
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	project := cfg.Resources[0]
+	if !reflect.DeepEqual(project.ExcludedFields, []string{"created"}) {
+		t.Errorf("Expected project excluded_fields [created], got %v", project.ExcludedFields)
+	}
+	if project.Polling != nil {
+		t.Errorf("Expected no polling for project, got %+v", project.Polling)
+	}
+	if project.Timeouts == nil || project.Timeouts.Create != "30m" {
+		t.Errorf("Expected project create timeout 30m, got %+v", project.Timeouts)
+	}
+
+	volume := cfg.Resources[1]
+	if !reflect.DeepEqual(volume.ExcludedFields, []string{"created", "backend_id", "image"}) {
+		t.Errorf("Expected merged excluded_fields, got %v", volume.ExcludedFields)
+	}
+	if tags := volume.SetFields["tags"]; !tags.Computed || tags.Set != nil {
+		t.Errorf("Expected resource set_fields to override defaults, got %+v", tags)
+	}
+	if volume.Polling == nil || volume.Polling.Interval != "5s" {
+		t.Errorf("Expected polling interval from defaults, got %+v", volume.Polling)
+	}
+	want := TimeoutsConfig{Create: "45m", Delete: "1h"}
+	if volume.Timeouts == nil || *volume.Timeouts != want {
+		t.Errorf("Expected timeouts %+v, got %+v", want, volume.Timeouts)
+	}
+}
+
+func TestLoadConfigMigrations(t *testing.T) {
+	header := `generator:
+  openapi_schema: "schema.yaml"
+  provider_name: "waldur"
+
+resources:
+  - name: "openstack_instance"
+    base_operation_id: "openstack_instances"
+`
+	tests := []struct {
+		name    string
+		config  string
+		want    []StateUpgradeConfig
+		version int64
+		wantErr bool
+	}{
+		{
+			name: "migrations only",
+			config: `    migrations:
+      - version: 0
+        moved:
+          flavor_name: flavor
+      - version: 1
+        moved:
+          cores: limits.cores
+`,
+			want: []StateUpgradeConfig{
+				{Version: 0, Moved: map[string]string{"flavor_name": "flavor"}},
+				{Version: 1, Moved: map[string]string{"cores": "limits.cores"}},
+			},
+			version: 2,
+		},
+		{
+			name: "merged into the state upgrade of the same version",
+			config: `    schema_version: 3
+    state_upgrades:
+      - version: 1
+        renamed:
+          flavor_name: flavor
+    migrations:
+      - version: 1
+        moved:
+          cores: limits.cores
+`,
+			want: []StateUpgradeConfig{
+				{Version: 1, Renamed: map[string]string{"flavor_name": "flavor"}, Moved: map[string]string{"cores": "limits.cores"}},
+			},
+			version: 3,
+		},
+		{
+			name: "duplicate version",
+			config: `    migrations:
+      - version: 0
+        moved:
+          cores: limits.cores
+      - version: 0
+        moved:
+          ram: limits.ram
+`,
+			wantErr: true,
+		},
+		{
+			name: "moved by both",
+			config: `    schema_version: 1
+    state_upgrades:
+      - version: 0
+        moved:
+          cores: limits.cores
+    migrations:
+      - version: 0
+        moved:
+          cores: quotas.cores
+`,
+			wantErr: true,
+		},
+		{
+			name: "invalid path",
+			config: `    migrations:
+      - version: 0
+        moved:
+          cores: limits..cores
+`,
+			wantErr: true,
+		},
+		{
+			name: "negative version",
+			config: `    migrations:
+      - version: -1
+        moved:
+          cores: limits.cores
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "test-config.yaml")
+			if err := os.WriteFile(configPath, []byte(header+tt.config), 0644); err != nil {
+				t.Fatalf("Failed to create test config: %v", err)
+			}
+
+			cfg, err := LoadConfig(configPath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			r := cfg.Resources[0]
+			if !reflect.DeepEqual(r.StateUpgrades, tt.want) {
+				t.Errorf("Expected state_upgrades %+v, got %+v", tt.want, r.StateUpgrades)
+			}
+			if r.SchemaVersion != tt.version {
+				t.Errorf("Expected schema_version %d, got %d", tt.version, r.SchemaVersion)
+			}
+			if r.Migrations != nil {
+				t.Errorf("Expected migrations to be merged, got %+v", r.Migrations)
+			}
+		})
+	}
+}
+
+func TestFilterFeatures(t *testing.T) {
+	newConfig := func() *Config {
+		return &Config{
+			Resources: []Resource{
+				{Name: "structure_project"},
+				{Name: "openstack_server_group", FeatureFlag: "beta"},
+				{Name: "openstack_marketplace", FeatureFlag: "alpha"},
+			},
+			DataSources: []DataSource{
+				{Name: "structure_project"},
+				{Name: "openstack_server_group", FeatureFlag: "beta"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		features        []string
+		wantResources   []string
+		wantDataSources []string
+	}{
+		{
+			name:            "no features",
+			wantResources:   []string{"structure_project"},
+			wantDataSources: []string{"structure_project"},
+		},
+		{
+			name:            "beta enabled",
+			features:        []string{"beta"},
+			wantResources:   []string{"structure_project", "openstack_server_group"},
+			wantDataSources: []string{"structure_project", "openstack_server_group"},
+		},
+		{
+			name:            "all enabled",
+			features:        []string{"alpha", "beta"},
+			wantResources:   []string{"structure_project", "openstack_server_group", "openstack_marketplace"},
+			wantDataSources: []string{"structure_project", "openstack_server_group"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig()
+			cfg.FilterFeatures(tt.features)
+
+			var resources, dataSources []string
+			for _, r := range cfg.Resources {
+				resources = append(resources, r.Name)
+			}
+			for _, d := range cfg.DataSources {
+				dataSources = append(dataSources, d.Name)
+			}
+			if !reflect.DeepEqual(resources, tt.wantResources) {
+				t.Errorf("Expected resources %v, got %v", tt.wantResources, resources)
+			}
+			if !reflect.DeepEqual(dataSources, tt.wantDataSources) {
+				t.Errorf("Expected data sources %v, got %v", tt.wantDataSources, dataSources)
+			}
+		})
 	}
 }
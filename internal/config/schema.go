@@ -0,0 +1,176 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// validateSchema checks a parsed config document against the Config struct layout,
+// reporting unknown keys and misplaced blocks with their YAML line numbers
+func validateSchema(doc *yaml.Node) error {
+	var errs []string
+	validateNode(doc, reflect.TypeOf(Config{}), "", &errs)
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return nil
+}
+
+// validateNode recursively validates a YAML node against the expected Go type
+func validateNode(node *yaml.Node, t reflect.Type, path string, errs *[]string) {
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			validateNode(child, t, path, errs)
+		}
+		return
+	case yaml.AliasNode:
+		validateNode(node.Alias, t, path, errs)
+		return
+	}
+	if node.Kind == 0 || node.ShortTag() == "!!null" {
+		return
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			*errs = append(*errs, fmt.Sprintf("line %d: %s: expected a mapping, got %s", node.Line, displayPath(path), kindName(node)))
+			return
+		}
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "<<" {
+				validateNode(value, t, path, errs)
+				continue
+			}
+			field, ok := fields[key.Value]
+			if !ok {
+				msg := fmt.Sprintf("line %d: %s: unknown key %q", key.Line, displayPath(path), key.Value)
+				if suggestion := closestKey(key.Value, fields); suggestion != "" {
+					msg += fmt.Sprintf(" (did you mean %q?)", suggestion)
+				}
+				*errs = append(*errs, msg)
+				continue
+			}
+			validateNode(value, field.Type, joinPath(path, key.Value), errs)
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			*errs = append(*errs, fmt.Sprintf("line %d: %s: expected a list, got %s", node.Line, displayPath(path), kindName(node)))
+			return
+		}
+		for i, item := range node.Content {
+			validateNode(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			*errs = append(*errs, fmt.Sprintf("line %d: %s: expected a mapping, got %s", node.Line, displayPath(path), kindName(node)))
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			validateNode(node.Content[i+1], t.Elem(), joinPath(path, node.Content[i].Value), errs)
+		}
+	default:
+		expected, tag := scalarKind(t.Kind())
+		if node.Kind != yaml.ScalarNode || (tag != "" && node.ShortTag() != tag) {
+			*errs = append(*errs, fmt.Sprintf("line %d: %s: expected %s, got %s", node.Line, displayPath(path), expected, kindName(node)))
+		}
+	}
+}
+
+// yamlFields maps yaml keys to struct fields
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+// closestKey returns the known key nearest to an unknown one, if any is close enough
+func closestKey(key string, fields map[string]reflect.StructField) string {
+	best, bestDist := "", 3
+	for name := range fields {
+		d := editDistance(key, name)
+		if d < bestDist || (d == bestDist && best != "" && name < best) {
+			best, bestDist = name, d
+		}
+	}
+	return best
+}
+
+// editDistance computes the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// scalarKind describes a scalar Go kind and the YAML tag it requires (empty if any scalar is accepted)
+func scalarKind(k reflect.Kind) (string, string) {
+	switch k {
+	case reflect.Bool:
+		return "a boolean", "!!bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer", "!!int"
+	default:
+		return "a scalar value", ""
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "top level"
+	}
+	return path
+}
+
+func kindName(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	default:
+		return fmt.Sprintf("%q", node.Value)
+	}
+}
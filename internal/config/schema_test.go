@@ -0,0 +1,108 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name: "unknown resource key",
+			content: `generator:
+  openapi_schema: "schema.yaml"
+  provider_name: "waldur"
+resources:
+  - name: "marketplace_resource"
+    base_operation_id: "marketplace_resources"
+    update_action:
+      update_limits:
+        operation: "marketplace_resources_update_limits"
+`,
+			want: []string{`line 7: resources[0]: unknown key "update_action" (did you mean "update_actions"?)`},
+		},
+		{
+			name: "misplaced block",
+			content: `generator:
+  openapi_schema: "schema.yaml"
+  provider_name: "waldur"
+  resources:
+    - name: "structure_project"
+`,
+			want: []string{`line 4: generator: unknown key "resources"`},
+		},
+		{
+			name: "wrong block type",
+			content: `generator:
+  openapi_schema: "schema.yaml"
+  provider_name: "waldur"
+resources:
+  name: "structure_project"
+`,
+			want: []string{`line 5: resources: expected a list, got a mapping`},
+		},
+		{
+			name: "nested field override key",
+			content: `generator:
+  openapi_schema: "schema.yaml"
+  provider_name: "waldur"
+resources:
+  - name: "openstack_security_group"
+    base_operation_id: "openstack_security_groups"
+    set_fields:
+      rules:
+        computd: true
+`,
+			want: []string{`line 9: resources[0].set_fields.rules: unknown key "computd" (did you mean "computed"?)`},
+		},
+		{
+			name: "wrong scalar type",
+			content: `generator:
+  openapi_schema: "schema.yaml"
+  provider_name: "waldur"
+resources:
+  - name: "openstack_volume"
+    base_operation_id: "openstack_volumes"
+    polling:
+      max_attempts: many
+`,
+			want: []string{`line 8: resources[0].polling.max_attempts: expected an integer, got "many"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to create test config: %v", err)
+			}
+
+			_, err := LoadConfig(configPath)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected error to contain %q, got:\n%v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadConfigEmptyFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, nil, 0644); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+
+	if _, err := LoadConfig(configPath); err != nil {
+		t.Fatalf("LoadConfig failed on empty file: %v", err)
+	}
+}
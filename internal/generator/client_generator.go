@@ -21,7 +21,7 @@ func (g *Generator) generateClient() error {
 	}
 	defer f.Close()
 
-	if err := tmpl.Execute(f, nil); err != nil {
+	if err := tmpl.Execute(f, g.auth); err != nil {
 		return err
 	}
 
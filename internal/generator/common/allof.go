@@ -0,0 +1,197 @@
+package common
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// allOfProperties returns the properties and required names of an object schema, including those
+// of its allOf members. A property defined more than once is merged into one schema (see mergePropertySchemas),
+// with the definitions taken in order: the schema's own properties first, then each member.
+// The schema itself is left unchanged.
+func allOfProperties(cfg SchemaConfig, pathPrefix string, schema *openapi3.Schema) (openapi3.Schemas, map[string]bool) {
+	properties := make(openapi3.Schemas, len(schema.Properties))
+	required := make(map[string]bool)
+	for name, prop := range schema.Properties {
+		properties[name] = prop
+	}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	for _, member := range schema.AllOf {
+		if member == nil || member.Value == nil {
+			continue
+		}
+		memberProps, memberRequired := allOfProperties(cfg, pathPrefix, member.Value)
+		for name, prop := range memberProps {
+			if existing, ok := properties[name]; ok {
+				properties[name] = mergePropertySchemas(cfg, joinPath(pathPrefix, name), existing, prop)
+			} else {
+				properties[name] = prop
+			}
+		}
+		for name := range memberRequired {
+			required[name] = true
+		}
+	}
+	return properties, required
+}
+
+// mergePropertySchemas combines two definitions of the same property. The most restrictive constraint wins:
+// enums are intersected, bounds narrowed, and nullability kept only when both allow it. Descriptions
+// are concatenated. Definitions that cannot both hold (different types, formats or patterns, or enums
+// without common values) are reported, and the first one is kept.
+func mergePropertySchemas(cfg SchemaConfig, path string, first, second *openapi3.SchemaRef) *openapi3.SchemaRef {
+	if second == nil || second.Value == nil {
+		return first
+	}
+	if first == nil || first.Value == nil || first.Value == second.Value {
+		return second
+	}
+	a, b := first.Value, second.Value
+	merged := *a
+
+	switch typeA, typeB := GetSchemaType(a), GetSchemaType(b); {
+	case typeA == "":
+		merged.Type = b.Type
+	case typeB == "" || typeA == typeB:
+	case typeA == OpenAPITypeNumber && typeB == OpenAPITypeInteger:
+		merged.Type = b.Type // Integers are a subset of numbers
+	case typeA == OpenAPITypeInteger && typeB == OpenAPITypeNumber:
+	default:
+		reportSchemaConflict(cfg, path, "type", typeA, typeB)
+	}
+	merged.Format = mergeSchemaString(cfg, path, "format", a.Format, b.Format)
+	merged.Pattern = mergeSchemaString(cfg, path, "pattern", a.Pattern, b.Pattern)
+
+	merged.Description = a.Description
+	if b.Description != "" && !strings.Contains(a.Description, b.Description) {
+		merged.Description = strings.TrimSpace(a.Description + " " + b.Description)
+	}
+	if merged.Title == "" {
+		merged.Title = b.Title
+	}
+	if merged.Default == nil {
+		merged.Default = b.Default
+	}
+
+	merged.Enum = mergeEnums(cfg, path, a.Enum, b.Enum)
+	merged.Nullable = a.Nullable && b.Nullable
+	merged.ReadOnly = a.ReadOnly || b.ReadOnly
+	merged.WriteOnly = a.WriteOnly || b.WriteOnly
+	merged.Deprecated = a.Deprecated || b.Deprecated
+	merged.UniqueItems = a.UniqueItems || b.UniqueItems
+
+	if b.Min != nil && (a.Min == nil || *b.Min > *a.Min) {
+		merged.Min, merged.ExclusiveMin = b.Min, b.ExclusiveMin
+	} else if b.Min != nil && *b.Min == *a.Min {
+		merged.ExclusiveMin = a.ExclusiveMin || b.ExclusiveMin
+	}
+	if b.Max != nil && (a.Max == nil || *b.Max < *a.Max) {
+		merged.Max, merged.ExclusiveMax = b.Max, b.ExclusiveMax
+	} else if b.Max != nil && *b.Max == *a.Max {
+		merged.ExclusiveMax = a.ExclusiveMax || b.ExclusiveMax
+	}
+	merged.MinLength = max(a.MinLength, b.MinLength)
+	merged.MaxLength = minBound(a.MaxLength, b.MaxLength)
+	merged.MinItems = max(a.MinItems, b.MinItems)
+	merged.MaxItems = minBound(a.MaxItems, b.MaxItems)
+
+	if a.Items != nil || b.Items != nil {
+		merged.Items = mergePropertySchemas(cfg, path, a.Items, b.Items)
+	}
+	if len(a.Properties) > 0 || len(b.Properties) > 0 || len(a.AllOf) > 0 || len(b.AllOf) > 0 {
+		propsA, requiredA := allOfProperties(cfg, path, a)
+		propsB, requiredB := allOfProperties(cfg, path, b)
+		merged.AllOf = nil
+		merged.Properties = propsA
+		for name, prop := range propsB {
+			if existing, ok := propsA[name]; ok {
+				merged.Properties[name] = mergePropertySchemas(cfg, joinPath(path, name), existing, prop)
+			} else {
+				merged.Properties[name] = prop
+			}
+		}
+		merged.Required = nil
+		for _, required := range []map[string]bool{requiredA, requiredB} {
+			for name := range required {
+				if !slices.Contains(merged.Required, name) {
+					merged.Required = append(merged.Required, name)
+				}
+			}
+		}
+	}
+	if merged.AdditionalProperties.Schema == nil && merged.AdditionalProperties.Has == nil {
+		merged.AdditionalProperties = b.AdditionalProperties
+	}
+
+	// The merged definition no longer matches either named schema
+	return &openapi3.SchemaRef{Value: &merged}
+}
+
+// mergeSchemaString keeps the value set by either definition, reporting two different values
+func mergeSchemaString(cfg SchemaConfig, path, what, a, b string) string {
+	if a == "" {
+		return b
+	}
+	if b != "" && a != b {
+		reportSchemaConflict(cfg, path, what, a, b)
+	}
+	return a
+}
+
+// mergeEnums returns the values allowed by both enums, in the order of the first
+func mergeEnums(cfg SchemaConfig, path string, a, b []any) []any {
+	if len(a) == 0 {
+		return b
+	}
+	if len(b) == 0 {
+		return a
+	}
+	var common []any
+	for _, v := range a {
+		for _, w := range b {
+			if reflect.DeepEqual(v, w) {
+				common = append(common, v)
+				break
+			}
+		}
+	}
+	if len(common) == 0 {
+		reportSchemaConflict(cfg, path, "enum", a, b)
+		return a
+	}
+	return common
+}
+
+// minBound returns the lower of two optional upper bounds
+func minBound(a, b *uint64) *uint64 {
+	if a == nil || (b != nil && *b < *a) {
+		return b
+	}
+	return a
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// reportSchemaConflict warns about incompatible allOf definitions of a property once per conflict
+func reportSchemaConflict(cfg SchemaConfig, path, what string, first, second any) {
+	key := fmt.Sprintf("%s %s %v %v", path, what, first, second)
+	if cfg.ReportedConflicts != nil {
+		if cfg.ReportedConflicts[key] {
+			return
+		}
+		cfg.ReportedConflicts[key] = true
+	}
+	fmt.Printf("Warning: conflicting allOf definitions of %s: %s %v and %v, keeping %v\n", path, what, first, second, first)
+}
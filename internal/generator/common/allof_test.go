@@ -0,0 +1,79 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestExtractFields_AllOfRefinements(t *testing.T) {
+	ten, twenty := 10.0, 20.0
+	base := &openapi3.SchemaRef{Ref: "#/components/schemas/Base", Value: &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{
+			"state": {Value: &openapi3.Schema{
+				Type: &openapi3.Types{"string"}, Description: "Resource state", Nullable: true,
+				Enum: []any{"OK", "Erred", "Creating"},
+			}},
+			"size": {Value: &openapi3.Schema{Type: &openapi3.Types{"number"}, Min: &ten}},
+			"name": {Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+		},
+	}}
+	refinement := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{
+			"state": {Value: &openapi3.Schema{Description: "Only stable states.", Enum: []any{"OK", "Erred"}}},
+			"size":  {Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}, Max: &twenty}},
+			"name":  {Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}}}, // Conflicts with the base
+		},
+		Required: []string{"name"},
+	}}
+	root := &openapi3.SchemaRef{Value: &openapi3.Schema{AllOf: openapi3.SchemaRefs{base, refinement}}}
+
+	fields, err := ExtractFields(SchemaConfig{}, root, false)
+	if err != nil {
+		t.Fatalf("ExtractFields failed: %v", err)
+	}
+	if len(fields) != 3 {
+		t.Fatalf("expected name, size and state fields, got %v", fields)
+	}
+	name, size, state := fields[0], fields[1], fields[2]
+
+	if !reflect.DeepEqual(state.Enum, []string{"OK", "Erred"}) {
+		t.Errorf("state enum = %v, want the values allowed by both definitions", state.Enum)
+	}
+	if state.Description != "Resource state Only stable states." || state.Nullable {
+		t.Errorf("state description = %q, nullable = %v", state.Description, state.Nullable)
+	}
+	if size.Type != OpenAPITypeInteger || size.Minimum == nil || *size.Minimum != ten || size.Maximum == nil || *size.Maximum != twenty {
+		t.Errorf("size = %s [%v, %v], want an integer between 10 and 20", size.Type, size.Minimum, size.Maximum)
+	}
+	if name.Type != OpenAPITypeString || !name.Required {
+		t.Errorf("name = %s, required = %v, want the first definition, required", name.Type, name.Required)
+	}
+	// The schemas themselves are left unchanged
+	if root.Value.Properties != nil || len(base.Value.Properties["state"].Value.Enum) != 3 {
+		t.Error("allOf merging modified the schemas")
+	}
+}
+
+func TestMergeEnums(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []any
+		want []any
+	}{
+		{"intersection", []any{"a", "b", "c"}, []any{"c", "a"}, []any{"a", "c"}},
+		{"only first", []any{"a"}, nil, []any{"a"}},
+		{"only second", nil, []any{"b"}, []any{"b"}},
+		{"disjoint keeps first", []any{"a"}, []any{"b"}, []any{"a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeEnums(SchemaConfig{ReportedConflicts: map[string]bool{}}, "state", tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeEnums() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package common
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 )
@@ -34,8 +37,8 @@ func CollectUniqueStructs(params ...[]FieldInfo) []FieldInfo {
 					traverse(f.Properties)
 				}
 			}
-			// Check list/set of objects with AttrTypeRef or RefName
-			if (f.GoType == TFTypeList || f.GoType == TFTypeSet) && f.ItemSchema != nil {
+			// Check list/set/map of objects with AttrTypeRef or RefName
+			if (f.GoType == TFTypeList || f.GoType == TFTypeSet || f.IsObjectMap()) && f.ItemSchema != nil {
 				key := f.ItemSchema.AttrTypeRef
 				if key == "" {
 					key = f.ItemSchema.RefName
@@ -65,76 +68,202 @@ func CollectUniqueStructs(params ...[]FieldInfo) []FieldInfo {
 	return result
 }
 
-// AssignMissingAttrTypeRefs recursively assigns a AttrTypeRef to objects/lists of objects that lack one.
-func AssignMissingAttrTypeRefs(cfg SchemaConfig, fields []FieldInfo, prefix string, seenHashes map[string]string, seenNames map[string]string) {
+// AssignAttrTypeRefs names the attribute types of objects and of collection items that are objects.
+// Structures with the same attributes share a name. A structure is named after its OpenAPI component,
+// or else after its attribute path. Structures claiming the same name fall back to their path names,
+// or to the name with a suffix derived from their attributes. Names therefore don't depend on the
+// order resources and fields are processed in.
+func AssignAttrTypeRefs(fieldSets ...[]FieldInfo) {
+	candidates := make(map[string]*structNames)
+	for _, fields := range fieldSets {
+		collectStructNames(fields, "", candidates)
+	}
+	names := resolveStructNames(candidates)
+	for _, fields := range fieldSets {
+		applyStructNames(fields, names)
+	}
+}
+
+// structNames holds the candidate names of a structure
+type structNames struct {
+	refs  map[string]bool // OpenAPI component names
+	paths map[string]bool // Attribute path names
+}
+
+// structSchema returns the schema of the structure a field holds: the field itself for objects,
+// or the item schema for collections of objects
+func structSchema(f *FieldInfo) *FieldInfo {
+	if f.GoType == TFTypeObject {
+		return f
+	}
+	if (f.GoType == TFTypeList || f.GoType == TFTypeSet || f.IsObjectMap()) && f.ItemSchema != nil && f.ItemSchema.GoType == TFTypeObject {
+		return f.ItemSchema
+	}
+	return nil
+}
+
+func collectStructNames(fields []FieldInfo, prefix string, candidates map[string]*structNames) {
 	for i := range fields {
-		f := &fields[i]
-
-		// Recursively process children first (Bottom-Up)
-		if f.GoType == TFTypeObject {
-			AssignMissingAttrTypeRefs(cfg, f.Properties, prefix+ToTitle(f.Name), seenHashes, seenNames)
-		} else if (f.GoType == TFTypeList || f.GoType == TFTypeSet) && f.ItemSchema != nil {
-			if f.ItemSchema.GoType == TFTypeObject {
-				AssignMissingAttrTypeRefs(cfg, f.ItemSchema.Properties, prefix+ToTitle(f.Name), seenHashes, seenNames)
-
-				// Also assign ref to ItemSchema itself
-				hash := computeStructHash(*f.ItemSchema)
-				if name, ok := seenHashes[hash]; ok {
-					f.ItemSchema.AttrTypeRef = name
-				} else {
-					candidate := f.ItemSchema.RefName
-					if candidate == "" {
-						candidate = prefix + ToTitle(f.Name)
-					}
-					finalName := resolveUniqueName(candidate, hash, seenNames)
-					seenHashes[hash] = finalName
-					seenNames[finalName] = hash
-					f.ItemSchema.AttrTypeRef = finalName
-				}
-			}
+		s := structSchema(&fields[i])
+		if s == nil {
+			continue
 		}
+		path := prefix + ToTitle(fields[i].Name)
+		collectStructNames(s.Properties, path, candidates)
 
-		// Now process f itself if it is Object
-		if f.GoType == TFTypeObject {
-			hash := computeStructHash(*f)
-			if name, ok := seenHashes[hash]; ok {
-				f.AttrTypeRef = name
-			} else {
-				candidate := f.RefName
-				if candidate == "" {
-					candidate = prefix + ToTitle(f.Name)
-				}
-				finalName := resolveUniqueName(candidate, hash, seenNames)
-				seenHashes[hash] = finalName
-				seenNames[finalName] = hash
-				f.AttrTypeRef = finalName
-			}
+		hash := computeStructHash(*s)
+		names, ok := candidates[hash]
+		if !ok {
+			names = &structNames{refs: map[string]bool{}, paths: map[string]bool{}}
+			candidates[hash] = names
 		}
+		if s.RefName != "" {
+			names.refs[s.RefName] = true
+		}
+		names.paths[path] = true
 	}
 }
 
-func resolveUniqueName(candidate string, hash string, seenNames map[string]string) string {
-	finalName := candidate
-	counter := 2
-	for {
-		if oldHash, exists := seenNames[finalName]; exists {
-			if oldHash == hash {
-				return finalName
+func resolveStructNames(candidates map[string]*structNames) map[string]string {
+	hashes := slices.Sorted(maps.Keys(candidates))
+	preferred := make(map[string]string, len(hashes))
+	claims := make(map[string]int)
+	for _, hash := range hashes {
+		names := candidates[hash]
+		name := slices.Min(slices.Collect(maps.Keys(names.paths)))
+		if len(names.refs) > 0 {
+			name = slices.Min(slices.Collect(maps.Keys(names.refs)))
+		}
+		preferred[hash] = name
+		claims[name]++
+	}
+
+	result := make(map[string]string, len(hashes))
+	used := make(map[string]bool)
+	for _, hash := range hashes {
+		if name := preferred[hash]; claims[name] == 1 {
+			result[hash] = name
+			used[name] = true
+		}
+	}
+	// Colliding structures take a path name nothing else claims
+	remaining := make(map[string]int)
+	for _, hash := range hashes {
+		if _, ok := result[hash]; ok {
+			continue
+		}
+		for _, path := range slices.Sorted(maps.Keys(candidates[hash].paths)) {
+			if claims[path] == 0 && !used[path] {
+				result[hash] = path
+				used[path] = true
+				break
 			}
-			finalName = fmt.Sprintf("%s%d", candidate, counter)
-			counter++
-		} else {
-			return finalName
+		}
+		if _, ok := result[hash]; !ok {
+			remaining[preferred[hash]]++
+		}
+	}
+	// The others keep the name if they are left alone with it, and otherwise get a suffix
+	for _, hash := range hashes {
+		if _, ok := result[hash]; ok {
+			continue
+		}
+		name := preferred[hash]
+		if remaining[name] > 1 || used[name] {
+			name += fmt.Sprintf("%x", sha256.Sum256([]byte(hash)))[:8]
+		}
+		result[hash] = name
+		used[name] = true
+	}
+	return result
+}
+
+func applyStructNames(fields []FieldInfo, names map[string]string) {
+	for i := range fields {
+		if s := structSchema(&fields[i]); s != nil {
+			applyStructNames(s.Properties, names)
+			s.AttrTypeRef = names[computeStructHash(*s)]
 		}
 	}
 }
 
+// computeStructHash identifies the attribute type of a structure by the names and types of its
+// attributes, including those of nested structures
 func computeStructHash(f FieldInfo) string {
 	var parts []string
 	for _, p := range f.Properties {
-		key := fmt.Sprintf("%s:%s:%s", p.Name, p.GoType, p.AttrTypeRef)
+		key := fmt.Sprintf("%s:%s:%s", p.Name, p.GoType, p.ItemType)
+		if s := structSchema(&p); s != nil {
+			key += "{" + computeStructHash(*s) + "}"
+		}
 		parts = append(parts, key)
 	}
 	sort.Strings(parts)
 	return strings.Join(parts, "|")
 }
+
+// ShareAttrTypes registers the attribute types several resources declare under the same name,
+// such as those of OpenAPI components, so that their helpers are generated once in the common package instead of in
+// every service package. Shared structures are flagged in the fields of the resources and
+// removed from their nested structs. The shared structures are returned sorted by name.
+func ShareAttrTypes(resources []*ResourceData) []FieldInfo {
+	type entry struct {
+		def    FieldInfo
+		hashes map[string]bool
+		users  map[string]bool
+	}
+	entries := make(map[string]*entry)
+	for _, rd := range resources {
+		for _, s := range rd.NestedStructs {
+			e, ok := entries[s.AttrTypeRef]
+			if !ok {
+				e = &entry{def: s, hashes: map[string]bool{}, users: map[string]bool{}}
+				entries[s.AttrTypeRef] = e
+			}
+			e.hashes[computeStructHash(s)] = true
+			e.users[rd.Name] = true
+		}
+	}
+
+	// A structure is shared when it has the same attributes in every resource using it
+	// and only nests shared structures
+	shared := make(map[string]bool)
+	var isShared func(name string) bool
+	isShared = func(name string) bool {
+		if result, ok := shared[name]; ok {
+			return result
+		}
+		e, ok := entries[name]
+		result := ok && len(e.hashes) == 1 && len(e.users) > 1
+		shared[name] = false // Guards against recursion through the nested structures
+		for i := 0; result && i < len(e.def.Properties); i++ {
+			if s := structSchema(&e.def.Properties[i]); s != nil {
+				result = isShared(s.AttrTypeRef)
+			}
+		}
+		shared[name] = result
+		return result
+	}
+
+	var result []FieldInfo
+	for _, name := range slices.Sorted(maps.Keys(entries)) {
+		if isShared(name) {
+			result = append(result, entries[name].def)
+		}
+	}
+	for _, rd := range resources {
+		markSharedAttrTypes(rd.ModelFields, shared)
+		markSharedAttrTypes(rd.ResponseFields, shared)
+		rd.NestedStructs = slices.DeleteFunc(rd.NestedStructs, func(s FieldInfo) bool { return shared[s.AttrTypeRef] })
+	}
+	return result
+}
+
+func markSharedAttrTypes(fields []FieldInfo, shared map[string]bool) {
+	for i := range fields {
+		if s := structSchema(&fields[i]); s != nil {
+			markSharedAttrTypes(s.Properties, shared)
+			s.SharedAttrType = shared[s.AttrTypeRef]
+		}
+	}
+}
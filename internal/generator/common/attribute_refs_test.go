@@ -1,6 +1,11 @@
 package common
 
-import "testing"
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
 
 func TestCollectUniqueStructs(t *testing.T) {
 	fields := []FieldInfo{
@@ -46,43 +51,132 @@ func TestCollectUniqueStructs(t *testing.T) {
 	}
 }
 
-func TestAssignMissingAttrTypeRefs(t *testing.T) {
-	cfg := SchemaConfig{}
+func TestAssignAttrTypeRefs(t *testing.T) {
+	str := func(name string) FieldInfo { return FieldInfo{Name: name, GoType: TFTypeString} }
+	object := func(name, refName string, props ...FieldInfo) FieldInfo {
+		return FieldInfo{Name: name, GoType: TFTypeObject, RefName: refName, Properties: props}
+	}
+	newFields := func() []FieldInfo {
+		return []FieldInfo{
+			object("user", "", str("username")),
+			object("profile", "", str("username")), // Same structure as user
+			object("owner", "Owner", str("username"), str("email")),
+			{Name: "ports", GoType: TFTypeList, ItemType: OpenAPITypeObject, ItemSchema: &FieldInfo{GoType: TFTypeObject, RefName: "Port",
+				Properties: []FieldInfo{str("subnet"), object("options", "", str("mode"))}}},
+			// Different structures of the same component fall back to their paths
+			object("backup", "Port", str("subnet")),
+			object("settings", "", object("options", "", str("level"))),
+		}
+	}
+
+	want := map[string]string{
+		"user":             "Profile",
+		"profile":          "Profile",
+		"owner":            "Owner",
+		"ports":            "Ports",
+		"ports.options":    "PortsOptions",
+		"backup":           "Backup",
+		"settings":         "Settings",
+		"settings.options": "SettingsOptions",
+	}
+	collect := func(fields []FieldInfo) map[string]string {
+		got := map[string]string{}
+		for _, f := range fields {
+			if s := structSchema(&f); s != nil {
+				got[f.Name] = s.AttrTypeRef
+				for _, p := range s.Properties {
+					if s := structSchema(&p); s != nil {
+						got[f.Name+"."+p.Name] = s.AttrTypeRef
+					}
+				}
+			}
+		}
+		return got
+	}
+
+	fields := newFields()
+	AssignAttrTypeRefs(fields)
+	if got := collect(fields); !reflect.DeepEqual(got, want) {
+		t.Errorf("AttrTypeRefs = %v, want %v", got, want)
+	}
+
+	// Names don't depend on the order fields are processed in
+	reversed := newFields()
+	slices.Reverse(reversed)
+	AssignAttrTypeRefs(reversed[:3], reversed[3:])
+	if got := collect(reversed); !reflect.DeepEqual(got, want) {
+		t.Errorf("AttrTypeRefs of reversed fields = %v, want %v", got, want)
+	}
+}
+
+func TestAssignAttrTypeRefs_HashSuffix(t *testing.T) {
 	fields := []FieldInfo{
-		{
-			Name:   "user",
-			GoType: TFTypeObject,
-			Properties: []FieldInfo{
-				{Name: "username", Type: "string"},
-			},
-		},
+		{Name: "first", GoType: TFTypeObject, RefName: "Item", Properties: []FieldInfo{{Name: "a", GoType: TFTypeString}}},
+		{Name: "item", GoType: TFTypeObject, Properties: []FieldInfo{{Name: "b", GoType: TFTypeString}}},
+		{Name: "second", GoType: TFTypeObject, RefName: "Item", Properties: []FieldInfo{{Name: "c", GoType: TFTypeString}}},
 	}
-	seenHashes := make(map[string]string)
-	seenNames := make(map[string]string)
+	AssignAttrTypeRefs(fields)
 
-	AssignMissingAttrTypeRefs(cfg, fields, "Base", seenHashes, seenNames)
+	// first and second fall back to their paths, item keeps its own
+	for i, want := range []string{"First", "Item", "Second"} {
+		if fields[i].AttrTypeRef != want {
+			t.Errorf("%s AttrTypeRef = %q, want %q", fields[i].Name, fields[i].AttrTypeRef, want)
+		}
+	}
 
-	if fields[0].AttrTypeRef == "" {
-		t.Error("AttrTypeRef was not assigned to object field")
+	// Structures left without a free name get a suffix derived from their attributes
+	fields = []FieldInfo{
+		{Name: "item", GoType: TFTypeObject, RefName: "Item", Properties: []FieldInfo{{Name: "a", GoType: TFTypeString}}},
+		{Name: "item", GoType: TFTypeObject, Properties: []FieldInfo{{Name: "b", GoType: TFTypeString}}},
+	}
+	AssignAttrTypeRefs(fields)
+	if fields[0].AttrTypeRef == fields[1].AttrTypeRef || !strings.HasPrefix(fields[0].AttrTypeRef, "Item") || !strings.HasPrefix(fields[1].AttrTypeRef, "Item") {
+		t.Errorf("AttrTypeRefs = %q, %q", fields[0].AttrTypeRef, fields[1].AttrTypeRef)
 	}
+}
 
-	expectedPrefix := "BaseUser"
-	if fields[0].AttrTypeRef != expectedPrefix {
-		t.Errorf("Expected AttrTypeRef %q, got %q", expectedPrefix, fields[0].AttrTypeRef)
+func TestShareAttrTypes(t *testing.T) {
+	str := func(name string) FieldInfo { return FieldInfo{Name: name, GoType: TFTypeString} }
+	object := func(name string, props ...FieldInfo) FieldInfo {
+		return FieldInfo{Name: name, GoType: TFTypeObject, Properties: props}
+	}
+	newResource := func(name string, fields ...FieldInfo) *ResourceData {
+		rd := &ResourceData{Name: name, ModelFields: fields}
+		AssignAttrTypeRefs(rd.ModelFields)
+		rd.NestedStructs = CollectUniqueStructs(rd.ModelFields)
+		return rd
 	}
+	first := newResource("first",
+		object("price", str("total")),
+		object("owner", str("name"), object("address", str("city"))),
+		object("limits", str("cpu")),
+	)
+	second := newResource("second",
+		object("price", str("total")),
+		object("owner", str("name"), object("address", str("street"))),
+	)
 
-	// Test deduplication by hash
-	fields2 := []FieldInfo{
-		{
-			Name:   "profile",
-			GoType: TFTypeObject,
-			Properties: []FieldInfo{
-				{Name: "username", Type: "string"}, // Same structure as 'user'
-			},
-		},
+	shared := ShareAttrTypes([]*ResourceData{first, second})
+
+	// Owners nest addresses that differ, so they stay with their resources
+	var names []string
+	for _, s := range shared {
+		names = append(names, s.AttrTypeRef)
+	}
+	if !reflect.DeepEqual(names, []string{"Price"}) {
+		t.Errorf("shared structs = %v, want [Price]", names)
+	}
+	for _, rd := range []*ResourceData{first, second} {
+		if !rd.ModelFields[0].SharedAttrType || rd.ModelFields[1].SharedAttrType {
+			t.Errorf("%s: SharedAttrType of price, owner = %v, %v", rd.Name, rd.ModelFields[0].SharedAttrType, rd.ModelFields[1].SharedAttrType)
+		}
+		for _, s := range rd.NestedStructs {
+			if s.AttrTypeRef == "Price" {
+				t.Errorf("%s still declares the shared Price struct", rd.Name)
+			}
+		}
 	}
-	AssignMissingAttrTypeRefs(cfg, fields2, "Other", seenHashes, seenNames)
-	if fields2[0].AttrTypeRef != fields[0].AttrTypeRef {
-		t.Errorf("Expected duplicate structure to have same AttrTypeRef %q, got %q", fields[0].AttrTypeRef, fields2[0].AttrTypeRef)
+	if len(first.NestedStructs) != 3 {
+		t.Errorf("first declares %d structs, want Limits, Owner and OwnerAddress", len(first.NestedStructs))
 	}
 }
@@ -0,0 +1,65 @@
+package common
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Auth describes how the generated client authenticates, derived from the OpenAPI security schemes
+type Auth struct {
+	TokenHeader    string // Header carrying the API token
+	TokenPrefix    string // Prefix of the token in its header, e.g. "Token "
+	Bearer         bool   // Whether the API also accepts the token as a bearer token (e.g. OIDC)
+	Basic          bool   // Whether the API accepts HTTP basic authentication
+	OAuth2         bool   // Whether the API accepts OAuth2 client credentials
+	OAuth2TokenURL string // Token URL of the OAuth2 client credentials flow
+}
+
+// DefaultAuth is used when the schema defines no supported API key scheme:
+// the token is sent as "Authorization: Token <token>"
+var DefaultAuth = Auth{TokenHeader: "Authorization", TokenPrefix: "Token "}
+
+// NewAuth returns the authentication of a schema's security schemes and the sorted names of
+// the schemes the generated client cannot use (cookies, OpenID Connect discovery, OAuth2 flows
+// other than client credentials)
+func NewAuth(schemes openapi3.SecuritySchemes) (Auth, []string) {
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	auth := Auth{}
+	var unsupported []string
+	for _, name := range names {
+		ref := schemes[name]
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		scheme := ref.Value
+		switch {
+		case scheme.Type == "apiKey" && scheme.In == "header" && auth.TokenHeader == "":
+			auth.TokenHeader = scheme.Name
+			// Django REST framework token authentication expects the "Token" keyword
+			if strings.EqualFold(scheme.Name, "Authorization") {
+				auth.TokenPrefix = "Token "
+			}
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "bearer"):
+			auth.Bearer = true
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+			auth.Basic = true
+		case scheme.Type == "oauth2" && scheme.Flows != nil && scheme.Flows.ClientCredentials != nil && !auth.OAuth2:
+			auth.OAuth2 = true
+			auth.OAuth2TokenURL = scheme.Flows.ClientCredentials.TokenURL
+		default:
+			unsupported = append(unsupported, name)
+		}
+	}
+
+	if auth.TokenHeader == "" {
+		auth.TokenHeader, auth.TokenPrefix = DefaultAuth.TokenHeader, DefaultAuth.TokenPrefix
+	}
+	return auth, unsupported
+}
@@ -0,0 +1,67 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestNewAuth(t *testing.T) {
+	scheme := func(s *openapi3.SecurityScheme) *openapi3.SecuritySchemeRef {
+		return &openapi3.SecuritySchemeRef{Value: s}
+	}
+
+	tests := []struct {
+		name            string
+		schemes         openapi3.SecuritySchemes
+		want            Auth
+		wantUnsupported []string
+	}{
+		{
+			name:    "no schemes",
+			schemes: nil,
+			want:    DefaultAuth,
+		},
+		{
+			name: "waldur",
+			schemes: openapi3.SecuritySchemes{
+				"tokenAuth":        scheme(&openapi3.SecurityScheme{Type: "apiKey", In: "header", Name: "Authorization"}),
+				"waldurCookieAuth": scheme(&openapi3.SecurityScheme{Type: "apiKey", In: "cookie", Name: "sessionid"}),
+				"waldurOIDCAuth":   scheme(&openapi3.SecurityScheme{Type: "http", Scheme: "bearer"}),
+			},
+			want:            Auth{TokenHeader: "Authorization", TokenPrefix: "Token ", Bearer: true},
+			wantUnsupported: []string{"waldurCookieAuth"},
+		},
+		{
+			name: "custom header, basic and oauth2",
+			schemes: openapi3.SecuritySchemes{
+				"apiKey": scheme(&openapi3.SecurityScheme{Type: "apiKey", In: "header", Name: "X-API-Key"}),
+				"basic":  scheme(&openapi3.SecurityScheme{Type: "http", Scheme: "Basic"}),
+				"oauth": scheme(&openapi3.SecurityScheme{Type: "oauth2", Flows: &openapi3.OAuthFlows{
+					ClientCredentials: &openapi3.OAuthFlow{TokenURL: "https://sso.example.com/token"},
+				}}),
+				"oidc": scheme(&openapi3.SecurityScheme{Type: "openIdConnect", OpenIdConnectUrl: "https://sso.example.com"}),
+			},
+			want: Auth{
+				TokenHeader:    "X-API-Key",
+				Basic:          true,
+				OAuth2:         true,
+				OAuth2TokenURL: "https://sso.example.com/token",
+			},
+			wantUnsupported: []string{"oidc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, unsupported := NewAuth(tt.schemes)
+			if got != tt.want {
+				t.Errorf("NewAuth() = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(unsupported, tt.wantUnsupported) {
+				t.Errorf("NewAuth() unsupported = %v, want %v", unsupported, tt.wantUnsupported)
+			}
+		})
+	}
+}
@@ -31,3 +31,17 @@ const (
 	TFTypeMap     = "types.Map"
 	TFTypeObject  = "types.Object"
 )
+
+// FormatValidator is a regular expression validating a string format
+type FormatValidator struct {
+	Pattern string // Go regular expression the value must match
+	Message string // Error message shown when it does not
+}
+
+// StringFormatValidators maps the OpenAPI string formats that are validated to their validators.
+// UUIDs may omit dashes, as the Waldur API returns them without.
+var StringFormatValidators = map[string]FormatValidator{
+	"uuid":  {`^[0-9a-fA-F]{8}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{12}$`, "must be a valid UUID"},
+	"uri":   {`^[a-zA-Z][a-zA-Z0-9+.-]*:\S+$`, "must be a valid URI"},
+	"email": {`^[^@\s]+@[^@\s]+$`, "must be a valid email address"},
+}
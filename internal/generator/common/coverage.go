@@ -0,0 +1,67 @@
+package common
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ReferencedSchemas returns the names of the component schemas reachable through $ref from
+// the given schemas, including the referenced schemas themselves
+func ReferencedSchemas(schemaRefs []*openapi3.SchemaRef) map[string]bool {
+	names := make(map[string]bool)
+	visited := make(map[*openapi3.Schema]bool)
+	var walk func(ref *openapi3.SchemaRef)
+	walk = func(ref *openapi3.SchemaRef) {
+		if ref == nil || ref.Value == nil {
+			return
+		}
+		if ref.Ref != "" {
+			names[schemaName(ref)] = true
+		}
+		if visited[ref.Value] {
+			return
+		}
+		visited[ref.Value] = true
+
+		s := ref.Value
+		for _, prop := range s.Properties {
+			walk(prop)
+		}
+		walk(s.Items)
+		walk(s.AdditionalProperties.Schema)
+		walk(s.Not)
+		for _, group := range []openapi3.SchemaRefs{s.AllOf, s.OneOf, s.AnyOf} {
+			for _, variant := range group {
+				walk(variant)
+			}
+		}
+	}
+	for _, ref := range schemaRefs {
+		walk(ref)
+	}
+	return names
+}
+
+// CRUDCandidates returns, sorted, the base operation IDs of the collections that have list,
+// create, retrieve and destroy operations but are not among the configured base operation IDs
+func CRUDCandidates(operationIDs []string, configured map[string]bool) []string {
+	exists := make(map[string]bool, len(operationIDs))
+	for _, opID := range operationIDs {
+		exists[opID] = true
+	}
+
+	var candidates []string
+	for _, opID := range operationIDs {
+		base, ok := strings.CutSuffix(opID, "_list")
+		if !ok || configured[base] {
+			continue
+		}
+		if exists[base+"_create"] && exists[base+"_retrieve"] && exists[base+"_destroy"] {
+			candidates = append(candidates, base)
+		}
+	}
+	sort.Strings(candidates)
+	return candidates
+}
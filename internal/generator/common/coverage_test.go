@@ -0,0 +1,76 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestReferencedSchemas(t *testing.T) {
+	str := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	subnet := &openapi3.Schema{Type: &openapi3.Types{"object"}, Properties: openapi3.Schemas{"cidr": str}}
+	network := &openapi3.Schema{Type: &openapi3.Types{"object"}}
+	network.Properties = openapi3.Schemas{
+		"name": str,
+		"subnets": &openapi3.SchemaRef{Value: &openapi3.Schema{
+			Type:  &openapi3.Types{"array"},
+			Items: &openapi3.SchemaRef{Ref: "#/components/schemas/Subnet", Value: subnet},
+		}},
+		"parent": &openapi3.SchemaRef{Ref: "#/components/schemas/Network", Value: network},
+	}
+	state := &openapi3.SchemaRef{Ref: "#/components/schemas/StateEnum", Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	tenant := &openapi3.SchemaRef{Value: &openapi3.Schema{AllOf: openapi3.SchemaRefs{
+		{Ref: "#/components/schemas/Network", Value: network},
+		{Value: &openapi3.Schema{Properties: openapi3.Schemas{"state": state}}},
+	}}}
+
+	tests := []struct {
+		name    string
+		schemas []*openapi3.SchemaRef
+		want    map[string]bool
+	}{
+		{
+			name:    "no schemas",
+			schemas: []*openapi3.SchemaRef{nil},
+			want:    map[string]bool{},
+		},
+		{
+			name:    "inline schema",
+			schemas: []*openapi3.SchemaRef{str},
+			want:    map[string]bool{},
+		},
+		{
+			name:    "circular references",
+			schemas: []*openapi3.SchemaRef{{Ref: "#/components/schemas/Network", Value: network}},
+			want:    map[string]bool{"Network": true, "Subnet": true},
+		},
+		{
+			name:    "composition",
+			schemas: []*openapi3.SchemaRef{tenant},
+			want:    map[string]bool{"Network": true, "Subnet": true, "StateEnum": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReferencedSchemas(tt.schemas); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReferencedSchemas() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCRUDCandidates(t *testing.T) {
+	operationIDs := []string{
+		"customers_create", "customers_destroy", "customers_list", "customers_retrieve",
+		"projects_create", "projects_destroy", "projects_list", "projects_retrieve",
+		"events_list", "events_retrieve",
+		"keys_create", "keys_list", "keys_retrieve",
+	}
+	configured := map[string]bool{"customers": true}
+
+	want := []string{"projects"}
+	if got := CRUDCandidates(operationIDs, configured); !reflect.DeepEqual(got, want) {
+		t.Errorf("CRUDCandidates() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,80 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+)
+
+// schemaCycle returns the names of the schemas forming a cycle when nested is one of the
+// enclosing schemas, or nil. The names start at the alphabetically first schema of the cycle
+// and end by repeating it, so the same cycle reads the same wherever it is entered.
+func schemaCycle(ancestors []*openapi3.SchemaRef, nested *openapi3.SchemaRef) []string {
+	if nested == nil || nested.Value == nil {
+		return nil
+	}
+	for i, ancestor := range ancestors {
+		if ancestor.Value != nested.Value {
+			continue
+		}
+		// The first entry is the schema nested refers to, even when it was entered without a $ref
+		names := []string{schemaName(nested)}
+		for _, s := range ancestors[i+1:] {
+			names = append(names, schemaName(s))
+		}
+		start := 0
+		for j, name := range names {
+			if name < names[start] {
+				start = j
+			}
+		}
+		names = append(names[start:], names[:start]...)
+		return append(names, names[0])
+	}
+	return nil
+}
+
+// schemaName returns the component name of a schema reference, or "(inline)"
+func schemaName(s *openapi3.SchemaRef) string {
+	if s.Ref == "" {
+		return "(inline)"
+	}
+	parts := strings.Split(s.Ref, "/")
+	return parts[len(parts)-1]
+}
+
+// reportCycle warns about a circular reference once per cycle
+func reportCycle(cfg SchemaConfig, path string, cycle []string) {
+	key := strings.Join(cycle, " -> ")
+	if cfg.ReportedCycles != nil {
+		if cfg.ReportedCycles[key] {
+			return
+		}
+		cfg.ReportedCycles[key] = true
+	}
+	action := "left out"
+	if cfg.CircularRefs == config.CircularRefsURL {
+		action = "exposed as a URL"
+	}
+	fmt.Printf("Warning: circular schema reference %s at %s, attribute %s\n", key, path, action)
+}
+
+// urlReferenceField turns a field referring back to an enclosing schema into the URL of
+// the referenced object, or a list of URLs for arrays
+func urlReferenceField(field FieldInfo, isArray bool) FieldInfo {
+	field.URLReference = true
+	field.RefName = ""
+	field.Format = ""
+	if isArray {
+		field.Type = OpenAPITypeArray
+		field.ItemType = OpenAPITypeString
+		field.GoType = TFTypeList
+	} else {
+		field.Type = OpenAPITypeString
+		field.GoType = TFTypeString
+	}
+	CalculateSDKType(&field)
+	return field
+}
@@ -0,0 +1,68 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+)
+
+// networkSchemas returns a Network schema whose subnets refer back to their network
+func networkSchemas() *openapi3.SchemaRef {
+	network := &openapi3.Schema{Type: &openapi3.Types{"object"}, Properties: openapi3.Schemas{}}
+	subnet := &openapi3.Schema{Type: &openapi3.Types{"object"}, Properties: openapi3.Schemas{}}
+	networkRef := &openapi3.SchemaRef{Ref: "#/components/schemas/Network", Value: network}
+	subnetRef := &openapi3.SchemaRef{Ref: "#/components/schemas/Subnet", Value: subnet}
+
+	network.Properties["name"] = &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	network.Properties["subnets"] = &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"array"}, Items: subnetRef}}
+	subnet.Properties["cidr"] = &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	subnet.Properties["network"] = networkRef
+	return networkRef
+}
+
+func TestExtractFields_CircularRefs(t *testing.T) {
+	fields, err := ExtractFields(SchemaConfig{}, networkSchemas(), false)
+	if err != nil {
+		t.Fatalf("ExtractFields failed: %v", err)
+	}
+	if len(fields) != 2 || fields[1].Name != "subnets" || fields[1].ItemSchema == nil {
+		t.Fatalf("expected name and subnets fields, got %v", fields)
+	}
+	var names []string
+	for _, p := range fields[1].ItemSchema.Properties {
+		names = append(names, p.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"cidr"}) {
+		t.Errorf("subnet properties = %v, want [cidr]", names)
+	}
+
+	fields, err = ExtractFields(SchemaConfig{CircularRefs: config.CircularRefsURL}, networkSchemas(), false)
+	if err != nil {
+		t.Fatalf("ExtractFields failed: %v", err)
+	}
+	props := fields[1].ItemSchema.Properties
+	if len(props) != 2 || props[1].Name != "network" {
+		t.Fatalf("expected cidr and network properties, got %v", props)
+	}
+	if !props[1].URLReference || props[1].GoType != TFTypeString || props[1].SDKType != "common.URLReference" {
+		t.Errorf("network = %+v, want a URL reference string", props[1])
+	}
+}
+
+func TestSchemaCycle(t *testing.T) {
+	network := networkSchemas()
+	subnet := network.Value.Properties["subnets"].Value.Items
+
+	// Entered from a component without a $ref, starting at Subnet
+	root := &openapi3.SchemaRef{Value: subnet.Value}
+	got := schemaCycle([]*openapi3.SchemaRef{root, network}, subnet)
+	if want := []string{"Network", "Subnet", "Network"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("schemaCycle() = %v, want %v", got, want)
+	}
+
+	if got := schemaCycle([]*openapi3.SchemaRef{network}, network.Value.Properties["name"]); got != nil {
+		t.Errorf("schemaCycle() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,67 @@
+package common
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// descriptionEscaper escapes values quoted in descriptions, which are rendered in Go string literals
+var descriptionEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ", "\r", "", "\t", " ")
+
+// AttributeDescription returns the description of an attribute followed by its valid values, its bounds
+// and, for resource attributes, the default used when it is not configured, e.g.
+// "Volume type. Valid values: `ssd`, `hdd`. Defaults to `ssd`."
+// Bounds at the limits of int32 come from the format rather than the API, and are left out.
+func AttributeDescription(f FieldInfo) string {
+	description := f.Description
+	if len(f.Enum) > 0 {
+		values := make([]string, len(f.Enum))
+		for i, value := range f.Enum {
+			values[i] = "`" + descriptionEscaper.Replace(value) + "`"
+		}
+		description = AppendSentence(description, "Valid values: "+strings.Join(values, ", ")+".")
+	}
+
+	var minimum, maximum string
+	if f.Minimum != nil && *f.Minimum != math.MinInt32 {
+		minimum = strconv.FormatFloat(*f.Minimum, 'f', -1, 64)
+	}
+	if f.Maximum != nil && *f.Maximum != math.MaxInt32 {
+		maximum = strconv.FormatFloat(*f.Maximum, 'f', -1, 64)
+	}
+	switch {
+	case minimum != "" && maximum != "":
+		description = AppendSentence(description, "Must be between "+minimum+" and "+maximum+".")
+	case minimum != "":
+		description = AppendSentence(description, "Must be at least "+minimum+".")
+	case maximum != "":
+		description = AppendSentence(description, "Must be at most "+maximum+".")
+	}
+
+	// Mirrors the schema, where only optional resource attributes get the default
+	if f.Default != "" && !f.IsDataSource && !f.WriteOnly && !f.ReadOnly && !f.Required {
+		value := f.Default
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		description = AppendSentence(description, "Defaults to `"+descriptionEscaper.Replace(value)+"`.")
+	}
+	return description
+}
+
+// AddResourceHints points the descriptions of top-level UUID fields (e.g., "project_uuid") and reference
+// fields (e.g., "project") at the resource managing the objects they identify. resourceTypes maps the
+// object names (e.g., "project") to full Terraform type names (e.g., "waldur_structure_project").
+func AddResourceHints(fields []FieldInfo, resourceTypes map[string]string) {
+	for i := range fields {
+		f := &fields[i]
+		name, isUUID := strings.CutSuffix(f.Name, "_uuid")
+		if !isUUID && f.ReferencePath == "" {
+			continue
+		}
+		if typeName, ok := resourceTypes[name]; ok && !strings.Contains(f.Description, "`"+typeName+"`") {
+			f.Description = AppendSentence(f.Description, "See the `"+typeName+"` resource.")
+		}
+	}
+}
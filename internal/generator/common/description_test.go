@@ -0,0 +1,76 @@
+package common
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAttributeDescription(t *testing.T) {
+	zero, ten, int32Max := 0.0, 10.0, float64(math.MaxInt32)
+	tests := []struct {
+		name  string
+		field FieldInfo
+		want  string
+	}{
+		{
+			name:  "plain",
+			field: FieldInfo{Description: "Name"},
+			want:  "Name",
+		},
+		{
+			name:  "enum with default",
+			field: FieldInfo{Description: "Volume type", Enum: []string{"ssd", "hdd"}, Default: `"ssd"`},
+			want:  "Volume type. Valid values: `ssd`, `hdd`. Defaults to `ssd`.",
+		},
+		{
+			name:  "bounds",
+			field: FieldInfo{Description: "Size.", Minimum: &zero, Maximum: &ten},
+			want:  "Size. Must be between 0 and 10.",
+		},
+		{
+			name:  "int32 limit",
+			field: FieldInfo{Description: "Cores", Minimum: &ten, Maximum: &int32Max},
+			want:  "Cores. Must be at least 10.",
+		},
+		{
+			name:  "data source default",
+			field: FieldInfo{Description: "Enabled", Default: "true", IsDataSource: true},
+			want:  "Enabled",
+		},
+		{
+			name:  "required default",
+			field: FieldInfo{Description: "Enabled", Default: "true", Required: true},
+			want:  "Enabled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AttributeDescription(tt.field); got != tt.want {
+				t.Errorf("AttributeDescription() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddResourceHints(t *testing.T) {
+	fields := []FieldInfo{
+		{Name: "project_uuid", Description: "UUID of the project"},
+		{Name: "project", Description: "Project", ReferencePath: "/api/projects/{uuid}/"},
+		{Name: "tenant_uuid", Description: "Tenant"},
+		{Name: "project_name", Description: "Name of the project"},
+	}
+	AddResourceHints(fields, map[string]string{"project": "waldur_structure_project"})
+	AddResourceHints(fields, map[string]string{"project": "waldur_structure_project"})
+
+	want := []string{
+		"UUID of the project. See the `waldur_structure_project` resource.",
+		"Project. See the `waldur_structure_project` resource.",
+		"Tenant",
+		"Name of the project",
+	}
+	for i, f := range fields {
+		if f.Description != want[i] {
+			t.Errorf("%s description = %q, want %q", f.Name, f.Description, want[i])
+		}
+	}
+}
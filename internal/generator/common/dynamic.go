@@ -0,0 +1,42 @@
+package common
+
+import "github.com/waldur/terraform-provider-waldur-generator/internal/config"
+
+// DynamicObjectStrategy returns how the dynamic object at path is exposed. Rules for the dotted
+// path take precedence over rules for the plain field name, which take precedence over the global default.
+func DynamicObjectStrategy(cfg SchemaConfig, path, name string) string {
+	for _, key := range []string{path, name} {
+		if override, ok := cfg.FieldOverrides[key]; ok && override.DynamicObject != "" {
+			return override.DynamicObject
+		}
+	}
+	if cfg.DynamicObjects != "" {
+		return cfg.DynamicObjects
+	}
+	return config.DynamicObjectsMap
+}
+
+// ApplyDynamicObjects exposes top-level dynamic objects as normalized JSON strings when the
+// json strategy applies to them, and top-level fields of any type configured with as_json.
+// Nested dynamic objects stay maps: nested values are converted through object attribute types,
+// which have no JSON counterpart.
+func ApplyDynamicObjects(cfg SchemaConfig, fields []FieldInfo) {
+	for i := range fields {
+		f := &fields[i]
+		if cfg.FieldOverrides[f.Name].AsJSON {
+			// The structure is left to the API, and so are its constraints
+			f.Format, f.Enum, f.ItemSchema, f.Properties = "", nil, nil, nil
+			f.Minimum, f.Maximum, f.Pattern, f.MinLength, f.MaxLength = nil, nil, "", 0, nil
+			f.MinItems, f.MaxItems, f.SetKeys = 0, nil, nil
+			f.RefName, f.ItemRefName, f.Discriminator = "", "", ""
+			f.HasDefault, f.Default, f.URLReference = false, "", false
+		} else if !f.DynamicObject || DynamicObjectStrategy(cfg, f.Name, f.Name) != config.DynamicObjectsJSON {
+			continue
+		}
+		f.Type = OpenAPITypeString
+		f.GoType = TFTypeString
+		f.ItemType = ""
+		f.JSON = true
+		CalculateSDKType(f)
+	}
+}
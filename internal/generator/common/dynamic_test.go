@@ -0,0 +1,123 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+)
+
+func TestDynamicObjectStrategy(t *testing.T) {
+	cfg := SchemaConfig{
+		DynamicObjects: config.DynamicObjectsJSON,
+		FieldOverrides: map[string]config.FieldConfig{
+			"limits":      {DynamicObject: config.DynamicObjectsMap},
+			"plan.limits": {DynamicObject: config.DynamicObjectsJSON},
+			"options":     {Computed: true},
+		},
+	}
+
+	tests := []struct {
+		path string
+		name string
+		want string
+	}{
+		{"limits", "limits", config.DynamicObjectsMap},
+		{"plan.limits", "limits", config.DynamicObjectsJSON},
+		{"options", "options", config.DynamicObjectsJSON},
+	}
+	for _, tt := range tests {
+		if got := DynamicObjectStrategy(cfg, tt.path, tt.name); got != tt.want {
+			t.Errorf("DynamicObjectStrategy(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	if got := DynamicObjectStrategy(SchemaConfig{}, "attributes", "attributes"); got != config.DynamicObjectsMap {
+		t.Errorf("default DynamicObjectStrategy = %q, want %q", got, config.DynamicObjectsMap)
+	}
+}
+
+func TestApplyDynamicObjects(t *testing.T) {
+	newField := func(name string, dynamic bool) FieldInfo {
+		f := FieldInfo{Name: name, Type: OpenAPITypeObject, GoType: TFTypeMap, ItemType: OpenAPITypeString, DynamicObject: dynamic}
+		CalculateSDKType(&f)
+		return f
+	}
+
+	tests := []struct {
+		name     string
+		cfg      SchemaConfig
+		field    FieldInfo
+		wantJSON bool
+	}{
+		{
+			name:  "default keeps maps",
+			field: newField("attributes", true),
+		},
+		{
+			name:     "json strategy",
+			cfg:      SchemaConfig{DynamicObjects: config.DynamicObjectsJSON},
+			field:    newField("attributes", true),
+			wantJSON: true,
+		},
+		{
+			name:  "typed map is not dynamic",
+			cfg:   SchemaConfig{DynamicObjects: config.DynamicObjectsJSON},
+			field: newField("limits", false),
+		},
+		{
+			name: "field override",
+			cfg: SchemaConfig{FieldOverrides: map[string]config.FieldConfig{
+				"attributes": {DynamicObject: config.DynamicObjectsJSON},
+			}},
+			field:    newField("attributes", true),
+			wantJSON: true,
+		},
+		{
+			name: "as_json object",
+			cfg: SchemaConfig{FieldOverrides: map[string]config.FieldConfig{
+				"options": {AsJSON: true},
+			}},
+			field: FieldInfo{
+				Name: "options", Type: OpenAPITypeObject, GoType: TFTypeObject, RefName: "OfferingOptions",
+				Properties: []FieldInfo{{Name: "order", Type: OpenAPITypeArray, GoType: TFTypeList, ItemType: OpenAPITypeString}},
+			},
+			wantJSON: true,
+		},
+		{
+			name: "as_json list",
+			cfg: SchemaConfig{FieldOverrides: map[string]config.FieldConfig{
+				"rules": {AsJSON: true},
+			}},
+			field: FieldInfo{
+				Name: "rules", Type: OpenAPITypeArray, GoType: TFTypeSet, ItemType: OpenAPITypeObject, SetKeys: []string{"id"},
+				ItemSchema: &FieldInfo{Properties: []FieldInfo{{Name: "id", Type: OpenAPITypeInteger, GoType: TFTypeInt64}}},
+			},
+			wantJSON: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := []FieldInfo{tt.field}
+			ApplyDynamicObjects(tt.cfg, fields)
+			f := fields[0]
+			if f.JSON != tt.wantJSON {
+				t.Fatalf("JSON = %v, want %v", f.JSON, tt.wantJSON)
+			}
+			if !tt.wantJSON {
+				if f.GoType != TFTypeMap {
+					t.Errorf("GoType = %q, want %q", f.GoType, TFTypeMap)
+				}
+				return
+			}
+			if f.GoType != TFTypeString || f.SDKType != "json.RawMessage" || f.IsPointer {
+				t.Errorf("got GoType %q, SDKType %q, IsPointer %v", f.GoType, f.SDKType, f.IsPointer)
+			}
+			if !f.TypeMeta.IsJSON || f.TypeMeta.IsComplex || f.TypeMeta.FromAPIFunc != "common.JSONValue" {
+				t.Errorf("unexpected TypeMeta %+v", f.TypeMeta)
+			}
+			if f.Properties != nil || f.ItemSchema != nil || f.ItemType != "" || f.RefName != "" || f.SetKeys != nil {
+				t.Errorf("structure kept: properties %v, item schema %v, item type %q, ref %q", f.Properties, f.ItemSchema, f.ItemType, f.RefName)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// EnumType is a named enum schema rendered as a Go type with one constant per value
+type EnumType struct {
+	Name     string      // Schema name, used as the Go type name
+	BaseType string      // Underlying Go type: string, int64 or float64
+	Consts   []EnumConst // Constants in schema order
+}
+
+// EnumConst is a constant of an enum type
+type EnumConst struct {
+	Name  string // Go constant name, e.g. "InstanceStateOK"
+	Value string // Go literal, e.g. `"OK"` or `5`
+}
+
+// NewEnumType returns the Go enum type of a named string, integer or number schema with enum values,
+// or nil for other schemas
+func NewEnumType(name string, schema *openapi3.Schema) *EnumType {
+	typeStr := GetSchemaType(schema)
+	values := EnumValues(schema.Enum, typeStr)
+	if len(values) == 0 {
+		return nil
+	}
+
+	enum := &EnumType{Name: name}
+	switch typeStr {
+	case OpenAPITypeString:
+		enum.BaseType = GoTypeString
+	case OpenAPITypeInteger:
+		enum.BaseType = GoTypeInt64
+	case OpenAPITypeNumber:
+		enum.BaseType = GoTypeFloat64
+	default:
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for _, value := range values {
+		constName := name + enumConstSuffix(value, typeStr)
+		base := constName
+		for i := 2; seen[constName]; i++ {
+			constName = fmt.Sprintf("%s%d", base, i)
+		}
+		seen[constName] = true
+
+		literal := value
+		if typeStr == OpenAPITypeString {
+			literal = strconv.Quote(value)
+		}
+		enum.Consts = append(enum.Consts, EnumConst{Name: constName, Value: literal})
+	}
+	return enum
+}
+
+// enumConstSuffix converts an enum value into an identifier suffix: words of string values are
+// capitalized and joined ("Creation Scheduled" becomes "CreationScheduled"), words of upper-case
+// values are title-cased ("CREATION_SCHEDULED" becomes "CreationScheduled"), and numbers keep
+// their digits ("-1" becomes "Minus1", "0.5" becomes "0_5")
+func enumConstSuffix(value, typeStr string) string {
+	if typeStr != OpenAPITypeString {
+		if rest, ok := strings.CutPrefix(value, "-"); ok {
+			value = "Minus" + rest
+		}
+		return strings.NewReplacer(".", "_", "+", "").Replace(value)
+	}
+
+	allUpper := strings.ToUpper(value) == value
+
+	var b strings.Builder
+	upper := true
+	for _, r := range value {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upper {
+				r = unicode.ToUpper(r)
+			} else if allUpper {
+				r = unicode.ToLower(r)
+			}
+			b.WriteRune(r)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	if b.Len() == 0 {
+		return "Empty"
+	}
+	return b.String()
+}
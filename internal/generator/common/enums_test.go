@@ -0,0 +1,96 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestEnumValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []any
+		typeStr string
+		want    []string
+	}{
+		{"strings", []any{"OK", "Erred"}, OpenAPITypeString, []string{"OK", "Erred"}},
+		{"integers", []any{0.0, 5.0, -1.0}, OpenAPITypeInteger, []string{"0", "5", "-1"}},
+		{"numbers", []any{0.5, 1.0}, OpenAPITypeNumber, []string{"0.5", "1"}},
+		{"mismatched values", []any{"a", 1.5, nil}, OpenAPITypeInteger, []string{}},
+		{"no values", nil, OpenAPITypeString, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EnumValues(tt.values, tt.typeStr); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EnumValues() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewEnumType(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *openapi3.Schema
+		want   *EnumType
+	}{
+		{
+			name:   "string",
+			schema: &openapi3.Schema{Type: &openapi3.Types{"string"}, Enum: []any{"OK", "Creation Scheduled", "OpenStack.Instance", ""}},
+			want: &EnumType{Name: "State", BaseType: GoTypeString, Consts: []EnumConst{
+				{Name: "StateOk", Value: `"OK"`},
+				{Name: "StateCreationScheduled", Value: `"Creation Scheduled"`},
+				{Name: "StateOpenStackInstance", Value: `"OpenStack.Instance"`},
+				{Name: "StateEmpty", Value: `""`},
+			}},
+		},
+		{
+			name:   "upper case",
+			schema: &openapi3.Schema{Type: &openapi3.Types{"string"}, Enum: []any{"CREATION_SCHEDULED", "OK"}},
+			want: &EnumType{Name: "State", BaseType: GoTypeString, Consts: []EnumConst{
+				{Name: "StateCreationScheduled", Value: `"CREATION_SCHEDULED"`},
+				{Name: "StateOk", Value: `"OK"`},
+			}},
+		},
+		{
+			name:   "colliding names",
+			schema: &openapi3.Schema{Type: &openapi3.Types{"string"}, Enum: []any{"half_month", "half-month"}},
+			want: &EnumType{Name: "State", BaseType: GoTypeString, Consts: []EnumConst{
+				{Name: "StateHalfMonth", Value: `"half_month"`},
+				{Name: "StateHalfMonth2", Value: `"half-month"`},
+			}},
+		},
+		{
+			name:   "integer",
+			schema: &openapi3.Schema{Type: &openapi3.Types{"integer"}, Enum: []any{0.0, 5.0, -1.0}},
+			want: &EnumType{Name: "State", BaseType: GoTypeInt64, Consts: []EnumConst{
+				{Name: "State0", Value: "0"},
+				{Name: "State5", Value: "5"},
+				{Name: "StateMinus1", Value: "-1"},
+			}},
+		},
+		{
+			name:   "number",
+			schema: &openapi3.Schema{Type: &openapi3.Types{"number"}, Enum: []any{0.5}},
+			want:   &EnumType{Name: "State", BaseType: GoTypeFloat64, Consts: []EnumConst{{Name: "State0_5", Value: "0.5"}}},
+		},
+		{
+			name:   "object",
+			schema: &openapi3.Schema{Type: &openapi3.Types{"object"}},
+		},
+		{
+			name:   "boolean",
+			schema: &openapi3.Schema{Type: &openapi3.Types{"boolean"}, Enum: []any{true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewEnumType("State", tt.schema); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewEnumType() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
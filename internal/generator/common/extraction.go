@@ -2,69 +2,182 @@ package common
 
 import (
 	"fmt"
+	"math"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
 )
 
 // ExtractFields extracts field information from an OpenAPI schema reference
 // Supports primitive types, enums, arrays (strings, objects), and nested objects
 func ExtractFields(cfg SchemaConfig, schemaRef *openapi3.SchemaRef, skipRootUUID bool) ([]FieldInfo, error) {
-	return extractFieldsRecursive(cfg, schemaRef, "", 0, 3, skipRootUUID) // max depth: 3
+	e := &extractor{
+		cfg:          cfg,
+		skipRootUUID: skipRootUUID,
+		named:        make(map[string][]FieldInfo),
+		reachable:    make(map[*openapi3.Schema]map[*openapi3.Schema]bool),
+	}
+	return e.extractFieldsRecursive(schemaRef, "", nil)
 }
 
-// extractFieldsRecursive extracts field information with depth limiting
-func extractFieldsRecursive(cfg SchemaConfig, schemaRef *openapi3.SchemaRef, pathPrefix string, depth, maxDepth int, skipRootUUID bool) ([]FieldInfo, error) {
-	if schemaRef == nil || schemaRef.Value == nil {
-		return nil, nil
-	}
+// extractor holds the state of one ExtractFields call. Nesting is not limited in depth:
+// circular references are broken instead, and named component schemas nested more than once
+// are extracted once and reused, so deep structures do not expand exponentially.
+type extractor struct {
+	cfg          SchemaConfig
+	skipRootUUID bool
+	named        map[string][]FieldInfo                         // Fields of named schemas, keyed by namedKey
+	reachable    map[*openapi3.Schema]map[*openapi3.Schema]bool // Schemas reachable from a schema
+}
 
-	if depth > maxDepth {
-		return nil, nil // Prevent infinite recursion
+// extractNested extracts the fields of a nested object schema, reusing an earlier extraction
+// of the same named schema when the result cannot differ
+func (e *extractor) extractNested(schemaRef *openapi3.SchemaRef, pathPrefix string, ancestors []*openapi3.SchemaRef) ([]FieldInfo, error) {
+	if schemaRef.Ref == "" || e.hasPathRules(pathPrefix) {
+		return e.extractFieldsRecursive(schemaRef, pathPrefix, ancestors)
 	}
+	key := e.namedKey(schemaRef, ancestors)
+	if fields, ok := e.named[key]; ok {
+		return cloneFields(fields), nil
+	}
+	fields, err := e.extractFieldsRecursive(schemaRef, pathPrefix, ancestors)
+	if err == nil {
+		e.named[key] = cloneFields(fields)
+	}
+	return fields, err
+}
 
-	schema := schemaRef.Value
-	var fields []FieldInfo
-
-	// Build a map of required fields for quick lookup
-	requiredMap := make(map[string]bool)
-	for _, req := range schema.Required {
-		requiredMap[req] = true
+// namedKey identifies the extraction of a named schema. Besides the schema itself it depends
+// only on the enclosing schemas reachable from it, where circular references are broken.
+func (e *extractor) namedKey(schemaRef *openapi3.SchemaRef, ancestors []*openapi3.SchemaRef) string {
+	reachable := e.reachableSchemas(schemaRef.Value)
+	var enclosing []string
+	for _, ancestor := range ancestors {
+		if reachable[ancestor.Value] {
+			enclosing = append(enclosing, fmt.Sprintf("%p", ancestor.Value))
+		}
 	}
+	sort.Strings(enclosing)
+	return schemaRef.Ref + "|" + strings.Join(enclosing, ",")
+}
 
-	// Flatten allOf if present
-	if len(schema.AllOf) > 0 {
-		for _, subSchemaRef := range schema.AllOf {
-			if subSchemaRef.Value == nil {
-				continue
-			}
-			// Merge properties from allOf schema
-			for name, prop := range subSchemaRef.Value.Properties {
-				if schema.Properties == nil {
-					schema.Properties = make(map[string]*openapi3.SchemaRef)
-				}
-				if _, exists := schema.Properties[name]; !exists {
-					schema.Properties[name] = prop
-				}
+// reachableSchemas returns the schemas nested in a schema, directly or indirectly
+func (e *extractor) reachableSchemas(schema *openapi3.Schema) map[*openapi3.Schema]bool {
+	if reachable, ok := e.reachable[schema]; ok {
+		return reachable
+	}
+	reachable := make(map[*openapi3.Schema]bool)
+	var walk func(s *openapi3.Schema)
+	walk = func(s *openapi3.Schema) {
+		visit := func(ref *openapi3.SchemaRef) {
+			if ref != nil && ref.Value != nil && !reachable[ref.Value] {
+				reachable[ref.Value] = true
+				walk(ref.Value)
 			}
-			// Merge required fields
-			for _, req := range subSchemaRef.Value.Required {
-				requiredMap[req] = true
+		}
+		for _, prop := range s.Properties {
+			visit(prop)
+		}
+		visit(s.Items)
+		visit(s.AdditionalProperties.Schema)
+		for _, group := range []openapi3.SchemaRefs{s.AllOf, s.OneOf, s.AnyOf} {
+			for _, variant := range group {
+				visit(variant)
 			}
 		}
 	}
+	walk(schema)
+	e.reachable[schema] = reachable
+	return reachable
+}
+
+// hasPathRules reports whether field rules target dotted paths below pathPrefix, which makes
+// the extraction of the schema at pathPrefix specific to that path
+func (e *extractor) hasPathRules(pathPrefix string) bool {
+	prefix := pathPrefix + "."
+	for path := range e.cfg.ExcludedFields {
+		// Dotted patterns and regular expressions may match paths below any prefix
+		if strings.HasPrefix(path, prefix) || (config.IsFieldPattern(path) && strings.ContainsAny(path, "./")) {
+			return true
+		}
+	}
+	for path := range e.cfg.SetFields {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	for path := range e.cfg.TypeCoercions {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	for path := range e.cfg.FieldOverrides {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneFields returns a deep copy of fields
+func cloneFields(fields []FieldInfo) []FieldInfo {
+	if fields == nil {
+		return nil
+	}
+	clone := make([]FieldInfo, len(fields))
+	for i, f := range fields {
+		clone[i] = f.Clone()
+	}
+	return clone
+}
+
+// mapValueFields returns the fields of the values of a map, or nil unless the values are objects
+// with known properties. Value fields are addressed by the path of the map (e.g., "quotas.limit").
+func (e *extractor) mapValueFields(prop *openapi3.Schema, pathPrefix string, ancestors []*openapi3.SchemaRef) []FieldInfo {
+	values := prop.AdditionalProperties.Schema
+	if values == nil || values.Value == nil || GetSchemaType(values.Value) != OpenAPITypeObject {
+		return nil
+	}
+	// Values referring back to an enclosing schema stay dynamic
+	if schemaCycle(ancestors, values) != nil {
+		return nil
+	}
+	fields, err := e.extractNested(values, pathPrefix, ancestors)
+	if err != nil {
+		return nil
+	}
+	return fields
+}
+
+// extractFieldsRecursive extracts the fields of an object schema at pathPrefix.
+// ancestors holds the enclosing schemas, used to detect circular references.
+func (e *extractor) extractFieldsRecursive(schemaRef *openapi3.SchemaRef, pathPrefix string, ancestors []*openapi3.SchemaRef) ([]FieldInfo, error) {
+	if schemaRef == nil || schemaRef.Value == nil {
+		return nil, nil
+	}
+	cfg := e.cfg
+	ancestors = append(ancestors[:len(ancestors):len(ancestors)], schemaRef)
+
+	schema := schemaRef.Value
+	var fields []FieldInfo
+
+	// Merge the properties of allOf members
+	properties, requiredMap := allOfProperties(cfg, pathPrefix, schema)
 
 	// Extract fields from properties
 	var propNames []string
-	for name := range schema.Properties {
+	for name := range properties {
 		propNames = append(propNames, name)
 	}
 	sort.Strings(propNames)
 
 	for _, propName := range propNames {
 		// Skip uuid field if requested (hard-coded in templates with tfsdk:"id")
-		if depth == 0 && strings.ToLower(propName) == "uuid" && skipRootUUID {
+		if pathPrefix == "" && strings.ToLower(propName) == "uuid" && e.skipRootUUID {
 			continue
 		}
 
@@ -73,11 +186,11 @@ func extractFieldsRecursive(cfg SchemaConfig, schemaRef *openapi3.SchemaRef, pat
 			fullPath = pathPrefix + "." + propName
 		}
 
-		if cfg.ExcludedFields[propName] || cfg.ExcludedFields[fullPath] {
+		if IsExcludedField(cfg, fullPath, propName) {
 			continue
 		}
 
-		propSchema := schema.Properties[propName]
+		propSchema := properties[propName]
 		if propSchema == nil || propSchema.Value == nil {
 			continue
 		}
@@ -85,10 +198,13 @@ func extractFieldsRecursive(cfg SchemaConfig, schemaRef *openapi3.SchemaRef, pat
 		prop := propSchema.Value
 		typeStr := GetSchemaType(prop)
 
-		// Override incorrect schema types for billing fields
-		if (propName == "total" || propName == "tax" || propName == "tax_current" || propName == "current") && typeStr == "string" {
-			typeStr = "number"
-			prop.Pattern = "" // Clear string-only pattern
+		// Use the type the API actually returns when it differs from the declared one
+		if target := CoercedType(cfg, fullPath, propName); target != "" && isScalarType(typeStr) {
+			typeStr = target
+		}
+		pattern := ""
+		if typeStr == OpenAPITypeString {
+			pattern = validPattern(cfg, fullPath, prop.Pattern)
 		}
 
 		refName := ""
@@ -97,6 +213,15 @@ func extractFieldsRecursive(cfg SchemaConfig, schemaRef *openapi3.SchemaRef, pat
 			refName = parts[len(parts)-1]
 		}
 
+		// Replace oneOf/anyOf unions of objects according to the configured strategy
+		union, err := resolveUnion(cfg, fullPath, propName, prop)
+		if err != nil {
+			return nil, err
+		}
+		if union != nil {
+			propSchema, prop, refName = union.Schema, union.Schema.Value, ""
+		}
+
 		description := SanitizeString(prop.Description)
 		if description == "" {
 			description = Humanize(propName)
@@ -110,10 +235,25 @@ func extractFieldsRecursive(cfg SchemaConfig, schemaRef *openapi3.SchemaRef, pat
 			ReadOnly:    prop.ReadOnly,
 			Description: description,
 			RefName:     refName,
-			Minimum:     prop.Min,
-			Maximum:     prop.Max,
-			Pattern:     prop.Pattern,
+			Minimum:     inclusiveBound(prop.Min, prop.ExclusiveMin, typeStr, 1),
+			Maximum:     inclusiveBound(prop.Max, prop.ExclusiveMax, typeStr, -1),
+			Pattern:     pattern,
 			HasDefault:  prop.Default != nil,
+			Nullable:    prop.Nullable,
+			Secret:      prop.WriteOnly,
+			Deprecated:  prop.Deprecated,
+		}
+		if !cfg.IgnoreDefaults && prop.Format != "date-time" {
+			field.Default = defaultLiteral(prop.Default, typeStr)
+		}
+		if typeStr == OpenAPITypeInteger && prop.Format == "int32" {
+			field.Minimum, field.Maximum = int32Bounds(field.Minimum, field.Maximum)
+		}
+		switch typeStr {
+		case OpenAPITypeString:
+			field.MinLength, field.MaxLength = int64(prop.MinLength), upperBound(prop.MaxLength)
+		case OpenAPITypeArray:
+			field.MinItems, field.MaxItems = int64(prop.MinItems), upperBound(prop.MaxItems)
 		}
 
 		// Apply overrides
@@ -123,15 +263,34 @@ func extractFieldsRecursive(cfg SchemaConfig, schemaRef *openapi3.SchemaRef, pat
 				field.UseStateForUnknown = true
 			}
 			field.UnknownIfNull = override.UnknownIfNull
+			if override.IgnoreDefault {
+				field.Default = ""
+			}
+			field.WriteOnly = override.WriteOnly
 			if override.Optional {
 				field.Required = false
 			}
 			if override.Required {
 				field.Required = true
 			}
-			if override.ForceNew {
-				field.ForceNew = true
+			if override.ForceNew != nil {
+				field.ForceNew = *override.ForceNew
 			}
+			field.SetKeys = override.Keys
+			field.ReplaceIf = override.ReplaceIf
+		}
+
+		// Break references back to an enclosing schema
+		nested := propSchema
+		if typeStr == OpenAPITypeArray {
+			nested = prop.Items
+		}
+		if cycle := schemaCycle(ancestors, nested); cycle != nil {
+			reportCycle(cfg, fullPath, cycle)
+			if cfg.CircularRefs == config.CircularRefsURL {
+				fields = append(fields, urlReferenceField(field, typeStr == OpenAPITypeArray))
+			}
+			continue
 		}
 
 		// Handle different types
@@ -141,35 +300,41 @@ func extractFieldsRecursive(cfg SchemaConfig, schemaRef *openapi3.SchemaRef, pat
 		CalculateSDKType(&field)
 
 		switch typeStr {
-		case OpenAPITypeString:
-			// Check for enum
-			if len(prop.Enum) > 0 {
-				field.Enum = make([]string, 0, len(prop.Enum))
-				for _, e := range prop.Enum {
-					if str, ok := e.(string); ok {
-						field.Enum = append(field.Enum, str)
-					}
-				}
-			}
+		case OpenAPITypeString, OpenAPITypeInteger, OpenAPITypeNumber:
+			field.Enum = EnumValues(prop.Enum, typeStr)
 			fields = append(fields, field)
 
-		case OpenAPITypeInteger, OpenAPITypeBoolean, OpenAPITypeNumber:
+		case OpenAPITypeBoolean:
 			fields = append(fields, field)
 
 		case OpenAPITypeArray:
 			// Extract array item type
 			if prop.Items != nil && prop.Items.Value != nil {
-				itemType := GetSchemaType(prop.Items.Value)
+				items := prop.Items
+				itemType := GetSchemaType(items.Value)
+				// Coercions of arrays apply to their items
+				if target := CoercedType(cfg, fullPath, propName); target != "" && isScalarType(itemType) {
+					itemType = target
+				}
 				field.ItemType = itemType
 
 				// Extract item ref name
-				if prop.Items.Ref != "" {
-					parts := strings.Split(prop.Items.Ref, "/")
+				if items.Ref != "" {
+					parts := strings.Split(items.Ref, "/")
 					field.ItemRefName = parts[len(parts)-1]
 				}
 
+				itemUnion, err := resolveUnion(cfg, fullPath, propName, items.Value)
+				if err != nil {
+					return nil, err
+				}
+				if itemUnion != nil {
+					items = itemUnion.Schema
+					field.ItemRefName = ""
+				}
+
 				if itemType == OpenAPITypeString {
-					if IsSetField(cfg, propName) {
+					if IsSetField(cfg, fullPath, propName, prop.UniqueItems) {
 						field.GoType = TFTypeSet
 					} else {
 						field.GoType = TFTypeList
@@ -179,7 +344,7 @@ func extractFieldsRecursive(cfg SchemaConfig, schemaRef *openapi3.SchemaRef, pat
 					fields = append(fields, field)
 				} else if itemType == OpenAPITypeObject {
 					// Array of objects - extract nested schema
-					if nestedFields, err := extractFieldsRecursive(cfg, prop.Items, fullPath, depth+1, maxDepth, false); err == nil && len(nestedFields) > 0 {
+					if nestedFields, err := e.extractNested(items, fullPath, ancestors); err == nil && len(nestedFields) > 0 {
 						// Store first nested field as representative schema
 						if len(nestedFields) > 0 {
 							field.ItemSchema = &FieldInfo{
@@ -188,10 +353,11 @@ func extractFieldsRecursive(cfg SchemaConfig, schemaRef *openapi3.SchemaRef, pat
 								Properties: nestedFields,
 								RefName:    field.ItemRefName, // Propagate ref name to item schema
 							}
+							itemUnion.apply(field.ItemSchema)
 							CalculateSDKType(field.ItemSchema)
 						}
 
-						if IsSetField(cfg, propName) {
+						if IsSetField(cfg, fullPath, propName, prop.UniqueItems) {
 							field.GoType = TFTypeSet
 						} else {
 							field.GoType = TFTypeList
@@ -201,7 +367,7 @@ func extractFieldsRecursive(cfg SchemaConfig, schemaRef *openapi3.SchemaRef, pat
 					}
 				} else {
 					// Other primitive arrays (integer, etc)
-					if IsSetField(cfg, propName) {
+					if IsSetField(cfg, fullPath, propName, prop.UniqueItems) {
 						field.GoType = TFTypeSet
 					} else {
 						field.GoType = TFTypeList
@@ -213,9 +379,26 @@ func extractFieldsRecursive(cfg SchemaConfig, schemaRef *openapi3.SchemaRef, pat
 
 		case OpenAPITypeObject:
 			// Nested object - extract properties
-			if nestedFields, err := extractFieldsRecursive(cfg, propSchema, fullPath, depth+1, maxDepth, false); err == nil && len(nestedFields) > 0 {
+			if nestedFields, err := e.extractNested(propSchema, fullPath, ancestors); err == nil && len(nestedFields) > 0 {
 				field.Properties = nestedFields
 				field.GoType = TFTypeObject
+				union.apply(&field)
+				CalculateSDKType(&field)
+				fields = append(fields, field)
+			} else if valueFields := e.mapValueFields(prop, fullPath, ancestors); len(valueFields) > 0 {
+				// Maps with object values keep the structure of their values
+				field.GoType = TFTypeMap
+				field.ItemType = OpenAPITypeObject
+				if ref := prop.AdditionalProperties.Schema.Ref; ref != "" {
+					field.ItemRefName = ref[strings.LastIndex(ref, "/")+1:]
+				}
+				field.ItemSchema = &FieldInfo{
+					Type:       OpenAPITypeObject,
+					GoType:     TFTypeObject,
+					Properties: valueFields,
+					RefName:    field.ItemRefName,
+				}
+				CalculateSDKType(field.ItemSchema)
 				CalculateSDKType(&field)
 				fields = append(fields, field)
 			} else if prop.AdditionalProperties.Schema != nil && prop.AdditionalProperties.Schema.Value != nil {
@@ -223,13 +406,13 @@ func extractFieldsRecursive(cfg SchemaConfig, schemaRef *openapi3.SchemaRef, pat
 				field.GoType = TFTypeMap
 				itemType := GetSchemaType(prop.AdditionalProperties.Schema.Value)
 
-				// Special case: 'prices' and 'switch_price' are defined as numbers but returned as strings
-				// 'quotas' and 'marketplace_resource_count' are numbers and returned as numbers
-				if itemType == OpenAPITypeNumber && (propName == "prices" || propName == "switch_price") {
-					field.ItemType = OpenAPITypeString
-				} else {
-					field.ItemType = itemType
+				// Coercions of maps apply to their values
+				if target := CoercedType(cfg, fullPath, propName); target != "" && isScalarType(itemType) {
+					itemType = target
 				}
+				field.ItemType = itemType
+				// Untyped or object values make the map as dynamic as a free-form object
+				field.DynamicObject = itemType == "" || itemType == OpenAPITypeObject
 
 				CalculateSDKType(&field)
 				fields = append(fields, field)
@@ -237,6 +420,7 @@ func extractFieldsRecursive(cfg SchemaConfig, schemaRef *openapi3.SchemaRef, pat
 				// Handle generic/dynamic objects (like attributes) as maps
 				field.GoType = TFTypeMap
 				field.ItemType = OpenAPITypeString // Default to Map[String]String
+				field.DynamicObject = true
 				CalculateSDKType(&field)
 				fields = append(fields, field)
 			}
@@ -246,12 +430,121 @@ func extractFieldsRecursive(cfg SchemaConfig, schemaRef *openapi3.SchemaRef, pat
 	return fields, nil
 }
 
+// isScalarType reports whether an OpenAPI type is a string, integer, number or boolean
+func isScalarType(typeStr string) bool {
+	switch typeStr {
+	case OpenAPITypeString, OpenAPITypeInteger, OpenAPITypeNumber, OpenAPITypeBoolean:
+		return true
+	}
+	return false
+}
+
+// inclusiveBound converts an exclusive integer bound into the inclusive one used by validators.
+// Other bounds are returned unchanged.
+func inclusiveBound(bound *float64, exclusive bool, typeStr string, step float64) *float64 {
+	if bound == nil || !exclusive || typeStr != OpenAPITypeInteger {
+		return bound
+	}
+	v := *bound + step
+	return &v
+}
+
+// EnumValues returns the enum values of a string, integer or number schema as they are written
+// in Go source, without quotes for strings. Values that do not match the type are dropped.
+func EnumValues(values []any, typeStr string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	enum := make([]string, 0, len(values))
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			if typeStr == OpenAPITypeString {
+				enum = append(enum, v)
+			}
+		case float64:
+			if typeStr == OpenAPITypeInteger && v == math.Trunc(v) {
+				enum = append(enum, strconv.FormatFloat(v, 'f', 0, 64))
+			} else if typeStr == OpenAPITypeNumber {
+				enum = append(enum, strconv.FormatFloat(v, 'g', -1, 64))
+			}
+		}
+	}
+	return enum
+}
+
+// defaultLiteral returns the Go literal of a scalar default value, or "" when it is not set
+// or does not match the type
+func defaultLiteral(value any, typeStr string) string {
+	switch v := value.(type) {
+	case string:
+		if typeStr == OpenAPITypeString {
+			return strconv.Quote(v)
+		}
+	case bool:
+		if typeStr == OpenAPITypeBoolean {
+			return strconv.FormatBool(v)
+		}
+	case float64:
+		if typeStr == OpenAPITypeInteger && v == math.Trunc(v) {
+			return strconv.FormatFloat(v, 'f', 0, 64)
+		}
+		if typeStr == OpenAPITypeNumber {
+			return strconv.FormatFloat(v, 'g', -1, 64)
+		}
+	}
+	return ""
+}
+
+// int32Bounds narrows integer bounds to the range of int32, which Terraform stores as int64
+func int32Bounds(min, max *float64) (*float64, *float64) {
+	if min == nil || *min < math.MinInt32 {
+		v := float64(math.MinInt32)
+		min = &v
+	}
+	if max == nil || *max > math.MaxInt32 {
+		v := float64(math.MaxInt32)
+		max = &v
+	}
+	return min, max
+}
+
+// validPattern returns the pattern of a string field, or "" when Go cannot compile it.
+// OpenAPI patterns follow ECMA-262, which allows constructs RE2 does not support (e.g., lookaheads);
+// those are left out with a warning instead of failing when the provider starts.
+func validPattern(cfg SchemaConfig, path, pattern string) string {
+	if pattern == "" {
+		return ""
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		if cfg.ReportedPatterns == nil || !cfg.ReportedPatterns[pattern] {
+			fmt.Printf("Warning: pattern %s of %s is not a valid Go regular expression, validator left out: %v\n", pattern, path, err)
+		}
+		if cfg.ReportedPatterns != nil {
+			cfg.ReportedPatterns[pattern] = true
+		}
+		return ""
+	}
+	return pattern
+}
+
+// upperBound converts an optional length or size limit to the int64 used by validators
+func upperBound(bound *uint64) *int64 {
+	if bound == nil {
+		return nil
+	}
+	v := int64(*bound)
+	return &v
+}
+
 // GetSchemaType extracts the type string from openapi3.Schema
 func GetSchemaType(schema *openapi3.Schema) string {
 	if schema.Type != nil {
-		// Types can be a slice, take the first one
-		if len(*schema.Type) > 0 {
-			return (*schema.Type)[0]
+		// Types can be a slice, take the first one that is not "null"
+		for _, t := range *schema.Type {
+			if t != "null" {
+				return t
+			}
 		}
 	}
 
@@ -287,23 +580,37 @@ func ExtractFilterParams(op *openapi3.Operation, resourceName string) []FilterPa
 				continue
 			}
 			if param.Schema != nil && param.Schema.Value != nil {
-				typeStr := GetSchemaType(param.Schema.Value)
+				valueSchema := param.Schema.Value
+				typeStr := GetSchemaType(valueSchema)
+
+				// Arrays of scalars become list filters, sent as repeated parameters
+				// (style: form, explode: true, the default) or as one comma-separated value
+				isList := typeStr == OpenAPITypeArray
+				if isList {
+					if valueSchema.Items == nil || valueSchema.Items.Value == nil {
+						continue
+					}
+					valueSchema = valueSchema.Items.Value
+					typeStr = GetSchemaType(valueSchema)
+				}
+
 				goType := GetGoType(typeStr)
 				if goType == "" || strings.HasPrefix(goType, TFTypeList) || strings.HasPrefix(goType, TFTypeObject) {
 					continue
 				}
 
-				var enumValues []string
-				for _, val := range param.Schema.Value.Enum {
-					enumValues = append(enumValues, fmt.Sprintf("%v", val))
-				}
-
-				filterParams = append(filterParams, FilterParam{
+				filterParam := FilterParam{
 					Name:        param.Name,
 					Type:        GetFilterParamType(goType),
 					Description: param.Description,
-					Enum:        enumValues,
-				})
+					Enum:        EnumValues(valueSchema.Enum, typeStr),
+				}
+				if isList {
+					filterParam.ItemType = filterParam.Type
+					filterParam.Type = "List"
+					filterParam.Explode = param.Explode == nil || *param.Explode
+				}
+				filterParams = append(filterParams, filterParam)
 			}
 		}
 	}
@@ -318,6 +625,68 @@ func ExtractFilterParams(op *openapi3.Operation, resourceName string) []FilterPa
 	return filterParams
 }
 
+// ExtractPagination returns the pagination of a list operation, detected from its page and
+// page_size query parameters and the x-result-count header of its success response.
+// It returns nil when the operation has no page parameter.
+func ExtractPagination(op *openapi3.Operation) *Pagination {
+	if op == nil {
+		return nil
+	}
+
+	var p Pagination
+	for _, paramRef := range op.Parameters {
+		if paramRef.Value == nil || paramRef.Value.In != openapi3.ParameterInQuery {
+			continue
+		}
+		switch paramRef.Value.Name {
+		case "page":
+			p.PageParam = paramRef.Value.Name
+		case "page_size":
+			p.PageSizeParam = paramRef.Value.Name
+		}
+	}
+	if p.PageParam == "" {
+		return nil
+	}
+
+	if op.Responses != nil {
+		if resp := op.Responses.Status(200); resp != nil && resp.Value != nil {
+			for name := range resp.Value.Headers {
+				if strings.EqualFold(name, "x-result-count") {
+					p.CountHeader = name
+				}
+			}
+		}
+	}
+	return &p
+}
+
+// ExtractOrdering returns the ordering of a list operation, taken from its "o" query parameter.
+// It returns nil when the operation cannot order its results.
+func ExtractOrdering(op *openapi3.Operation) *Ordering {
+	if op == nil {
+		return nil
+	}
+
+	for _, paramRef := range op.Parameters {
+		param := paramRef.Value
+		if param == nil || param.In != openapi3.ParameterInQuery || param.Name != "o" {
+			continue
+		}
+		o := Ordering{Explode: true}
+		if param.Schema != nil && param.Schema.Value != nil {
+			valueSchema := param.Schema.Value
+			if GetSchemaType(valueSchema) == OpenAPITypeArray && valueSchema.Items != nil && valueSchema.Items.Value != nil {
+				valueSchema = valueSchema.Items.Value
+				o.Explode = param.Explode == nil || *param.Explode
+			}
+			o.Values = EnumValues(valueSchema.Enum, OpenAPITypeString)
+		}
+		return &o
+	}
+	return nil
+}
+
 // GetGoType maps OpenAPI types to Terraform Plugin Framework types
 func GetGoType(openAPIType string) string {
 	switch openAPIType {
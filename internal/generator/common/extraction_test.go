@@ -0,0 +1,266 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+)
+
+// fieldPaths lists the dotted paths of fields and their nested properties
+func fieldPaths(prefix string, fields []FieldInfo) []string {
+	var paths []string
+	for _, f := range fields {
+		path := prefix + f.Name
+		paths = append(paths, path)
+		if f.ItemSchema != nil {
+			paths = append(paths, fieldPaths(path+".", f.ItemSchema.Properties)...)
+		}
+		paths = append(paths, fieldPaths(path+".", f.Properties)...)
+	}
+	return paths
+}
+
+func TestExtractFields_DeepNesting(t *testing.T) {
+	str := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	// Six levels of nested objects, the innermost one holding a string
+	schema := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"object"}, Properties: openapi3.Schemas{"name": str}}}
+	for i := 0; i < 6; i++ {
+		schema = &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"object"}, Properties: openapi3.Schemas{"inner": schema}}}
+	}
+
+	fields, err := ExtractFields(SchemaConfig{}, schema, false)
+	if err != nil {
+		t.Fatalf("ExtractFields failed: %v", err)
+	}
+	paths := fieldPaths("", fields)
+	want := "inner.inner.inner.inner.inner.inner.name"
+	if paths[len(paths)-1] != want {
+		t.Errorf("innermost field = %q, want %q", paths[len(paths)-1], want)
+	}
+}
+
+func TestExtractFields_NamedSchemaReuse(t *testing.T) {
+	str := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	ip := &openapi3.SchemaRef{Ref: "#/components/schemas/FixedIP", Value: &openapi3.Schema{
+		Type:       &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{"ip_address": str, "subnet_id": str},
+	}}
+	port := &openapi3.SchemaRef{Ref: "#/components/schemas/Port", Value: &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{
+			"fixed_ips":   {Value: &openapi3.Schema{Type: &openapi3.Types{"array"}, Items: ip}},
+			"floating_ip": ip,
+		},
+	}}
+	root := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type:       &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{"internal": port, "external": port},
+	}}
+
+	tests := []struct {
+		name string
+		cfg  SchemaConfig
+		want []string
+	}{
+		{
+			name: "shared schemas",
+			want: []string{
+				"external", "external.fixed_ips", "external.fixed_ips.ip_address", "external.fixed_ips.subnet_id",
+				"external.floating_ip", "external.floating_ip.ip_address", "external.floating_ip.subnet_id",
+				"internal", "internal.fixed_ips", "internal.fixed_ips.ip_address", "internal.fixed_ips.subnet_id",
+				"internal.floating_ip", "internal.floating_ip.ip_address", "internal.floating_ip.subnet_id",
+			},
+		},
+		{
+			name: "path rules",
+			cfg:  SchemaConfig{ExcludedFields: map[string]bool{"internal.fixed_ips.subnet_id": true}},
+			want: []string{
+				"external", "external.fixed_ips", "external.fixed_ips.ip_address", "external.fixed_ips.subnet_id",
+				"external.floating_ip", "external.floating_ip.ip_address", "external.floating_ip.subnet_id",
+				"internal", "internal.fixed_ips", "internal.fixed_ips.ip_address",
+				"internal.floating_ip", "internal.floating_ip.ip_address", "internal.floating_ip.subnet_id",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := ExtractFields(tt.cfg, root, false)
+			if err != nil {
+				t.Fatalf("ExtractFields failed: %v", err)
+			}
+			if got := fieldPaths("", fields); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paths = %v, want %v", got, tt.want)
+			}
+			// Reused fields are copies
+			fields[0].Properties[1].Properties[0].Name = "changed"
+			if fields[1].Properties[1].Properties[0].Name != "ip_address" {
+				t.Error("reused fields share their properties")
+			}
+		})
+	}
+}
+
+func TestExtractFields_NamedSchemaReuseWithCycles(t *testing.T) {
+	network := networkSchemas()
+	subnet := network.Value.Properties["subnets"].Value.Items
+	root := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type:       &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{"network": network, "subnet": subnet},
+	}}
+
+	fields, err := ExtractFields(SchemaConfig{CircularRefs: config.CircularRefsURL}, root, false)
+	if err != nil {
+		t.Fatalf("ExtractFields failed: %v", err)
+	}
+	// Subnet is cut at a different reference depending on the enclosing schemas
+	want := []string{
+		"network", "network.name", "network.subnets", "network.subnets.cidr", "network.subnets.network",
+		"subnet", "subnet.cidr", "subnet.network", "subnet.network.name", "subnet.network.subnets",
+	}
+	if got := fieldPaths("", fields); !reflect.DeepEqual(got, want) {
+		t.Errorf("paths = %v, want %v", got, want)
+	}
+}
+
+func TestExtractFields_MapOfObjects(t *testing.T) {
+	integer := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}}}
+	quota := &openapi3.SchemaRef{Ref: "#/components/schemas/Quota", Value: &openapi3.Schema{
+		Type:       &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{"limit": integer, "usage": integer},
+	}}
+	root := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{
+			"attributes": {Value: &openapi3.Schema{
+				Type:                 &openapi3.Types{"object"},
+				AdditionalProperties: openapi3.AdditionalProperties{Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"object"}}}},
+			}},
+			"quotas": {Value: &openapi3.Schema{
+				Type:                 &openapi3.Types{"object"},
+				AdditionalProperties: openapi3.AdditionalProperties{Schema: quota},
+			}},
+		},
+	}}
+
+	fields, err := ExtractFields(SchemaConfig{}, root, false)
+	if err != nil {
+		t.Fatalf("ExtractFields failed: %v", err)
+	}
+	if len(fields) != 2 {
+		t.Fatalf("expected attributes and quotas fields, got %v", fields)
+	}
+
+	attributes := fields[0]
+	if attributes.IsObjectMap() || !attributes.DynamicObject || attributes.SDKType != "map[string]"+GoTypeAny {
+		t.Errorf("attributes = %+v, want a dynamic map", attributes)
+	}
+
+	quotas := fields[1]
+	if !quotas.IsObjectMap() || quotas.DynamicObject {
+		t.Fatalf("quotas = %+v, want a map of objects", quotas)
+	}
+	if quotas.SDKType != "map[string]Quota" || quotas.TypeMeta.SchemaAttrType != "schema.MapNestedAttribute" {
+		t.Errorf("quotas SDKType = %q, SchemaAttrType = %q", quotas.SDKType, quotas.TypeMeta.SchemaAttrType)
+	}
+	if got := fieldPaths("", quotas.ItemSchema.Properties); !reflect.DeepEqual(got, []string{"limit", "usage"}) {
+		t.Errorf("quota properties = %v, want [limit usage]", got)
+	}
+}
+
+func TestExtractFields_TypeCoercions(t *testing.T) {
+	decimal := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Pattern: `^-?\d+(?:\.\d+)?$`}}
+	root := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{
+			"total": decimal,
+			"plan": {Value: &openapi3.Schema{
+				Type:       &openapi3.Types{"object"},
+				Properties: openapi3.Schemas{"total": decimal},
+			}},
+			"prices": {Value: &openapi3.Schema{
+				Type:                 &openapi3.Types{"object"},
+				AdditionalProperties: openapi3.AdditionalProperties{Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"number"}}}},
+			}},
+		},
+	}}
+	cfg := SchemaConfig{TypeCoercions: map[string]string{"total": OpenAPITypeNumber, "plan.total": OpenAPITypeInteger, "prices": OpenAPITypeString}}
+
+	fields, err := ExtractFields(cfg, root, false)
+	if err != nil {
+		t.Fatalf("ExtractFields failed: %v", err)
+	}
+	plan, prices, total := fields[0], fields[1], fields[2]
+	if total.Type != OpenAPITypeNumber || total.GoType != TFTypeFloat64 || total.Pattern != "" {
+		t.Errorf("total = %s %s pattern %q, want a number without pattern", total.Type, total.GoType, total.Pattern)
+	}
+	if got := plan.Properties[0]; got.Type != OpenAPITypeInteger {
+		t.Errorf("plan.total type = %s, want the dotted path rule %s", got.Type, OpenAPITypeInteger)
+	}
+	if prices.ItemType != OpenAPITypeString || prices.SDKType != "map[string]string" {
+		t.Errorf("prices = %s %s, want a map of strings", prices.ItemType, prices.SDKType)
+	}
+	// The schema itself is left unchanged
+	if decimal.Value.Pattern == "" {
+		t.Error("coercion cleared the pattern of the schema")
+	}
+}
+
+func TestExtractFields_LengthAndSize(t *testing.T) {
+	five := uint64(5)
+	str := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	root := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{
+			"name": {Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, MinLength: 1, MaxLength: &five}},
+			"tags": {Value: &openapi3.Schema{Type: &openapi3.Types{"array"}, Items: str, MinItems: 1, MaxItems: &five, UniqueItems: true}},
+			"ips":  {Value: &openapi3.Schema{Type: &openapi3.Types{"array"}, Items: str}},
+		},
+	}}
+
+	fields, err := ExtractFields(SchemaConfig{}, root, false)
+	if err != nil {
+		t.Fatalf("ExtractFields failed: %v", err)
+	}
+	ips, name, tags := fields[0], fields[1], fields[2]
+	if name.MinLength != 1 || name.MaxLength == nil || *name.MaxLength != 5 {
+		t.Errorf("name length = [%d, %v], want [1, 5]", name.MinLength, name.MaxLength)
+	}
+	if tags.MinItems != 1 || tags.MaxItems == nil || *tags.MaxItems != 5 || tags.GoType != TFTypeSet {
+		t.Errorf("tags = %s of [%d, %v] items, want a Set of 1 to 5", tags.GoType, tags.MinItems, tags.MaxItems)
+	}
+	if ips.MinItems != 0 || ips.MaxItems != nil || ips.GoType != TFTypeList {
+		t.Errorf("ips = %s of [%d, %v] items, want an unconstrained List", ips.GoType, ips.MinItems, ips.MaxItems)
+	}
+}
+
+func TestExtractFields_Patterns(t *testing.T) {
+	root := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{
+			"count":    {Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}, Pattern: `^\d+$`}},
+			"name":     {Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Pattern: "^[a-z`]+$"}},
+			"password": {Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Pattern: `^(?=.*\d).{8,}$`}},
+		},
+	}}
+	reported := map[string]bool{}
+
+	fields, err := ExtractFields(SchemaConfig{ReportedPatterns: reported}, root, false)
+	if err != nil {
+		t.Fatalf("ExtractFields failed: %v", err)
+	}
+	want := map[string]string{
+		"count":    "",          // Not a string
+		"name":     "^[a-z`]+$", // Rendered as a quoted string
+		"password": "",          // Lookaheads are not supported by RE2
+	}
+	for _, f := range fields {
+		if f.Pattern != want[f.Name] {
+			t.Errorf("%s pattern = %q, want %q", f.Name, f.Pattern, want[f.Name])
+		}
+	}
+	if !reported[`^(?=.*\d).{8,}$`] {
+		t.Error("invalid pattern was not reported")
+	}
+}
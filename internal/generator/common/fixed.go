@@ -0,0 +1,32 @@
+package common
+
+import (
+	"slices"
+	"strconv"
+)
+
+// ApplyFixedFields fixes the top-level scalar create fields that accept a single value (an enum with
+// one value, which includes OpenAPI 3.1 const): the create request always sends it, and no attribute
+// exposes it. Model and response fields are returned without the fixed fields.
+func ApplyFixedFields(createFields, modelFields, responseFields []FieldInfo) ([]FieldInfo, []FieldInfo) {
+	fixed := make(map[string]bool)
+	for i := range createFields {
+		f := &createFields[i]
+		if len(f.Enum) != 1 || f.Nullable || f.ReadOnly || f.IsPathParam || f.JsonTag == "-" || f.Transform != "" || f.ReferencePath != "" {
+			continue
+		}
+		switch f.Type {
+		case OpenAPITypeString:
+			f.FixedValue = "common.Ptr(" + strconv.Quote(f.Enum[0]) + ")"
+		case OpenAPITypeInteger:
+			f.FixedValue = "common.Ptr(int64(" + f.Enum[0] + "))"
+		case OpenAPITypeNumber:
+			f.FixedValue = "common.Ptr(float64(" + f.Enum[0] + "))"
+		default:
+			continue
+		}
+		fixed[f.Name] = true
+	}
+	isFixed := func(f FieldInfo) bool { return fixed[f.Name] }
+	return slices.DeleteFunc(modelFields, isFixed), slices.DeleteFunc(responseFields, isFixed)
+}
@@ -0,0 +1,38 @@
+package common
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestApplyFixedFields(t *testing.T) {
+	createFields := []FieldInfo{
+		{Name: "type", Type: OpenAPITypeString, Enum: []string{"OpenStack.Instance"}},
+		{Name: "version", Type: OpenAPITypeInteger, Enum: []string{"2"}},
+		{Name: "ratio", Type: OpenAPITypeNumber, Enum: []string{"1"}},
+		{Name: "state", Type: OpenAPITypeString, Enum: []string{"OK", "Erred"}},
+		{Name: "kind", Type: OpenAPITypeString, Enum: []string{"basic"}, Nullable: true},
+		{Name: "name", Type: OpenAPITypeString},
+	}
+	modelFields := []FieldInfo{{Name: "type"}, {Name: "version"}, {Name: "ratio"}, {Name: "state"}, {Name: "kind"}, {Name: "name"}}
+	responseFields := []FieldInfo{{Name: "type"}, {Name: "name"}, {Name: "url"}}
+
+	modelFields, responseFields = ApplyFixedFields(createFields, modelFields, responseFields)
+
+	want := map[string]string{
+		"type":    `common.Ptr("OpenStack.Instance")`,
+		"version": "common.Ptr(int64(2))",
+		"ratio":   "common.Ptr(float64(1))",
+	}
+	for _, f := range createFields {
+		if f.FixedValue != want[f.Name] {
+			t.Errorf("%s fixed value = %q, want %q", f.Name, f.FixedValue, want[f.Name])
+		}
+	}
+	if paths := fieldPaths("", modelFields); !slices.Equal(paths, []string{"state", "kind", "name"}) {
+		t.Errorf("model fields = %v, want [state kind name]", paths)
+	}
+	if paths := fieldPaths("", responseFields); !slices.Equal(paths, []string{"name", "url"}) {
+		t.Errorf("response fields = %v, want [name url]", paths)
+	}
+}
@@ -0,0 +1,108 @@
+package common
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+)
+
+// InferNestedForceNew marks the nested attributes of updatable fields that the update request cannot
+// change as requiring replacement. Nested attributes are matched with the update request by dotted
+// path, through list, set and map items. Fields updated as a whole, whose update request has no nested
+// properties, are left alone. An explicit force_new override of a dotted path wins over the inference;
+// the returned messages describe the overrides contradicting it.
+func InferNestedForceNew(fields, updateFields []FieldInfo, overrides map[string]config.FieldConfig) []string {
+	var conflicts []string
+	updates := make(map[string]FieldInfo, len(updateFields))
+	for _, u := range updateFields {
+		updates[u.Name] = u
+	}
+	for i := range fields {
+		f := &fields[i]
+		if u, ok := updates[f.Name]; ok && !f.ForceNew {
+			inferNestedForceNew(f.Name, nestedProperties(*f), nestedProperties(u), overrides, &conflicts)
+		}
+	}
+	return conflicts
+}
+
+func inferNestedForceNew(prefix string, fields, updateFields []FieldInfo, overrides map[string]config.FieldConfig, conflicts *[]string) {
+	if len(fields) == 0 || len(updateFields) == 0 {
+		return
+	}
+	updates := make(map[string]FieldInfo, len(updateFields))
+	for _, u := range updateFields {
+		updates[u.Name] = u
+	}
+	for i := range fields {
+		f := &fields[i]
+		if f.ReadOnly {
+			continue
+		}
+		path := prefix + "." + f.Name
+		u, updatable := updates[f.Name]
+		if explicit := overrides[path].ForceNew; explicit != nil {
+			if *explicit && updatable {
+				*conflicts = append(*conflicts, fmt.Sprintf("%s has force_new: true but can be updated in place", path))
+			} else if !*explicit && !updatable {
+				*conflicts = append(*conflicts, fmt.Sprintf("%s has force_new: false but is not updatable in place", path))
+			}
+			f.ForceNew = *explicit
+		} else {
+			f.ForceNew = !updatable
+		}
+		if updatable && !f.ForceNew {
+			inferNestedForceNew(path, nestedProperties(*f), nestedProperties(u), overrides, conflicts)
+		}
+	}
+}
+
+// AddUpdatePath adds the attribute at a dotted path of fields, such as the compare_key of an update
+// action, to the update fields used by InferNestedForceNew. Only that attribute becomes updatable in
+// place, its siblings keep requiring replacement. Fields already updated as a whole are left alone.
+func AddUpdatePath(updateFields, fields []FieldInfo, path string) []FieldInfo {
+	name, rest, nested := strings.Cut(path, ".")
+	j := slices.IndexFunc(fields, func(f FieldInfo) bool { return f.Name == name })
+	if j < 0 {
+		return updateFields
+	}
+	f := fields[j]
+	i := slices.IndexFunc(updateFields, func(u FieldInfo) bool { return u.Name == name })
+	if i >= 0 && len(nestedProperties(updateFields[i])) == 0 {
+		return updateFields
+	}
+	updateFields = slices.Clone(updateFields)
+	if !nested {
+		// The whole attribute is updatable
+		u := FieldInfo{Name: f.Name, GoType: f.GoType, ItemType: f.ItemType}
+		if i >= 0 {
+			updateFields[i] = u
+		} else {
+			updateFields = append(updateFields, u)
+		}
+		return updateFields
+	}
+	if i < 0 {
+		updateFields = append(updateFields, FieldInfo{Name: f.Name, GoType: f.GoType, ItemType: f.ItemType})
+		i = len(updateFields) - 1
+	}
+	u := updateFields[i]
+	properties := AddUpdatePath(nestedProperties(u), nestedProperties(f), rest)
+	if f.ItemSchema != nil {
+		u.ItemSchema = &FieldInfo{Properties: properties}
+	} else {
+		u.Properties = properties
+	}
+	updateFields[i] = u
+	return updateFields
+}
+
+// nestedProperties returns the properties of an object field, or of the items of a collection field
+func nestedProperties(f FieldInfo) []FieldInfo {
+	if f.ItemSchema != nil {
+		return f.ItemSchema.Properties
+	}
+	return f.Properties
+}
@@ -0,0 +1,122 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+)
+
+func TestInferNestedForceNew(t *testing.T) {
+	str := func(name string) FieldInfo { return FieldInfo{Name: name, GoType: TFTypeString} }
+	ports := func(props ...FieldInfo) FieldInfo {
+		return FieldInfo{Name: "ports", GoType: TFTypeList, ItemType: OpenAPITypeObject, ItemSchema: &FieldInfo{Properties: props}}
+	}
+	computed := str("mac_address")
+	computed.ReadOnly = true
+
+	fields := []FieldInfo{
+		ports(str("subnet"), str("description"), computed, FieldInfo{Name: "fixed_ips", GoType: TFTypeList, ItemType: OpenAPITypeObject,
+			ItemSchema: &FieldInfo{Properties: []FieldInfo{str("ip_address"), str("subnet_id")}}}),
+		{Name: "options", GoType: TFTypeObject, Properties: []FieldInfo{str("flavor"), str("image")}},
+		{Name: "settings", GoType: TFTypeObject, Properties: []FieldInfo{str("key")}},
+		{Name: "volumes", GoType: TFTypeObject, ForceNew: true, Properties: []FieldInfo{str("size")}},
+	}
+	updateFields := []FieldInfo{
+		ports(str("description"), FieldInfo{Name: "fixed_ips", GoType: TFTypeList, ItemType: OpenAPITypeObject,
+			ItemSchema: &FieldInfo{Properties: []FieldInfo{str("ip_address")}}}),
+		{Name: "options", GoType: TFTypeObject, Properties: []FieldInfo{str("flavor")}},
+		str("settings"), // Replaced as a whole
+	}
+	forceNew, keep := true, false
+	overrides := map[string]config.FieldConfig{
+		"options.image":     {ForceNew: &keep},
+		"options.flavor":    {ForceNew: &forceNew},
+		"ports.mac_address": {ForceNew: &forceNew},
+	}
+
+	conflicts := InferNestedForceNew(fields, updateFields, overrides)
+
+	got := map[string]bool{}
+	var collect func(prefix string, fields []FieldInfo)
+	collect = func(prefix string, fields []FieldInfo) {
+		for _, f := range fields {
+			got[prefix+f.Name] = f.ForceNew
+			collect(prefix+f.Name+".", nestedProperties(f))
+		}
+	}
+	collect("", fields)
+	want := map[string]bool{
+		"ports":                      false,
+		"ports.subnet":               true,
+		"ports.description":          false,
+		"ports.mac_address":          false, // Read-only attributes are never replaced
+		"ports.fixed_ips":            false,
+		"ports.fixed_ips.ip_address": false,
+		"ports.fixed_ips.subnet_id":  true,
+		"options":                    false,
+		"options.flavor":             true,
+		"options.image":              false,
+		"settings":                   false,
+		"settings.key":               false,
+		"volumes":                    true,
+		"volumes.size":               false,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ForceNew = %v, want %v", got, want)
+	}
+
+	wantConflicts := []string{
+		"options.flavor has force_new: true but can be updated in place",
+		"options.image has force_new: false but is not updatable in place",
+	}
+	if !reflect.DeepEqual(conflicts, wantConflicts) {
+		t.Errorf("conflicts = %q, want %q", conflicts, wantConflicts)
+	}
+}
+
+func TestAddUpdatePath(t *testing.T) {
+	str := func(name string) FieldInfo { return FieldInfo{Name: name, GoType: TFTypeString} }
+	fields := []FieldInfo{
+		{Name: "limits", GoType: TFTypeObject, Properties: []FieldInfo{str("cpu"), str("ram"), str("disk")}},
+		{Name: "attributes", GoType: TFTypeObject, Properties: []FieldInfo{
+			{Name: "tags", GoType: TFTypeList, ItemType: OpenAPITypeString},
+			{Name: "network", GoType: TFTypeObject, Properties: []FieldInfo{str("subnet"), str("ip")}},
+		}},
+		{Name: "options", GoType: TFTypeObject, Properties: []FieldInfo{str("flavor")}},
+	}
+	updateFields := []FieldInfo{str("options")} // Replaced as a whole
+
+	updateFields = AddUpdatePath(updateFields, fields, "limits.cpu")
+	updateFields = AddUpdatePath(updateFields, fields, "limits.ram")
+	updateFields = AddUpdatePath(updateFields, fields, "attributes.network.ip")
+	updateFields = AddUpdatePath(updateFields, fields, "options.flavor")
+	updateFields = AddUpdatePath(updateFields, fields, "unknown.field")
+	InferNestedForceNew(fields, updateFields, nil)
+
+	got := map[string]bool{}
+	var collect func(prefix string, fields []FieldInfo)
+	collect = func(prefix string, fields []FieldInfo) {
+		for _, f := range fields {
+			got[prefix+f.Name] = f.ForceNew
+			collect(prefix+f.Name+".", nestedProperties(f))
+		}
+	}
+	collect("", fields)
+	want := map[string]bool{
+		"limits":                    false,
+		"limits.cpu":                false,
+		"limits.ram":                false,
+		"limits.disk":               true,
+		"attributes":                false,
+		"attributes.tags":           true,
+		"attributes.network":        false,
+		"attributes.network.ip":     false,
+		"attributes.network.subnet": true,
+		"options":                   false,
+		"options.flavor":            false,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ForceNew = %v, want %v", got, want)
+	}
+}
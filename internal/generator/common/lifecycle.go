@@ -0,0 +1,33 @@
+package common
+
+// LifecycleMetaNames are the server metadata fields grouped into the lifecycle_meta attribute of every resource
+var LifecycleMetaNames = []string{"backend_id", "created", "error_message", "modified", "state"}
+
+// LifecycleField maps a lifecycle_meta attribute to the response struct field holding its value
+type LifecycleField struct {
+	Name   string // Attribute and response field name, e.g. "created"
+	GoName string // Response struct field, empty when the response declares the field with a type other than string
+	Hidden bool   // Whether the struct field is declared only for lifecycle_meta, as the field is excluded from the response fields
+}
+
+// LifecycleFields resolves the lifecycle_meta attributes against the response fields. Fields that
+// are excluded or missing from the schema are still decoded, so that every resource maps them alike.
+func LifecycleFields(responseFields []FieldInfo) []LifecycleField {
+	var result []LifecycleField
+	for _, name := range LifecycleMetaNames {
+		lf := LifecycleField{Name: name, GoName: "Lifecycle" + ToTitle(name), Hidden: true}
+		for _, f := range responseFields {
+			if f.Name != name {
+				continue
+			}
+			lf.Hidden = false
+			lf.GoName = ""
+			if f.SDKType == GoTypeString && f.IsPointer && f.JsonTag != "-" {
+				lf.GoName = ToTitle(name)
+			}
+			break
+		}
+		result = append(result, lf)
+	}
+	return result
+}
@@ -0,0 +1,25 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLifecycleFields(t *testing.T) {
+	state := FieldInfo{Name: "state", Type: OpenAPITypeString, GoType: TFTypeString}
+	CalculateSDKType(&state)
+	backendID := FieldInfo{Name: "backend_id", Type: OpenAPITypeObject, GoType: TFTypeObject}
+	CalculateSDKType(&backendID)
+
+	got := LifecycleFields([]FieldInfo{backendID, state})
+	want := []LifecycleField{
+		{Name: "backend_id"},
+		{Name: "created", GoName: "LifecycleCreated", Hidden: true},
+		{Name: "error_message", GoName: "LifecycleErrorMessage", Hidden: true},
+		{Name: "modified", GoName: "LifecycleModified", Hidden: true},
+		{Name: "state", GoName: "State"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LifecycleFields() = %+v, want %+v", got, want)
+	}
+}
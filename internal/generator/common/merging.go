@@ -1,9 +1,22 @@
 package common
 
+import (
+	"fmt"
+	"slices"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+)
+
 // MergeFields combines two lists of fields, deduplicating by name.
 // Fields from the first list take precedence for shared properties,
-// but ReadOnly status is taken from either.
-func MergeFields(primary, secondary []FieldInfo) []FieldInfo {
+// but ReadOnly and Deprecated status are taken from either.
+// Fields declared with different types are resolved by their prefer override, or reported and
+// left to the first list.
+func MergeFields(cfg SchemaConfig, primary, secondary []FieldInfo) []FieldInfo {
+	return mergeFields(cfg, "", primary, secondary)
+}
+
+func mergeFields(cfg SchemaConfig, prefix string, primary, secondary []FieldInfo) []FieldInfo {
 	fieldIdx := make(map[string]int)
 	var merged []FieldInfo
 
@@ -17,6 +30,9 @@ func MergeFields(primary, secondary []FieldInfo) []FieldInfo {
 	for _, f := range secondary {
 		if idx, ok := fieldIdx[f.Name]; ok {
 			existing := merged[idx]
+			if typesConflict(existing, f) {
+				existing = resolveTypeConflict(cfg, prefix+f.Name, existing, f)
+			}
 			// Preserve IsPathParam from primary - path params should keep their Required state
 			if existing.IsPathParam {
 				existing.ReadOnly = false // Path params are always writable
@@ -26,13 +42,16 @@ func MergeFields(primary, secondary []FieldInfo) []FieldInfo {
 			if f.ServerComputed {
 				existing.ServerComputed = true
 			}
+			if f.Deprecated {
+				existing.Deprecated = true
+			}
 
 			// Recursively merge nested properties if present in both
 			if len(existing.Properties) > 0 && len(f.Properties) > 0 {
-				existing.Properties = MergeFields(existing.Properties, f.Properties)
+				existing.Properties = mergeFields(cfg, prefix+f.Name+".", existing.Properties, f.Properties)
 			}
 			if existing.ItemSchema != nil && f.ItemSchema != nil && len(existing.ItemSchema.Properties) > 0 && len(f.ItemSchema.Properties) > 0 {
-				existing.ItemSchema.Properties = MergeFields(existing.ItemSchema.Properties, f.ItemSchema.Properties)
+				existing.ItemSchema.Properties = mergeFields(cfg, prefix+f.Name+".", existing.ItemSchema.Properties, f.ItemSchema.Properties)
 			}
 
 			// Update in slice
@@ -46,6 +65,105 @@ func MergeFields(primary, secondary []FieldInfo) []FieldInfo {
 	return merged
 }
 
+// typesConflict reports whether two definitions of a field map to different Terraform types.
+// Lists and sets only differ in uniqueness and are not considered conflicting.
+func typesConflict(a, b FieldInfo) bool {
+	collection := func(goType string) bool { return goType == TFTypeList || goType == TFTypeSet }
+	if a.GoType != b.GoType && !(collection(a.GoType) && collection(b.GoType)) {
+		return true
+	}
+	return (collection(a.GoType) || a.GoType == TFTypeMap) && a.ItemType != b.ItemType
+}
+
+// describeType names the OpenAPI type of a field in conflict warnings
+func describeType(f FieldInfo) string {
+	if f.Type == OpenAPITypeArray {
+		return "array of " + f.ItemType
+	}
+	return f.Type
+}
+
+// resolveTypeConflict picks the definition of a field whose primary (request) and secondary
+// (response) definitions have different types. The prefer override of the dotted path takes
+// precedence over that of the plain field name.
+func resolveTypeConflict(cfg SchemaConfig, path string, primary, secondary FieldInfo) FieldInfo {
+	name := secondary.Name
+	prefer := ""
+	for _, key := range []string{path, name} {
+		if override, ok := cfg.FieldOverrides[key]; ok && override.Prefer != "" {
+			prefer = override.Prefer
+			break
+		}
+	}
+	nested := path != name
+
+	switch {
+	case prefer == config.PreferExpand && expandsToObject(primary, secondary):
+		primary.URLReference = true
+		CalculateSDKType(&primary)
+		return primary
+	case prefer == config.PreferRequest && !nested:
+		primary.TypeConflict = config.PreferRequest
+		return primary
+	case prefer == config.PreferResponse && !nested:
+		secondary.TypeConflict = config.PreferResponse
+		secondary.ReadOnly = true
+		secondary.Required = false
+		return secondary
+	}
+
+	action := "set prefer to resolve"
+	switch {
+	case prefer == config.PreferExpand:
+		action = "prefer expand needs a URL and an object"
+	case prefer != "":
+		action = "prefer " + prefer + " only applies to top-level fields"
+	}
+	key := fmt.Sprintf("%s merge %s %s", path, describeType(primary), describeType(secondary))
+	if cfg.ReportedConflicts != nil {
+		if cfg.ReportedConflicts[key] {
+			return primary
+		}
+		cfg.ReportedConflicts[key] = true
+	}
+	fmt.Printf("Warning: conflicting request and response types of %s: %s and %s, keeping %s (%s)\n",
+		path, describeType(primary), describeType(secondary), describeType(primary), action)
+	return primary
+}
+
+// expandsToObject reports whether a string, or list of strings, may be returned as the object,
+// or list of objects, it identifies
+func expandsToObject(primary, secondary FieldInfo) bool {
+	if primary.GoType == TFTypeString && !primary.JSON {
+		return secondary.GoType == TFTypeObject
+	}
+	collection := func(goType string) bool { return goType == TFTypeList || goType == TFTypeSet }
+	return collection(primary.GoType) && collection(secondary.GoType) &&
+		primary.ItemType == OpenAPITypeString && secondary.ItemType == OpenAPITypeObject
+}
+
+// ResolveTypeConflicts removes the side of top-level fields that lost a type conflict resolution:
+// fields preferring the response are no longer sent, and fields preferring the request are no longer
+// read back from responses.
+func ResolveTypeConflicts(modelFields, createFields, updateFields, responseFields []FieldInfo) ([]FieldInfo, []FieldInfo, []FieldInfo) {
+	preferred := make(map[string]string)
+	for _, f := range modelFields {
+		if f.TypeConflict != "" {
+			preferred[f.Name] = f.TypeConflict
+		}
+	}
+	if len(preferred) == 0 {
+		return createFields, updateFields, responseFields
+	}
+	prefers := func(prefer string) func(FieldInfo) bool {
+		return func(f FieldInfo) bool { return preferred[f.Name] == prefer }
+	}
+	createFields = slices.DeleteFunc(createFields, prefers(config.PreferResponse))
+	updateFields = slices.DeleteFunc(updateFields, prefers(config.PreferResponse))
+	responseFields = slices.DeleteFunc(responseFields, prefers(config.PreferRequest))
+	return createFields, updateFields, responseFields
+}
+
 // MergeOrderFields combines offering (input) and resource (output) fields for Order resources.
 // Input fields take precedence and determine writability.
 // Output fields not in input are marked as ReadOnly (Computed).
@@ -109,6 +227,11 @@ func mergeOrderedFieldsRecursive(input, output []FieldInfo) []FieldInfo {
 				updated = true
 			}
 
+			if f.Deprecated && !existing.Deprecated {
+				existing.Deprecated = true
+				updated = true
+			}
+
 			// Update description if output has one and input doesn't
 			if existing.Description == "" && f.Description != "" {
 				existing.Description = f.Description
@@ -1,7 +1,10 @@
 package common
 
 import (
+	"reflect"
 	"testing"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
 )
 
 func TestMergeFields(t *testing.T) {
@@ -10,11 +13,11 @@ func TestMergeFields(t *testing.T) {
 		{Name: "field2", Type: "integer", Required: false},
 	}
 	secondary := []FieldInfo{
-		{Name: "field2", Type: "integer", Required: true, ReadOnly: true},
+		{Name: "field2", Type: "integer", Required: true, ReadOnly: true, Deprecated: true},
 		{Name: "field3", Type: "boolean"},
 	}
 
-	merged := MergeFields(primary, secondary)
+	merged := MergeFields(SchemaConfig{}, primary, secondary)
 
 	if len(merged) != 3 {
 		t.Fatalf("Expected 3 fields, got %d", len(merged))
@@ -37,6 +40,9 @@ func TestMergeFields(t *testing.T) {
 		if !f.ReadOnly {
 			t.Error("field2 should be ReadOnly from secondary")
 		}
+		if !f.Deprecated {
+			t.Error("field2 should be Deprecated from secondary")
+		}
 		if f.Required {
 			t.Error("field2 should preserve its Required status from primary (merged logic: primary takes precedence for properties)")
 		}
@@ -68,7 +74,7 @@ func TestMergeFields_Recursive(t *testing.T) {
 		},
 	}
 
-	merged := MergeFields(primary, secondary)
+	merged := MergeFields(SchemaConfig{}, primary, secondary)
 	if len(merged) != 1 {
 		t.Fatal("Expected 1 merged field")
 	}
@@ -79,6 +85,112 @@ func TestMergeFields_Recursive(t *testing.T) {
 	}
 }
 
+func TestMergeFields_TypeConflicts(t *testing.T) {
+	url := func(name string) FieldInfo {
+		return FieldInfo{Name: name, Type: OpenAPITypeString, GoType: TFTypeString, Format: "uri"}
+	}
+	object := func(name string) FieldInfo {
+		return FieldInfo{Name: name, Type: OpenAPITypeObject, GoType: TFTypeObject, Properties: []FieldInfo{url("url"), {Name: "name", GoType: TFTypeString}}}
+	}
+	primary := []FieldInfo{
+		url("project"), url("tenant"), url("flavor"), url("image"), url("customer"),
+		{Name: "security_groups", Type: OpenAPITypeArray, GoType: TFTypeList, ItemType: OpenAPITypeString},
+		{Name: "ports", Type: OpenAPITypeArray, GoType: TFTypeList, ItemType: OpenAPITypeObject, ItemSchema: &FieldInfo{Properties: []FieldInfo{url("subnet")}}},
+		{Name: "tags", Type: OpenAPITypeArray, GoType: TFTypeSet, ItemType: OpenAPITypeString},
+	}
+	secondary := []FieldInfo{
+		object("project"), object("tenant"), object("flavor"), object("image"), object("customer"),
+		{Name: "security_groups", Type: OpenAPITypeArray, GoType: TFTypeList, ItemType: OpenAPITypeObject, ItemSchema: &FieldInfo{Properties: []FieldInfo{url("url")}}},
+		{Name: "ports", Type: OpenAPITypeArray, GoType: TFTypeList, ItemType: OpenAPITypeObject, ItemSchema: &FieldInfo{Properties: []FieldInfo{object("subnet")}}},
+		{Name: "tags", Type: OpenAPITypeArray, GoType: TFTypeList, ItemType: OpenAPITypeString},
+	}
+	cfg := SchemaConfig{
+		FieldOverrides: map[string]config.FieldConfig{
+			"project":         {Prefer: config.PreferExpand},
+			"tenant":          {Prefer: config.PreferRequest},
+			"flavor":          {Prefer: config.PreferResponse},
+			"security_groups": {Prefer: config.PreferExpand},
+			"ports.subnet":    {Prefer: config.PreferExpand},
+			"customer":        {UnknownIfNull: true},
+		},
+		ReportedConflicts: map[string]bool{},
+	}
+
+	merged := MergeFields(cfg, primary, secondary)
+
+	type result struct {
+		GoType       string
+		URLReference bool
+		TypeConflict string
+		ReadOnly     bool
+	}
+	got := map[string]result{}
+	for _, f := range merged {
+		got[f.Name] = result{f.GoType, f.URLReference, f.TypeConflict, f.ReadOnly}
+	}
+	got["ports.subnet"] = result{GoType: merged[6].ItemSchema.Properties[0].GoType, URLReference: merged[6].ItemSchema.Properties[0].URLReference}
+	want := map[string]result{
+		"project":         {GoType: TFTypeString, URLReference: true},
+		"tenant":          {GoType: TFTypeString, TypeConflict: config.PreferRequest},
+		"flavor":          {GoType: TFTypeObject, TypeConflict: config.PreferResponse, ReadOnly: true},
+		"image":           {GoType: TFTypeString},
+		"customer":        {GoType: TFTypeString},
+		"security_groups": {GoType: TFTypeList, URLReference: true},
+		"ports":           {GoType: TFTypeList},
+		"ports.subnet":    {GoType: TFTypeString, URLReference: true},
+		"tags":            {GoType: TFTypeSet},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("merged = %+v, want %+v", got, want)
+	}
+	if merged[0].SDKType != "common.URLReference" || merged[5].SDKType != "[]common.URLReference" {
+		t.Errorf("SDK types = %q, %q", merged[0].SDKType, merged[5].SDKType)
+	}
+
+	// Unresolved conflicts are reported once
+	wantReported := map[string]bool{
+		"image merge string object":    true,
+		"customer merge string object": true,
+	}
+	if !reflect.DeepEqual(cfg.ReportedConflicts, wantReported) {
+		t.Errorf("reported = %v, want %v", cfg.ReportedConflicts, wantReported)
+	}
+}
+
+func TestResolveTypeConflicts(t *testing.T) {
+	modelFields := []FieldInfo{
+		{Name: "name"},
+		{Name: "tenant", TypeConflict: config.PreferRequest},
+		{Name: "flavor", TypeConflict: config.PreferResponse},
+	}
+	fields := func(names ...string) []FieldInfo {
+		var fields []FieldInfo
+		for _, name := range names {
+			fields = append(fields, FieldInfo{Name: name})
+		}
+		return fields
+	}
+	names := func(fields []FieldInfo) []string {
+		var names []string
+		for _, f := range fields {
+			names = append(names, f.Name)
+		}
+		return names
+	}
+
+	create, update, response := ResolveTypeConflicts(modelFields, fields("name", "tenant", "flavor"), fields("name", "flavor"), fields("name", "tenant", "flavor"))
+
+	if got := names(create); !reflect.DeepEqual(got, []string{"name", "tenant"}) {
+		t.Errorf("create fields = %v", got)
+	}
+	if got := names(update); !reflect.DeepEqual(got, []string{"name"}) {
+		t.Errorf("update fields = %v", got)
+	}
+	if got := names(response); !reflect.DeepEqual(got, []string{"name", "flavor"}) {
+		t.Errorf("response fields = %v", got)
+	}
+}
+
 func TestMergeOrderFields(t *testing.T) {
 	input := []FieldInfo{
 		{Name: "plan", Type: "string"},
@@ -0,0 +1,57 @@
+package common
+
+import "github.com/waldur/terraform-provider-waldur-generator/internal/config"
+
+// NormalizedStringTypes maps normalizations to the generated model types comparing values under them.
+// Each model type comes with a <type>Type attribute type and a <type>Normalization type parameter.
+var NormalizedStringTypes = map[string]string{
+	config.NormalizeURL:                   "common.URL",
+	config.NormalizeCaseInsensitive:       "common.CaseInsensitive",
+	config.NormalizeWhitespaceInsensitive: "common.WhitespaceInsensitive",
+	NormalizeReference:                    "common.Reference",
+}
+
+// NormalizeStrategy returns the normalization of the string at path. Rules for the dotted path take
+// precedence over rules for the plain field name; without one, uri fields are compared as URLs
+// and date-time fields as timestamps.
+func NormalizeStrategy(cfg SchemaConfig, path, name, format string) string {
+	for _, key := range []string{path, name} {
+		if override, ok := cfg.FieldOverrides[key]; ok && override.Normalize != "" {
+			return override.Normalize
+		}
+	}
+	switch format {
+	case "uri":
+		return config.NormalizeURL
+	case "date-time":
+		return config.NormalizeRFC3339
+	}
+	return config.NormalizeNone
+}
+
+// ApplyNormalization sets how top-level string attributes are compared with the values returned by
+// the server, so that values the server rewrites don't show up as changes. Nested attributes are
+// converted through object attribute types and keep plain strings, like nested dynamic objects.
+func ApplyNormalization(cfg SchemaConfig, fields []FieldInfo) {
+	for i := range fields {
+		f := &fields[i]
+		if f.GoType != TFTypeString || f.JSON || f.URLReference || f.JsonTag == "-" {
+			continue
+		}
+		f.Normalize = ""
+		switch strategy := NormalizeStrategy(cfg, f.Name, f.Name, f.Format); strategy {
+		case config.NormalizeRFC3339:
+			f.Format = "date-time" // Exposed as an RFC 3339 timestamp
+		case config.NormalizeNone:
+			if f.Format == "date-time" {
+				f.Format = ""
+			}
+		default:
+			if f.Format == "date-time" {
+				f.Format = ""
+			}
+			f.Normalize = strategy
+		}
+		CalculateSDKType(f)
+	}
+}
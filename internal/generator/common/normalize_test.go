@@ -0,0 +1,90 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+)
+
+func TestNormalizeStrategy(t *testing.T) {
+	cfg := SchemaConfig{
+		FieldOverrides: map[string]config.FieldConfig{
+			"name":          {Normalize: config.NormalizeCaseInsensitive},
+			"plan.name":     {Normalize: config.NormalizeNone},
+			"backend_url":   {Normalize: config.NormalizeNone},
+			"options":       {Computed: true},
+			"last_sync":     {Normalize: config.NormalizeRFC3339},
+			"customer.name": {UnknownIfNull: true},
+		},
+	}
+
+	tests := []struct {
+		path   string
+		name   string
+		format string
+		want   string
+	}{
+		{"name", "name", "", config.NormalizeCaseInsensitive},
+		{"plan.name", "name", "", config.NormalizeNone},
+		{"customer.name", "name", "", config.NormalizeCaseInsensitive},
+		{"project", "project", "uri", config.NormalizeURL},
+		{"backend_url", "backend_url", "uri", config.NormalizeNone},
+		{"created", "created", "date-time", config.NormalizeRFC3339},
+		{"last_sync", "last_sync", "", config.NormalizeRFC3339},
+		{"options", "options", "", config.NormalizeNone},
+	}
+	for _, tt := range tests {
+		if got := NormalizeStrategy(cfg, tt.path, tt.name, tt.format); got != tt.want {
+			t.Errorf("NormalizeStrategy(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestApplyNormalization(t *testing.T) {
+	newField := func(name, format string) FieldInfo {
+		f := FieldInfo{Name: name, Type: OpenAPITypeString, GoType: TFTypeString, Format: format}
+		CalculateSDKType(&f)
+		return f
+	}
+	cfg := SchemaConfig{FieldOverrides: map[string]config.FieldConfig{
+		"name":      {Normalize: config.NormalizeCaseInsensitive},
+		"hostname":  {Normalize: config.NormalizeWhitespaceInsensitive},
+		"created":   {Normalize: config.NormalizeNone},
+		"last_sync": {Normalize: config.NormalizeRFC3339},
+	}}
+
+	tests := []struct {
+		field         FieldInfo
+		wantModelType string
+		wantDateTime  bool
+	}{
+		{newField("project", "uri"), "common.URL", false},
+		{newField("name", ""), "common.CaseInsensitive", false},
+		{newField("hostname", ""), "common.WhitespaceInsensitive", false},
+		{newField("description", ""), "", false},
+		{newField("modified", "date-time"), "", true},
+		{newField("created", "date-time"), "", false},
+		{newField("last_sync", ""), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field.Name, func(t *testing.T) {
+			fields := []FieldInfo{tt.field}
+			ApplyNormalization(cfg, fields)
+			f := fields[0]
+			if f.TypeMeta.ModelType != tt.wantModelType || f.TypeMeta.IsDateTime != tt.wantDateTime {
+				t.Errorf("model type = %q, date-time = %v, want %q, %v", f.TypeMeta.ModelType, f.TypeMeta.IsDateTime, tt.wantModelType, tt.wantDateTime)
+			}
+			if tt.wantModelType != "" && f.TypeMeta.CustomType != tt.wantModelType+"Type{}" {
+				t.Errorf("custom type = %q", f.TypeMeta.CustomType)
+			}
+		})
+	}
+
+	// Nested attributes keep plain strings
+	object := FieldInfo{Name: "plan", Type: OpenAPITypeObject, GoType: TFTypeObject, Properties: []FieldInfo{newField("url", "uri")}}
+	fields := []FieldInfo{object, newField("url", "uri")}
+	ApplyNormalization(cfg, fields)
+	if fields[0].Properties[0].Normalize != "" || fields[1].Normalize != config.NormalizeURL {
+		t.Errorf("nested normalize = %q, top-level normalize = %q", fields[0].Properties[0].Normalize, fields[1].Normalize)
+	}
+}
@@ -0,0 +1,71 @@
+package common
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+)
+
+// PollingOptions holds resolved polling settings rendered into the generated Wait* calls
+type PollingOptions struct {
+	Interval      string   // Go expression for the poll interval (e.g., "10 * time.Second"), empty for default
+	MaxAttempts   int      // Maximum number of refreshes (0 means unlimited)
+	Constant      bool     // True for a fixed poll interval instead of exponential backoff
+	PendingStates []string // Overrides the default pending states
+	SuccessStates []string // Overrides the default target states
+	FailureStates []string // Overrides the default failure states
+}
+
+// NewPollingOptions converts a resource polling config into template-ready options
+func NewPollingOptions(cfg *config.PollingConfig) (*PollingOptions, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	opts := &PollingOptions{
+		MaxAttempts:   cfg.MaxAttempts,
+		Constant:      cfg.Backoff == config.BackoffConstant,
+		PendingStates: cfg.PendingStates,
+		SuccessStates: cfg.SuccessStates,
+		FailureStates: cfg.FailureStates,
+	}
+	if cfg.Interval != "" {
+		d, err := time.ParseDuration(cfg.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid polling interval %q: %w", cfg.Interval, err)
+		}
+		opts.Interval = DurationLiteral(d)
+	}
+	return opts, nil
+}
+
+// TimeoutExpr returns a Go expression for a configured timeout, falling back to the given default
+func TimeoutExpr(value, fallback string) (string, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid timeout %q: %w", value, err)
+	}
+	return DurationLiteral(d), nil
+}
+
+// DurationLiteral renders a duration as a Go expression using the largest exact time unit
+func DurationLiteral(d time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", int64(d))
+}
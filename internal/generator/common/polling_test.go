@@ -0,0 +1,72 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+)
+
+func TestDurationLiteral(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{2 * time.Hour, "2 * time.Hour"},
+		{90 * time.Second, "90 * time.Second"},
+		{5 * time.Minute, "5 * time.Minute"},
+		{1500 * time.Millisecond, "1500 * time.Millisecond"},
+		{time.Duration(1500), "time.Duration(1500)"},
+	}
+
+	for _, tt := range tests {
+		if got := DurationLiteral(tt.in); got != tt.want {
+			t.Errorf("DurationLiteral(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNewPollingOptions(t *testing.T) {
+	if opts, err := NewPollingOptions(nil); err != nil || opts != nil {
+		t.Fatalf("Expected nil options for nil config, got %v, %v", opts, err)
+	}
+
+	opts, err := NewPollingOptions(&config.PollingConfig{
+		Interval:      "30s",
+		MaxAttempts:   5,
+		Backoff:       config.BackoffConstant,
+		SuccessStates: []string{"ACTIVE"},
+		FailureStates: []string{"ERROR"},
+	})
+	if err != nil {
+		t.Fatalf("NewPollingOptions failed: %v", err)
+	}
+
+	want := &PollingOptions{
+		Interval:      "30 * time.Second",
+		MaxAttempts:   5,
+		Constant:      true,
+		SuccessStates: []string{"ACTIVE"},
+		FailureStates: []string{"ERROR"},
+	}
+	if !reflect.DeepEqual(opts, want) {
+		t.Errorf("NewPollingOptions() = %+v, want %+v", opts, want)
+	}
+
+	if _, err := NewPollingOptions(&config.PollingConfig{Interval: "later"}); err == nil {
+		t.Error("Expected error for invalid interval")
+	}
+}
+
+func TestTimeoutExpr(t *testing.T) {
+	if got, _ := TimeoutExpr("", "common.DefaultCreateTimeout"); got != "common.DefaultCreateTimeout" {
+		t.Errorf("Expected fallback, got %q", got)
+	}
+	if got, _ := TimeoutExpr("45m", "common.DefaultCreateTimeout"); got != "45 * time.Minute" {
+		t.Errorf("Expected '45 * time.Minute', got %q", got)
+	}
+	if _, err := TimeoutExpr("forever", "common.DefaultCreateTimeout"); err == nil {
+		t.Error("Expected error for invalid timeout")
+	}
+}
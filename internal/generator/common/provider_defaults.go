@@ -0,0 +1,43 @@
+package common
+
+// ProviderDefaultPaths maps the top-level create fields that the provider can fill in with its
+// default_<name> attribute (e.g., "default_project") to the retrieve paths of the objects they refer to
+var ProviderDefaultPaths = map[string]string{
+	"project":  "/api/projects/{uuid}/",
+	"customer": "/api/customers/{uuid}/",
+}
+
+// ProviderDefault is a required create field that is filled in with a default of the provider when omitted
+type ProviderDefault struct {
+	Field        FieldInfo // Model field
+	RetrievePath string    // Retrieve path of the objects, resolving defaults given as UUIDs to URLs
+}
+
+// ApplyProviderDefaults makes the required string create fields that the provider has defaults for
+// optional and computed: the plan of a new resource takes the provider default when the configuration
+// omits the field. The model fields are updated and returned as provider defaults.
+func ApplyProviderDefaults(createFields, modelFields []FieldInfo) []ProviderDefault {
+	var defaults []ProviderDefault
+	for _, cf := range createFields {
+		path, ok := ProviderDefaultPaths[cf.Name]
+		if !ok || !cf.Required || cf.FixedValue != "" {
+			continue
+		}
+		for i := range modelFields {
+			f := &modelFields[i]
+			if f.Name != cf.Name || !f.Required || f.ReadOnly || f.WriteOnly || f.GoType != TFTypeString {
+				continue
+			}
+			// Plain and normalized strings only, whose values can be built from the default
+			if f.TypeMeta.FromAPIFunc != "common.StringPointerValue" && f.TypeMeta.ModelType == "" {
+				continue
+			}
+			f.Required = false
+			f.ServerComputed = true
+			f.UseStateForUnknown = true
+			f.Description = AppendSentence(f.Description, "Defaults to the `default_"+f.Name+"` provider attribute.")
+			defaults = append(defaults, ProviderDefault{Field: *f, RetrievePath: path})
+		}
+	}
+	return defaults
+}
@@ -0,0 +1,37 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestApplyProviderDefaults(t *testing.T) {
+	createFields := []FieldInfo{
+		{Name: "project", Required: true},
+		{Name: "customer"},
+		{Name: "name", Required: true},
+	}
+	modelFields := []FieldInfo{
+		{Name: "customer", Type: OpenAPITypeString, GoType: TFTypeString},
+		{Name: "name", Type: OpenAPITypeString, GoType: TFTypeString, Required: true},
+		{Name: "project", Type: OpenAPITypeString, GoType: TFTypeString, Required: true, Description: "Project URL"},
+	}
+	for i := range modelFields {
+		CalculateSDKType(&modelFields[i])
+	}
+
+	defaults := ApplyProviderDefaults(createFields, modelFields)
+
+	if len(defaults) != 1 || defaults[0].Field.Name != "project" || defaults[0].RetrievePath != "/api/projects/{uuid}/" {
+		t.Fatalf("defaults = %+v, want project only", defaults)
+	}
+	project := modelFields[2]
+	if project.Required || !project.ServerComputed || !project.UseStateForUnknown {
+		t.Errorf("project should be optional and computed, got required=%v computed=%v", project.Required, project.ServerComputed)
+	}
+	if want := "Project URL. Defaults to the `default_project` provider attribute."; project.Description != want {
+		t.Errorf("project description = %q, want %q", project.Description, want)
+	}
+	if !modelFields[1].Required {
+		t.Errorf("name should stay required")
+	}
+}
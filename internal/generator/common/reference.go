@@ -0,0 +1,70 @@
+package common
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+)
+
+// NormalizeReference compares the URL of an API object with its UUID. It is set on URL fields
+// referring to objects, rather than configured with normalize.
+const NormalizeReference = "reference"
+
+// pathParamPattern matches the parameters of an operation path
+var pathParamPattern = regexp.MustCompile(`\{[^}]+\}`)
+
+// ReferencePath returns the path of an operation retrieving an object by a single parameter,
+// with the parameter named {uuid}. It returns false for paths with other parameters.
+func ReferencePath(path string) (string, bool) {
+	if len(pathParamPattern.FindAllString(path, -1)) != 1 || !strings.HasSuffix(path, "}/") {
+		return "", false
+	}
+	return pathParamPattern.ReplaceAllString(path, "{uuid}"), true
+}
+
+// FindReferencePath detects the retrieve path of the objects a URL field refers to from its name.
+// The field "flavor" of an "openstack" resource refers to "/api/flavors/{uuid}/", or else to
+// "/api/openstack-flavors/{uuid}/", or else to the only "/api/<prefix>-flavors/{uuid}/".
+// It returns an empty string when no path or several paths match.
+func FindReferencePath(retrievePaths []string, service, name string) string {
+	collection := config.Pluralize(strings.ReplaceAll(name, "_", "-"))
+	candidates := []string{"/api/" + collection + "/{uuid}/", "/api/" + service + "-" + collection + "/{uuid}/"}
+	for _, candidate := range candidates {
+		for _, path := range retrievePaths {
+			if path == candidate {
+				return path
+			}
+		}
+	}
+	var found string
+	for _, path := range retrievePaths {
+		if strings.HasSuffix(path, "-"+collection+"/{uuid}/") {
+			if found != "" {
+				return ""
+			}
+			found = path
+		}
+	}
+	return found
+}
+
+// ApplyReferences lets top-level URL fields referring to API objects accept their UUIDs as well.
+// References maps field names to the retrieve paths of the objects they refer to. UUIDs are
+// resolved to URLs when sent, and URLs returned by the server are equal to the UUIDs they end with.
+func ApplyReferences(references map[string]string, fieldSets ...[]FieldInfo) {
+	for _, fields := range fieldSets {
+		for i := range fields {
+			f := &fields[i]
+			path, ok := references[f.Name]
+			if !ok || f.GoType != TFTypeString || f.JSON || f.URLReference || f.Transform != "" || f.JsonTag == "-" {
+				continue
+			}
+			f.ReferencePath = path
+			f.Normalize = NormalizeReference
+			f.Format = "" // UUIDs don't pass the uri format validator
+			f.Description = AppendSentence(f.Description, "Accepts a URL or a UUID.")
+			CalculateSDKType(f)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package common
+
+import "testing"
+
+func TestFindReferencePath(t *testing.T) {
+	paths := []string{
+		"/api/aws-images/{uuid}/",
+		"/api/marketplace-plans/{uuid}/",
+		"/api/marketplace-provider-offerings/{uuid}/",
+		"/api/marketplace-public-offerings/{uuid}/",
+		"/api/openstack-images/{uuid}/",
+		"/api/openstack-network-rbac-policies/{uuid}/",
+		"/api/projects/{uuid}/",
+	}
+	tests := []struct {
+		service, name, want string
+	}{
+		{"structure", "project", "/api/projects/{uuid}/"},
+		{"openstack", "image", "/api/openstack-images/{uuid}/"},
+		{"marketplace", "plan", "/api/marketplace-plans/{uuid}/"},
+		{"openstack", "network_rbac_policy", "/api/openstack-network-rbac-policies/{uuid}/"},
+		{"slurm", "plan", "/api/marketplace-plans/{uuid}/"}, // The only collection with the name
+		{"marketplace", "offering", ""},                     // Ambiguous
+		{"azure", "image", ""},                              // Ambiguous without an azure collection
+		{"structure", "customer", ""},
+	}
+	for _, tt := range tests {
+		if got := FindReferencePath(paths, tt.service, tt.name); got != tt.want {
+			t.Errorf("FindReferencePath(%s, %s) = %q, want %q", tt.service, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReferencePath(t *testing.T) {
+	if got, ok := ReferencePath("/api/openstack-flavors/{flavor_uuid}/"); !ok || got != "/api/openstack-flavors/{uuid}/" {
+		t.Errorf("ReferencePath = %q, %v", got, ok)
+	}
+	if _, ok := ReferencePath("/api/openstack-tenants/{uuid}/networks/{name}/"); ok {
+		t.Error("expected paths with several parameters to be rejected")
+	}
+	if _, ok := ReferencePath("/api/openstack-flavors/"); ok {
+		t.Error("expected paths without parameters to be rejected")
+	}
+}
+
+func TestApplyReferences(t *testing.T) {
+	newField := func(name string) FieldInfo {
+		f := FieldInfo{Name: name, Type: OpenAPITypeString, GoType: TFTypeString, Format: "uri", Normalize: "url", Description: "Project"}
+		CalculateSDKType(&f)
+		return f
+	}
+	fields := []FieldInfo{newField("project"), newField("homepage")}
+	ApplyReferences(map[string]string{"project": "/api/projects/{uuid}/"}, fields)
+
+	project := fields[0]
+	if project.ReferencePath != "/api/projects/{uuid}/" || project.Normalize != NormalizeReference || project.Format != "" {
+		t.Errorf("project = path %q, normalize %q, format %q", project.ReferencePath, project.Normalize, project.Format)
+	}
+	if project.TypeMeta.ModelType != "common.Reference" || project.TypeMeta.FormatPattern != "" {
+		t.Errorf("project model type = %q, format pattern = %q", project.TypeMeta.ModelType, project.TypeMeta.FormatPattern)
+	}
+	if project.Description != "Project. Accepts a URL or a UUID." {
+		t.Errorf("project description = %q", project.Description)
+	}
+	if homepage := fields[1]; homepage.ReferencePath != "" || homepage.Format != "uri" {
+		t.Errorf("homepage was changed: path %q, format %q", homepage.ReferencePath, homepage.Format)
+	}
+}
@@ -0,0 +1,200 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// replaceIfKinds maps the scalar types supporting replace_if conditions to their plan modifier kinds
+var replaceIfKinds = map[string]string{
+	TFTypeString:  "String",
+	TFTypeInt64:   "Int64",
+	TFTypeFloat64: "Float64",
+	TFTypeBool:    "Bool",
+}
+
+// CheckReplaceIf checks that the replace_if conditions of the fields, at any depth, are valid for their types
+// and that the fields are not always replaced
+func CheckReplaceIf(fields []FieldInfo) error {
+	return checkReplaceIf(fields, "")
+}
+
+func checkReplaceIf(fields []FieldInfo, prefix string) error {
+	for _, f := range fields {
+		path := prefix + f.Name
+		if f.ReplaceIf != "" {
+			if f.ForceNew {
+				return fmt.Errorf("field %s: replace_if requires an attribute updated in place; set force_new: false", path)
+			}
+			if _, err := ReplaceIfCondition(f); err != nil {
+				return fmt.Errorf("field %s: replace_if: %w", path, err)
+			}
+		}
+		if err := checkReplaceIf(nestedProperties(f), path+"."); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplaceIfModifier returns the RequiresReplaceIf plan modifier of a field with a replace_if condition,
+// or "" when the field has none. The condition is only evaluated when the attribute changes from a
+// known value to another known value.
+func ReplaceIfModifier(f FieldInfo) (string, error) {
+	if f.ReplaceIf == "" {
+		return "", nil
+	}
+	condition, err := ReplaceIfCondition(f)
+	if err != nil {
+		return "", fmt.Errorf("field %s: replace_if: %w", f.Name, err)
+	}
+	kind := replaceIfKinds[f.GoType]
+	pkg := strings.ToLower(kind) + "planmodifier"
+	description := "Replaces the resource when " + f.ReplaceIf
+	return fmt.Sprintf(`%s.RequiresReplaceIf(func(ctx context.Context, req planmodifier.%sRequest, resp *%s.RequiresReplaceIfFuncResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+	resp.RequiresReplace = %s
+}, %s, %s)`, pkg, kind, pkg, condition, strconv.Quote(description), strconv.Quote(description)), nil
+}
+
+// ReplaceIfCondition compiles the replace_if condition of a field to a Go expression. A condition compares
+// the prior value (old) and the planned value (new) with each other or with literals, e.g. "new < old",
+// and comparisons can be combined with && and ||.
+func ReplaceIfCondition(f FieldInfo) (string, error) {
+	kind, ok := replaceIfKinds[f.GoType]
+	if !ok {
+		return "", fmt.Errorf("only string, number and boolean attributes are supported")
+	}
+	tokens, err := tokenizeReplaceIf(f.ReplaceIf)
+	if err != nil {
+		return "", err
+	}
+
+	var out []string
+	for len(tokens) > 0 {
+		if len(tokens) < 3 {
+			return "", fmt.Errorf("incomplete comparison in %q", f.ReplaceIf)
+		}
+		left, op, right := tokens[0], tokens[1], tokens[2]
+		if !isComparison(op) {
+			return "", fmt.Errorf("expected a comparison operator, got %q", op)
+		}
+		if kind == "Bool" && op != "==" && op != "!=" {
+			return "", fmt.Errorf("booleans can only be compared with == and !=")
+		}
+		if !isValueOperand(left) && !isValueOperand(right) {
+			return "", fmt.Errorf("comparison %s %s %s uses neither old nor new", left, op, right)
+		}
+		l, err := replaceIfOperand(left, kind)
+		if err != nil {
+			return "", err
+		}
+		r, err := replaceIfOperand(right, kind)
+		if err != nil {
+			return "", err
+		}
+		out = append(out, l, op, r)
+		tokens = tokens[3:]
+
+		if len(tokens) > 0 {
+			if tokens[0] != "&&" && tokens[0] != "||" {
+				return "", fmt.Errorf("expected && or ||, got %q", tokens[0])
+			}
+			if len(tokens) == 1 {
+				return "", fmt.Errorf("incomplete comparison in %q", f.ReplaceIf)
+			}
+			out = append(out, tokens[0])
+			tokens = tokens[1:]
+		}
+	}
+	if len(out) == 0 {
+		return "", fmt.Errorf("empty condition")
+	}
+	return strings.Join(out, " "), nil
+}
+
+func isComparison(op string) bool {
+	switch op {
+	case "<", "<=", ">", ">=", "==", "!=":
+		return true
+	}
+	return false
+}
+
+func isValueOperand(token string) bool {
+	return token == "old" || token == "new"
+}
+
+// replaceIfOperand compiles an operand: old and new read the state and plan values, and literals must match kind
+func replaceIfOperand(token, kind string) (string, error) {
+	switch token {
+	case "old":
+		return "req.StateValue.Value" + kind + "()", nil
+	case "new":
+		return "req.PlanValue.Value" + kind + "()", nil
+	}
+	switch kind {
+	case "String":
+		if strings.HasPrefix(token, `"`) {
+			return token, nil
+		}
+	case "Int64":
+		if _, err := strconv.ParseInt(token, 10, 64); err == nil {
+			return token, nil
+		}
+	case "Float64":
+		if _, err := strconv.ParseFloat(token, 64); err == nil {
+			return token, nil
+		}
+	case "Bool":
+		if token == "true" || token == "false" {
+			return token, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not a valid %s operand", token, strings.ToLower(kind))
+}
+
+// tokenizeReplaceIf splits a condition into identifiers, literals and operators. String literals are
+// returned as quoted Go strings.
+func tokenizeReplaceIf(expr string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(expr); {
+		c := rune(expr[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '"':
+			end := strings.IndexByte(expr[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string in %q", expr)
+			}
+			tokens = append(tokens, strconv.Quote(expr[i+1:i+1+end]))
+			i += end + 2
+		case strings.ContainsRune("<>=!&|", c):
+			j := i + 1
+			for j < len(expr) && strings.ContainsRune("<>=!&|", rune(expr[j])) {
+				j++
+			}
+			op := expr[i:j]
+			if !isComparison(op) && op != "&&" && op != "||" {
+				return nil, fmt.Errorf("unknown operator %q", op)
+			}
+			tokens = append(tokens, op)
+			i = j
+		case unicode.IsLetter(c) || unicode.IsDigit(c) || c == '-' || c == '.' || c == '_':
+			j := i + 1
+			for j < len(expr) && (unicode.IsLetter(rune(expr[j])) || unicode.IsDigit(rune(expr[j])) || strings.ContainsRune("-._", rune(expr[j]))) {
+				j++
+			}
+			tokens = append(tokens, expr[i:j])
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q in %q", c, expr)
+		}
+	}
+	return tokens, nil
+}
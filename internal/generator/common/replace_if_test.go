@@ -0,0 +1,74 @@
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReplaceIfCondition(t *testing.T) {
+	tests := []struct {
+		field FieldInfo
+		want  string
+	}{
+		{FieldInfo{GoType: TFTypeInt64, ReplaceIf: "new < old"}, "req.PlanValue.ValueInt64() < req.StateValue.ValueInt64()"},
+		{FieldInfo{GoType: TFTypeInt64, ReplaceIf: "new<old || new > 1024"}, "req.PlanValue.ValueInt64() < req.StateValue.ValueInt64() || req.PlanValue.ValueInt64() > 1024"},
+		{FieldInfo{GoType: TFTypeFloat64, ReplaceIf: "old >= 0.5"}, "req.StateValue.ValueFloat64() >= 0.5"},
+		{FieldInfo{GoType: TFTypeString, ReplaceIf: `old == "ssd" && new != "ssd"`}, `req.StateValue.ValueString() == "ssd" && req.PlanValue.ValueString() != "ssd"`},
+		{FieldInfo{GoType: TFTypeBool, ReplaceIf: "new == false"}, "req.PlanValue.ValueBool() == false"},
+	}
+	for _, tt := range tests {
+		got, err := ReplaceIfCondition(tt.field)
+		if err != nil {
+			t.Errorf("ReplaceIfCondition(%q) error = %v", tt.field.ReplaceIf, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ReplaceIfCondition(%q) = %s, want %s", tt.field.ReplaceIf, got, tt.want)
+		}
+	}
+
+	invalid := []FieldInfo{
+		{GoType: TFTypeInt64, ReplaceIf: "new <"},
+		{GoType: TFTypeInt64, ReplaceIf: "new < 1.5"},
+		{GoType: TFTypeInt64, ReplaceIf: "1 < 2"},
+		{GoType: TFTypeInt64, ReplaceIf: "size < old"},
+		{GoType: TFTypeInt64, ReplaceIf: "new < old &&"},
+		{GoType: TFTypeInt64, ReplaceIf: "new =< old"},
+		{GoType: TFTypeString, ReplaceIf: `new == "ssd`},
+		{GoType: TFTypeBool, ReplaceIf: "new > old"},
+		{GoType: TFTypeList, ReplaceIf: "new != old"},
+	}
+	for _, f := range invalid {
+		if _, err := ReplaceIfCondition(f); err == nil {
+			t.Errorf("ReplaceIfCondition(%q) accepted an invalid condition", f.ReplaceIf)
+		}
+	}
+}
+
+func TestReplaceIfModifier(t *testing.T) {
+	got, err := ReplaceIfModifier(FieldInfo{Name: "size", GoType: TFTypeInt64, ReplaceIf: "new < old"})
+	if err != nil {
+		t.Fatalf("ReplaceIfModifier() error = %v", err)
+	}
+	for _, want := range []string{
+		"int64planmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {",
+		"resp.RequiresReplace = req.PlanValue.ValueInt64() < req.StateValue.ValueInt64()",
+		`"Replaces the resource when new < old"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ReplaceIfModifier() = %s, want it to contain %s", got, want)
+		}
+	}
+	if got, _ := ReplaceIfModifier(FieldInfo{Name: "size", GoType: TFTypeInt64}); got != "" {
+		t.Errorf("ReplaceIfModifier() without condition = %s", got)
+	}
+
+	volume := FieldInfo{Name: "disk", GoType: TFTypeObject, Properties: []FieldInfo{{Name: "size", GoType: TFTypeInt64, ReplaceIf: "new < old"}}}
+	if err := CheckReplaceIf([]FieldInfo{volume}); err != nil {
+		t.Errorf("CheckReplaceIf() = %v", err)
+	}
+	volume.Properties[0].ForceNew = true
+	if err := CheckReplaceIf([]FieldInfo{volume}); err == nil {
+		t.Error("CheckReplaceIf() accepted a condition on an attribute that is always replaced")
+	}
+}
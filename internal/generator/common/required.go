@@ -0,0 +1,58 @@
+package common
+
+import (
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Reasons a property required by a create request cannot be set
+const (
+	RequiredExcluded = "excluded" // Left out of the create request by excluded_fields or a prefer override
+	RequiredSkipped  = "skipped"  // Hidden from the Terraform schema
+	RequiredReadOnly = "read-only"
+)
+
+// RequiredFieldIssue is a property required by a create request that no resource attribute can set
+type RequiredFieldIssue struct {
+	Name   string
+	Reason string // RequiredExcluded, RequiredSkipped or RequiredReadOnly
+}
+
+// CheckRequiredFields returns, sorted by name, the top-level properties the create request schema
+// requires that the final create and model fields cannot set. Properties the server fills in
+// (read-only properties and properties with a default) and the uuid are never reported.
+func CheckRequiredFields(cfg SchemaConfig, schemaRef *openapi3.SchemaRef, createFields, modelFields []FieldInfo) []RequiredFieldIssue {
+	if schemaRef == nil || schemaRef.Value == nil {
+		return nil
+	}
+	properties, required := allOfProperties(cfg, "", schemaRef.Value)
+
+	inCreate := make(map[string]bool, len(createFields))
+	for _, f := range createFields {
+		inCreate[f.Name] = true
+	}
+	models := make(map[string]FieldInfo, len(modelFields))
+	for _, f := range modelFields {
+		models[f.Name] = f
+	}
+
+	var issues []RequiredFieldIssue
+	for name := range required {
+		prop := properties[name]
+		if name == "uuid" || prop == nil || prop.Value == nil || prop.Value.ReadOnly || prop.Value.Default != nil {
+			continue
+		}
+		model, inModel := models[name]
+		switch {
+		case inModel && model.ReadOnly:
+			issues = append(issues, RequiredFieldIssue{Name: name, Reason: RequiredReadOnly})
+		case !inCreate[name]:
+			issues = append(issues, RequiredFieldIssue{Name: name, Reason: RequiredExcluded})
+		case inModel && model.SchemaSkip:
+			issues = append(issues, RequiredFieldIssue{Name: name, Reason: RequiredSkipped})
+		}
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Name < issues[j].Name })
+	return issues
+}
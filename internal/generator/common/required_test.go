@@ -0,0 +1,38 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestCheckRequiredFields(t *testing.T) {
+	readOnly := openapi3.NewStringSchema()
+	readOnly.ReadOnly = true
+	schema := openapi3.NewObjectSchema().
+		WithProperty("name", openapi3.NewStringSchema()).
+		WithProperty("customer", openapi3.NewStringSchema()).
+		WithProperty("tenant", openapi3.NewStringSchema()).
+		WithProperty("size", openapi3.NewIntegerSchema()).
+		WithProperty("kind", openapi3.NewStringSchema().WithDefault("basic")).
+		WithProperty("url", readOnly).
+		WithProperty("uuid", openapi3.NewUUIDSchema())
+	schema.Required = []string{"name", "customer", "tenant", "size", "kind", "url", "uuid"}
+
+	createFields := []FieldInfo{{Name: "name"}, {Name: "size"}}
+	modelFields := []FieldInfo{
+		{Name: "name"},
+		{Name: "size", SchemaSkip: true},
+		{Name: "tenant", ReadOnly: true},
+	}
+	got := CheckRequiredFields(SchemaConfig{}, openapi3.NewSchemaRef("", schema), createFields, modelFields)
+	want := []RequiredFieldIssue{
+		{Name: "customer", Reason: RequiredExcluded},
+		{Name: "size", Reason: RequiredSkipped},
+		{Name: "tenant", Reason: RequiredReadOnly},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckRequiredFields() = %v, want %v", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
@@ -9,17 +10,74 @@ import (
 
 // SchemaConfig defines field-level rules for schema extraction
 type SchemaConfig struct {
-	ExcludedFields map[string]bool
-	SetFields      map[string]bool // Legacy global set fields
-	FieldOverrides map[string]config.FieldConfig
+	ExcludedFields    map[string]bool
+	SetFields         map[string]bool // Legacy global set fields
+	FieldOverrides    map[string]config.FieldConfig
+	UnionStrategy     string            // Default strategy for oneOf/anyOf object unions
+	CircularRefs      string            // How references back to an enclosing schema are exposed
+	ReportedCycles    map[string]bool   // Circular references already warned about (optional)
+	ReportedConflicts map[string]bool   // Conflicting allOf property definitions already warned about (optional)
+	ReportedPatterns  map[string]bool   // Invalid regular expressions already warned about (optional)
+	IgnoreDefaults    bool              // Whether OpenAPI defaults are left to the server instead of becoming schema defaults
+	DynamicObjects    string            // How top-level objects with arbitrary properties are exposed
+	TypeCoercions     map[string]string // Scalar types the API uses instead of the declared ones, by field name or dotted path
 }
 
-// IsSetField checks if a field should be treated as a Set
-func IsSetField(cfg SchemaConfig, name string) bool {
-	if override, ok := cfg.FieldOverrides[name]; ok {
-		return override.Set
+// ForResource returns a copy of the config with the set_fields and excluded_fields of a resource applied.
+// Resources and the data sources sharing their SDK use it so both extract the same field types.
+func (c SchemaConfig) ForResource(resource *config.Resource) SchemaConfig {
+	overrides := make(map[string]config.FieldConfig, len(c.FieldOverrides)+len(resource.SetFields))
+	for k, v := range c.FieldOverrides {
+		overrides[k] = v
 	}
-	return cfg.SetFields[name]
+	for k, v := range resource.SetFields {
+		overrides[k] = v
+	}
+	excluded := make(map[string]bool, len(c.ExcludedFields)+len(resource.ExcludedFields))
+	for k, v := range c.ExcludedFields {
+		excluded[k] = v
+	}
+	for _, f := range resource.ExcludedFields {
+		excluded[f] = true
+	}
+	c.FieldOverrides = overrides
+	c.ExcludedFields = excluded
+	return c
+}
+
+// IsSetField checks if a field should be treated as a Set.
+// Rules for the dotted path (e.g., "ports.fixed_ips") take precedence over rules for the plain field name.
+// Without a set override, arrays declaring uniqueItems are Sets.
+func IsSetField(cfg SchemaConfig, path, name string, uniqueItems bool) bool {
+	for _, key := range []string{path, name} {
+		if override, ok := cfg.FieldOverrides[key]; ok && override.Set != nil {
+			return *override.Set
+		}
+	}
+	return uniqueItems || cfg.SetFields[path] || cfg.SetFields[name]
+}
+
+// CoercedType returns the scalar type a field is coerced into, or "" when it keeps its declared type.
+// Rules for the dotted path take precedence over rules for the plain field name.
+func CoercedType(cfg SchemaConfig, path, name string) string {
+	if t, ok := cfg.TypeCoercions[path]; ok {
+		return t
+	}
+	return cfg.TypeCoercions[name]
+}
+
+// IsExcludedField checks if a field is excluded, by its plain name, its dotted path (e.g., "ports.fixed_ips"),
+// or a glob or regular expression pattern (see config.MatchFieldPattern)
+func IsExcludedField(cfg SchemaConfig, path, name string) bool {
+	if cfg.ExcludedFields[name] || cfg.ExcludedFields[path] {
+		return true
+	}
+	for pattern := range cfg.ExcludedFields {
+		if config.IsFieldPattern(pattern) && config.MatchFieldPattern(pattern, path, name) {
+			return true
+		}
+	}
+	return false
 }
 
 // GetDefaultDescription returns a generated description based on the field name if the current description is empty or too short.
@@ -75,11 +133,43 @@ func FillDescriptions(fields []FieldInfo, resourceName string) {
 	}
 }
 
+// DropSecretFields removes the top-level fields the schema marks writeOnly from response fields,
+// so the values sent in requests are never refreshed from the API
+func DropSecretFields(fields []FieldInfo) []FieldInfo {
+	kept := fields[:0]
+	for _, f := range fields {
+		if !f.Secret {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// BuildUpload returns how a request is uploaded when its operation accepts multipart/form-data.
+// Top-level binary string fields become file parts. Operations that also accept JSON are only
+// sent as multipart when they upload files; nil means the request is always sent as JSON.
+func BuildUpload(fields []FieldInfo, acceptsForm, acceptsJSON bool) *Upload {
+	if !acceptsForm {
+		return nil
+	}
+	var files []string
+	for _, f := range fields {
+		if f.Type == OpenAPITypeString && f.Format == "binary" {
+			files = append(files, f.Name)
+		}
+	}
+	if len(files) == 0 && acceptsJSON {
+		return nil
+	}
+	sort.Strings(files)
+	return &Upload{Files: files, FormOnly: !acceptsJSON}
+}
+
 // ApplySchemaSkipRecursive applies SchemaSkip to fields in cfg.ExcludedFields but not in inputFields.
 func ApplySchemaSkipRecursive(cfg SchemaConfig, fields []FieldInfo, inputFields map[string]bool) {
 	for i := range fields {
 		f := &fields[i]
-		if cfg.ExcludedFields[f.Name] && !inputFields[f.Name] {
+		if IsExcludedField(cfg, f.Name, f.Name) && !inputFields[f.Name] {
 			f.SchemaSkip = true
 		}
 		if len(f.Properties) > 0 {
@@ -93,29 +183,46 @@ func ApplySchemaSkipRecursive(cfg SchemaConfig, fields []FieldInfo, inputFields
 
 // CalculateSchemaStatusRecursive recursively determines ServerComputed, UseStateForUnknown,
 // and adjusts Required status for nested fields.
+// Fields are matched with the create and response fields by their dotted path (e.g., "ports.subnet"),
+// so a property required by the create request stays required at any depth.
 func CalculateSchemaStatusRecursive(fields []FieldInfo, createFields, responseFields []FieldInfo) {
-	createMap := make(map[string]FieldInfo)
-	for _, f := range createFields {
-		createMap[f.Name] = f
-	}
+	calculateSchemaStatus(fields, "", indexFieldPaths(createFields, ""), indexFieldPaths(responseFields, ""))
+}
 
-	responseMap := make(map[string]FieldInfo)
-	for _, f := range responseFields {
-		responseMap[f.Name] = f
+// indexFieldPaths maps the dotted path of every field and nested property to the field
+func indexFieldPaths(fields []FieldInfo, prefix string) map[string]FieldInfo {
+	index := make(map[string]FieldInfo)
+	for _, f := range fields {
+		path := prefix + f.Name
+		index[path] = f
+		nested := f.Properties
+		if f.ItemSchema != nil {
+			nested = f.ItemSchema.Properties
+		}
+		for k, v := range indexFieldPaths(nested, path+".") {
+			index[k] = v
+		}
 	}
+	return index
+}
 
+func calculateSchemaStatus(fields []FieldInfo, prefix string, createMap, responseMap map[string]FieldInfo) {
 	for i := range fields {
 		f := &fields[i]
+		path := prefix + f.Name
 
 		// ServerComputed logic
-		cf, inCreate := createMap[f.Name]
-		_, inResponse := responseMap[f.Name]
+		cf, inCreate := createMap[path]
+		_, inResponse := responseMap[path]
 
 		if f.ReadOnly {
 			f.ServerComputed = false
 		} else if !inCreate {
 			f.ServerComputed = true
-		} else if !cf.Required && inResponse {
+		} else if cf.Required {
+			// Required by the create request, even when the field was merged from another schema
+			f.Required = true
+		} else if inResponse {
 			f.ServerComputed = true
 		}
 
@@ -131,23 +238,9 @@ func CalculateSchemaStatusRecursive(fields []FieldInfo, createFields, responseFi
 
 		// Recursively process nested types
 		if f.GoType == TFTypeObject {
-			var subCreate, subResponse []FieldInfo
-			if inCreate {
-				subCreate = cf.Properties
-			}
-			if inResponse {
-				subResponse = responseMap[f.Name].Properties
-			}
-			CalculateSchemaStatusRecursive(f.Properties, subCreate, subResponse)
-		} else if (f.GoType == TFTypeList || f.GoType == TFTypeSet) && f.ItemSchema != nil {
-			var subCreate, subResponse []FieldInfo
-			if inCreate && cf.ItemSchema != nil {
-				subCreate = cf.ItemSchema.Properties
-			}
-			if inResponse && responseMap[f.Name].ItemSchema != nil {
-				subResponse = responseMap[f.Name].ItemSchema.Properties
-			}
-			CalculateSchemaStatusRecursive(f.ItemSchema.Properties, subCreate, subResponse)
+			calculateSchemaStatus(f.Properties, path+".", createMap, responseMap)
+		} else if (f.GoType == TFTypeList || f.GoType == TFTypeSet || f.IsObjectMap()) && f.ItemSchema != nil {
+			calculateSchemaStatus(f.ItemSchema.Properties, path+".", createMap, responseMap)
 		}
 	}
 }
@@ -0,0 +1,114 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SchemaField is a property of a flattened schema
+type SchemaField struct {
+	Type     string // OpenAPI type; arrays are written as "array of <item type>"
+	Required bool   // Whether the property is required by its enclosing object
+}
+
+// FlattenSchema returns the properties of an object schema keyed by their path. Properties of
+// nested objects and of array items are keyed as "parent.child"; circular references are not followed.
+func FlattenSchema(schemaRef *openapi3.SchemaRef) map[string]SchemaField {
+	fields := make(map[string]SchemaField)
+	if schemaRef != nil {
+		flattenProperties(schemaRef.Value, "", fields, make(map[*openapi3.Schema]bool))
+	}
+	return fields
+}
+
+func flattenProperties(schema *openapi3.Schema, prefix string, fields map[string]SchemaField, visiting map[*openapi3.Schema]bool) {
+	schema = objectSchema(schema)
+	if schema == nil || visiting[schema] {
+		return
+	}
+	visiting[schema] = true
+	defer delete(visiting, schema)
+
+	required := make(map[string]bool)
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	for name, prop := range schema.Properties {
+		if prop == nil || prop.Value == nil {
+			continue
+		}
+		path := prefix + name
+		typeStr := GetSchemaType(prop.Value)
+		nested := prop.Value
+		if typeStr == OpenAPITypeArray {
+			itemType := ""
+			nested = nil
+			if prop.Value.Items != nil && prop.Value.Items.Value != nil {
+				nested = prop.Value.Items.Value
+				itemType = GetSchemaType(nested)
+			}
+			typeStr = fmt.Sprintf("array of %s", itemType)
+		}
+		fields[path] = SchemaField{Type: typeStr, Required: required[name]}
+		flattenProperties(nested, path+".", fields, visiting)
+	}
+}
+
+// objectSchema returns the schema holding the properties of an object, unwrapping a single allOf
+func objectSchema(schema *openapi3.Schema) *openapi3.Schema {
+	if schema == nil {
+		return nil
+	}
+	if len(schema.Properties) == 0 && len(schema.AllOf) == 1 && schema.AllOf[0].Value != nil {
+		return objectSchema(schema.AllOf[0].Value)
+	}
+	if len(schema.Properties) == 0 {
+		return nil
+	}
+	return schema
+}
+
+// DiffFields describes the fields added, removed, retyped or with a changed required flag
+// between two flattened schemas, sorted by path. Each line is prefixed with the schema role,
+// e.g. "response field name removed".
+func DiffFields(role string, oldFields, newFields map[string]SchemaField) []string {
+	paths := make(map[string]bool)
+	for path := range oldFields {
+		paths[path] = true
+	}
+	for path := range newFields {
+		paths[path] = true
+	}
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+
+	var changes []string
+	for _, path := range sorted {
+		oldField, inOld := oldFields[path]
+		newField, inNew := newFields[path]
+		switch {
+		case !inOld:
+			changes = append(changes, fmt.Sprintf("%s field %s added (%s)", role, path, newField.Type))
+		case !inNew:
+			changes = append(changes, fmt.Sprintf("%s field %s removed", role, path))
+		default:
+			if oldField.Type != newField.Type {
+				changes = append(changes, fmt.Sprintf("%s field %s retyped: %s -> %s", role, path, oldField.Type, newField.Type))
+			}
+			if oldField.Required != newField.Required {
+				if newField.Required {
+					changes = append(changes, fmt.Sprintf("%s field %s is now required", role, path))
+				} else {
+					changes = append(changes, fmt.Sprintf("%s field %s is no longer required", role, path))
+				}
+			}
+		}
+	}
+	return changes
+}
@@ -0,0 +1,66 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestFlattenSchema(t *testing.T) {
+	str := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	node := &openapi3.Schema{Type: &openapi3.Types{"object"}, Required: []string{"name"}}
+	node.Properties = openapi3.Schemas{
+		"name":   str,
+		"parent": &openapi3.SchemaRef{Value: node},
+		"ports": &openapi3.SchemaRef{Value: &openapi3.Schema{
+			Type: &openapi3.Types{"array"},
+			Items: &openapi3.SchemaRef{Value: &openapi3.Schema{
+				Type:       &openapi3.Types{"object"},
+				Properties: openapi3.Schemas{"cidr": str},
+			}},
+		}},
+		"tags": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"array"}, Items: str}},
+	}
+	wrapped := &openapi3.SchemaRef{Value: &openapi3.Schema{AllOf: openapi3.SchemaRefs{{Value: node}}}}
+
+	want := map[string]SchemaField{
+		"name":       {Type: "string", Required: true},
+		"parent":     {Type: "object"},
+		"ports":      {Type: "array of object"},
+		"ports.cidr": {Type: "string"},
+		"tags":       {Type: "array of string"},
+	}
+	if got := FlattenSchema(wrapped); !reflect.DeepEqual(got, want) {
+		t.Errorf("FlattenSchema() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	oldFields := map[string]SchemaField{
+		"name":  {Type: "string", Required: true},
+		"size":  {Type: "integer"},
+		"slug":  {Type: "string"},
+		"state": {Type: "string"},
+	}
+	newFields := map[string]SchemaField{
+		"name":  {Type: "string"},
+		"size":  {Type: "number", Required: true},
+		"state": {Type: "string"},
+		"tags":  {Type: "array of string"},
+	}
+
+	want := []string{
+		"response field name is no longer required",
+		"response field size retyped: integer -> number",
+		"response field size is now required",
+		"response field slug removed",
+		"response field tags added (array of string)",
+	}
+	if got := DiffFields("response", oldFields, newFields); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffFields() = %q, want %q", got, want)
+	}
+	if got := DiffFields("response", oldFields, oldFields); got != nil {
+		t.Errorf("DiffFields() of identical fields = %q, want nil", got)
+	}
+}
@@ -1,9 +1,12 @@
 package common
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
 )
 
 func TestExtractFields(t *testing.T) {
@@ -326,3 +329,371 @@ func TestExtractFields_NestedObject(t *testing.T) {
 		t.Errorf("notifications type: expected boolean, got %s", notif.Type)
 	}
 }
+
+func TestIsSetField(t *testing.T) {
+	yes, no := true, false
+	cfg := SchemaConfig{
+		SetFields: map[string]bool{"security_groups": true, "ports.fixed_ips": true},
+		FieldOverrides: map[string]config.FieldConfig{
+			"floating_ips":          {Set: &yes},
+			"ports.security_groups": {Set: &no},
+			"security_groups":       {Computed: true},
+		},
+	}
+
+	tests := []struct {
+		path        string
+		name        string
+		uniqueItems bool
+		want        bool
+	}{
+		{"security_groups", "security_groups", false, true},       // global name, override without set
+		{"rules.security_groups", "security_groups", false, true}, // global name matches at any depth
+		{"ports.security_groups", "security_groups", true, false}, // path override forces a List, even with uniqueItems
+		{"ports.fixed_ips", "fixed_ips", false, true},             // global dotted path
+		{"subnets.fixed_ips", "fixed_ips", false, false},          // dotted path does not match elsewhere
+		{"floating_ips", "floating_ips", false, true},             // override by name
+		{"tags", "tags", true, true},                              // uniqueItems
+		{"tags", "tags", false, false},
+	}
+	for _, tt := range tests {
+		if got := IsSetField(cfg, tt.path, tt.name, tt.uniqueItems); got != tt.want {
+			t.Errorf("IsSetField(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestIsExcludedField(t *testing.T) {
+	cfg := SchemaConfig{
+		ExcludedFields: map[string]bool{
+			"created":             true,
+			"ports.fixed_ips":     true,
+			"marketplace_*":       true,
+			"rules.*_id":          true,
+			"/_error_traceback$/": true,
+			"/^subnets\\.cidr$/":  true,
+		},
+	}
+
+	tests := []struct {
+		path string
+		name string
+		want bool
+	}{
+		{"created", "created", true},                                                   // plain name
+		{"ports.created", "created", true},                                             // plain name matches at any depth
+		{"ports.fixed_ips", "fixed_ips", true},                                         // dotted path
+		{"subnets.fixed_ips", "fixed_ips", false},                                      // dotted path does not match elsewhere
+		{"marketplace_offering_uuid", "marketplace_offering_uuid", true},               // glob on the name
+		{"ports.marketplace_plan", "marketplace_plan", true},                           // glob at any depth
+		{"rules.network_id", "network_id", true},                                       // dotted glob on the path
+		{"ports.network_id", "network_id", false},                                      // dotted glob does not match elsewhere
+		{"service_settings_error_traceback", "service_settings_error_traceback", true}, // regular expression on the name
+		{"subnets.cidr", "cidr", true},                                                 // regular expression on the path
+		{"networks.cidr", "cidr", false},
+		{"name", "name", false},
+	}
+	for _, tt := range tests {
+		if got := IsExcludedField(cfg, tt.path, tt.name); got != tt.want {
+			t.Errorf("IsExcludedField(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestSchemaConfig_ForResource(t *testing.T) {
+	yes := true
+	base := SchemaConfig{
+		ExcludedFields: map[string]bool{"created": true},
+		FieldOverrides: map[string]config.FieldConfig{"tags": {Computed: true}},
+	}
+	resource := &config.Resource{
+		Name:           "openstack_port",
+		ExcludedFields: []string{"ports.fixed_ips"},
+		SetFields:      map[string]config.FieldConfig{"security_groups": {Set: &yes}},
+	}
+
+	cfg := base.ForResource(resource)
+	if !IsExcludedField(cfg, "created", "created") || !IsExcludedField(cfg, "ports.fixed_ips", "fixed_ips") {
+		t.Errorf("ExcludedFields = %v, want generator and resource exclusions", cfg.ExcludedFields)
+	}
+	if !IsSetField(cfg, "security_groups", "security_groups", false) || !cfg.FieldOverrides["tags"].Computed {
+		t.Errorf("FieldOverrides = %v, want generator and resource overrides", cfg.FieldOverrides)
+	}
+	// The base config is shared by every resource and data source
+	if len(base.ExcludedFields) != 1 || len(base.FieldOverrides) != 1 {
+		t.Errorf("ForResource modified the base config: %+v", base)
+	}
+}
+
+func TestCalculateSchemaStatusRecursive_NestedRequired(t *testing.T) {
+	port := func(subnetRequired bool) []FieldInfo {
+		return []FieldInfo{
+			{Name: "subnet", GoType: TFTypeString, Required: subnetRequired},
+			{Name: "name", GoType: TFTypeString},
+			{Name: "fixed_ips", GoType: TFTypeList, ItemType: OpenAPITypeObject, ItemSchema: &FieldInfo{
+				Properties: []FieldInfo{{Name: "subnet_id", GoType: TFTypeString, Required: subnetRequired}},
+			}},
+		}
+	}
+	createFields := []FieldInfo{
+		{Name: "ports", GoType: TFTypeList, ItemType: OpenAPITypeObject, ItemSchema: &FieldInfo{Properties: port(true)}},
+	}
+	responseFields := []FieldInfo{
+		{Name: "ports", GoType: TFTypeList, ItemType: OpenAPITypeObject, ItemSchema: &FieldInfo{Properties: port(false)}},
+		{Name: "port", GoType: TFTypeObject, Properties: port(false)},
+	}
+	// The model takes its nested properties from the response, which does not mark them as required
+	fields := []FieldInfo{responseFields[0].Clone(), responseFields[1].Clone()}
+
+	CalculateSchemaStatusRecursive(fields, createFields, responseFields)
+
+	tests := []struct {
+		name     string
+		field    FieldInfo
+		required bool
+		computed bool
+	}{
+		{"ports.subnet", fields[0].ItemSchema.Properties[0], true, false},
+		{"ports.name", fields[0].ItemSchema.Properties[1], false, true},
+		{"ports.fixed_ips.subnet_id", fields[0].ItemSchema.Properties[2].ItemSchema.Properties[0], true, false},
+		{"port.subnet", fields[1].Properties[0], false, true}, // same name under a field missing from the create request
+	}
+	for _, tt := range tests {
+		if tt.field.Required != tt.required || tt.field.ServerComputed != tt.computed {
+			t.Errorf("%s: Required = %v, ServerComputed = %v, want %v, %v", tt.name, tt.field.Required, tt.field.ServerComputed, tt.required, tt.computed)
+		}
+	}
+}
+
+func TestGetSchemaType(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *openapi3.Schema
+		want   string
+	}{
+		{"single type", &openapi3.Schema{Type: &openapi3.Types{"string"}}, "string"},
+		{"nullable 3.1 type", &openapi3.Schema{Type: &openapi3.Types{"null", "integer"}}, "integer"},
+		{"only null", &openapi3.Schema{Type: &openapi3.Types{"null"}}, ""},
+		{"oneOf fallback", &openapi3.Schema{OneOf: openapi3.SchemaRefs{{Value: &openapi3.Schema{Type: &openapi3.Types{"boolean"}}}}}, "boolean"},
+		{"no type", &openapi3.Schema{}, ""},
+	}
+	for _, tt := range tests {
+		if got := GetSchemaType(tt.schema); got != tt.want {
+			t.Errorf("%s: GetSchemaType() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExtractFields_WriteOnly(t *testing.T) {
+	schema := &openapi3.SchemaRef{
+		Value: &openapi3.Schema{
+			Type: &openapi3.Types{"object"},
+			Properties: openapi3.Schemas{
+				"name":     &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+				"password": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, WriteOnly: true}},
+			},
+		},
+	}
+
+	fields, err := ExtractFields(SchemaConfig{}, schema, false)
+	if err != nil {
+		t.Fatalf("ExtractFields failed: %v", err)
+	}
+	for _, f := range fields {
+		if f.Secret != (f.Name == "password") {
+			t.Errorf("%s: Secret = %v", f.Name, f.Secret)
+		}
+	}
+
+	kept := DropSecretFields(fields)
+	if len(kept) != 1 || kept[0].Name != "name" {
+		t.Errorf("DropSecretFields() = %v, want only name", kept)
+	}
+}
+
+func TestExtractFields_Int32Bounds(t *testing.T) {
+	lower := 1.0
+	schema := &openapi3.SchemaRef{
+		Value: &openapi3.Schema{
+			Type: &openapi3.Types{"object"},
+			Properties: openapi3.Schemas{
+				"count": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}, Format: "int32", Min: &lower}},
+				"size":  &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}, Format: "int64"}},
+			},
+		},
+	}
+
+	fields, err := ExtractFields(SchemaConfig{}, schema, false)
+	if err != nil {
+		t.Fatalf("ExtractFields failed: %v", err)
+	}
+	for _, f := range fields {
+		switch f.Name {
+		case "count":
+			if f.Minimum == nil || *f.Minimum != 1 || f.Maximum == nil || *f.Maximum != 2147483647 {
+				t.Errorf("count: bounds = %v..%v, want 1..2147483647", f.Minimum, f.Maximum)
+			}
+		case "size":
+			if f.Minimum != nil || f.Maximum != nil {
+				t.Errorf("size: expected no bounds, got %v..%v", f.Minimum, f.Maximum)
+			}
+		}
+	}
+}
+
+func TestExtractFields_Defaults(t *testing.T) {
+	schema := &openapi3.SchemaRef{
+		Value: &openapi3.Schema{
+			Type: &openapi3.Types{"object"},
+			Properties: openapi3.Schemas{
+				"cidr":    &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Default: "192.168.42.0/24"}},
+				"size":    &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}, Default: 10.0}},
+				"ratio":   &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"number"}, Default: 0.5}},
+				"enabled": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"boolean"}, Default: true}},
+				"created": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "date-time", Default: "2024-01-01T00:00:00Z"}},
+				"tags":    &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"array"}, Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}, Default: []any{}}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		cfg  SchemaConfig
+		want map[string]string
+	}{
+		{
+			name: "defaults",
+			want: map[string]string{"cidr": `"192.168.42.0/24"`, "size": "10", "ratio": "0.5", "enabled": "true", "created": "", "tags": ""},
+		},
+		{
+			name: "ignore defaults",
+			cfg:  SchemaConfig{IgnoreDefaults: true},
+			want: map[string]string{"cidr": "", "size": "", "ratio": "", "enabled": ""},
+		},
+		{
+			name: "ignore field default",
+			cfg:  SchemaConfig{FieldOverrides: map[string]config.FieldConfig{"cidr": {IgnoreDefault: true}}},
+			want: map[string]string{"cidr": "", "size": "10"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := ExtractFields(tt.cfg, schema, false)
+			if err != nil {
+				t.Fatalf("ExtractFields failed: %v", err)
+			}
+			for _, f := range fields {
+				if want, ok := tt.want[f.Name]; ok && f.Default != want {
+					t.Errorf("%s: Default = %q, want %q", f.Name, f.Default, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildUpload(t *testing.T) {
+	fields := []FieldInfo{
+		{Name: "name", Type: OpenAPITypeString},
+		{Name: "logo", Type: OpenAPITypeString, Format: "binary"},
+		{Name: "image", Type: OpenAPITypeString, Format: "binary"},
+		{Name: "homepage", Type: OpenAPITypeString, Format: "uri"},
+	}
+
+	tests := []struct {
+		name        string
+		fields      []FieldInfo
+		acceptsForm bool
+		acceptsJSON bool
+		want        *Upload
+	}{
+		{"json only", fields, false, true, nil},
+		{"form with files", fields, true, true, &Upload{Files: []string{"image", "logo"}}},
+		{"form without files", fields[:1], true, true, nil},
+		{"form only", fields[:1], true, false, &Upload{FormOnly: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildUpload(tt.fields, tt.acceptsForm, tt.acceptsJSON)
+			if (got == nil) != (tt.want == nil) {
+				t.Fatalf("BuildUpload() = %+v, want %+v", got, tt.want)
+			}
+			if got == nil {
+				return
+			}
+			if strings.Join(got.Files, ",") != strings.Join(tt.want.Files, ",") || got.FormOnly != tt.want.FormOnly {
+				t.Errorf("BuildUpload() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractFilterParams(t *testing.T) {
+	strSchema := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	arrayOf := func(items *openapi3.SchemaRef) *openapi3.SchemaRef {
+		return &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"array"}, Items: items}}
+	}
+	explode := false
+	op := &openapi3.Operation{Parameters: openapi3.Parameters{
+		{Value: &openapi3.Parameter{Name: "name", In: "query", Schema: strSchema}},
+		{Value: &openapi3.Parameter{Name: "page", In: "query", Schema: strSchema}},
+		{Value: &openapi3.Parameter{Name: "uuid__in", In: "query", Style: "form", Explode: &explode, Schema: arrayOf(strSchema)}},
+		{Value: &openapi3.Parameter{Name: "state", In: "query", Schema: arrayOf(&openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Enum: []any{"OK", "ERRED"}}})}},
+		{Value: &openapi3.Parameter{Name: "size", In: "query", Schema: arrayOf(&openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}}})}},
+		{Value: &openapi3.Parameter{Name: "nested", In: "query", Schema: arrayOf(&openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"object"}}})}},
+	}}
+
+	want := []FilterParam{
+		{Name: "name", Type: "String"},
+		{Name: "size", Type: "List", ItemType: "Int64", Explode: true},
+		{Name: "state", Type: "List", ItemType: "String", Explode: true, Enum: []string{"OK", "ERRED"}},
+		{Name: "uuid__in", Type: "List", ItemType: "String"},
+	}
+	if got := ExtractFilterParams(op, ""); !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractFilterParams() = %+v, want %+v", got, want)
+	}
+}
+
+func TestExtractOrdering(t *testing.T) {
+	strSchema := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	explode := false
+	ordering := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type:  &openapi3.Types{"array"},
+		Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Enum: []any{"-name", "name"}}},
+	}}
+
+	tests := []struct {
+		name string
+		op   *openapi3.Operation
+		want *Ordering
+	}{
+		{
+			name: "no ordering",
+			op: &openapi3.Operation{Parameters: openapi3.Parameters{
+				{Value: &openapi3.Parameter{Name: "name", In: "query", Schema: strSchema}},
+			}},
+		},
+		{
+			name: "comma-separated orderings",
+			op: &openapi3.Operation{Parameters: openapi3.Parameters{
+				{Value: &openapi3.Parameter{Name: "o", In: "query", Style: "form", Explode: &explode, Schema: ordering}},
+			}},
+			want: &Ordering{Values: []string{"-name", "name"}},
+		},
+		{
+			name: "free-form ordering",
+			op: &openapi3.Operation{Parameters: openapi3.Parameters{
+				{Value: &openapi3.Parameter{Name: "o", In: "query", Schema: strSchema}},
+			}},
+			want: &Ordering{Explode: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractOrdering(tt.op); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractOrdering() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -33,7 +33,14 @@ func CalculateSDKType(f *FieldInfo) {
 	switch f.Type {
 	case OpenAPITypeString:
 		f.SDKType = GoTypeString
+		if f.URLReference {
+			f.SDKType = "common.URLReference" // Accepts the URL or the object it identifies
+		}
 		f.IsPointer = true // Strings are almost always pointers in SDK
+		if f.JSON {
+			f.SDKType = "json.RawMessage" // Sent and received verbatim
+			f.IsPointer = false
+		}
 
 	case OpenAPITypeInteger:
 		f.SDKType = GoTypeInt64
@@ -49,7 +56,9 @@ func CalculateSDKType(f *FieldInfo) {
 
 	case OpenAPITypeArray:
 		f.IsPointer = !f.Required // Slices are pointers if optional in this SDK convention
-		if f.ItemType == OpenAPITypeString {
+		if f.URLReference {
+			f.SDKType = "[]common.URLReference"
+		} else if f.ItemType == OpenAPITypeString {
 			f.SDKType = "[]string"
 		} else if f.ItemType == OpenAPITypeInteger {
 			f.SDKType = "[]int64"
@@ -70,6 +79,12 @@ func CalculateSDKType(f *FieldInfo) {
 		// Map detection (Terraform types.Map logic)
 		if f.GoType == TFTypeMap {
 			f.IsPointer = false // Maps are reference types
+			if f.IsObjectMap() {
+				// Anonymous value structs are named by templates, like array items
+				f.SDKType = "map[string]" + f.ItemSchema.RefName
+				CalculateTypeMeta(f)
+				return
+			}
 			valType := GoTypeAny
 			switch f.ItemType {
 			case OpenAPITypeNumber:
@@ -92,6 +107,25 @@ func CalculateSDKType(f *FieldInfo) {
 		}
 	}
 
+	// Nullable request values are wrapped so that an explicit null can be sent
+	if f.SendNull {
+		f.SDKType = "common.Nullable[" + f.SDKType + "]"
+		f.IsPointer = false
+	}
+
 	// Always calculate TypeMeta after SDK type is determined
 	CalculateTypeMeta(f)
 }
+
+// MarkSendNull flags optional, nullable scalar fields of an update request so that
+// clearing the attribute sends an explicit null instead of leaving the field out
+func MarkSendNull(fields []FieldInfo) {
+	for i := range fields {
+		f := &fields[i]
+		if !f.Nullable || f.Required || f.ReadOnly || f.URLReference || f.JsonTag == "-" || f.TypeMeta.IsComplex {
+			continue
+		}
+		f.SendNull = true
+		CalculateSDKType(f)
+	}
+}
@@ -47,6 +47,28 @@ func TestCalculateSDKType(t *testing.T) {
 			expected:  "map[string]float64",
 			isPointer: false,
 		},
+		{
+			name: "map of objects",
+			field: FieldInfo{
+				Type:       OpenAPITypeObject,
+				GoType:     TFTypeMap,
+				ItemType:   OpenAPITypeObject,
+				ItemSchema: &FieldInfo{Type: OpenAPITypeObject, GoType: TFTypeObject, RefName: "Quota"},
+			},
+			expected:  "map[string]Quota",
+			isPointer: false,
+		},
+		{
+			name: "map of anonymous objects",
+			field: FieldInfo{
+				Type:       OpenAPITypeObject,
+				GoType:     TFTypeMap,
+				ItemType:   OpenAPITypeObject,
+				ItemSchema: &FieldInfo{Type: OpenAPITypeObject, GoType: TFTypeObject},
+			},
+			expected:  "map[string]",
+			isPointer: false,
+		},
 		{
 			name: "object with ref",
 			field: FieldInfo{
@@ -71,3 +93,34 @@ func TestCalculateSDKType(t *testing.T) {
 		})
 	}
 }
+
+func TestMarkSendNull(t *testing.T) {
+	fields := []FieldInfo{
+		{Name: "description", Type: OpenAPITypeString, GoType: TFTypeString, Nullable: true},
+		{Name: "name", Type: OpenAPITypeString, GoType: TFTypeString, Nullable: true, Required: true},
+		{Name: "limit", Type: OpenAPITypeInteger, GoType: TFTypeInt64},
+		{Name: "tags", Type: OpenAPITypeArray, ItemType: OpenAPITypeString, GoType: TFTypeList, Nullable: true},
+	}
+	for i := range fields {
+		CalculateSDKType(&fields[i])
+	}
+	MarkSendNull(fields)
+
+	want := map[string]string{
+		"description": "common.Nullable[string]",
+		"name":        GoTypeString,
+		"limit":       GoTypeInt64,
+		"tags":        "[]string",
+	}
+	for _, f := range fields {
+		if f.SendNull != (f.Name == "description") {
+			t.Errorf("%s: SendNull = %v", f.Name, f.SendNull)
+		}
+		if f.SDKType != want[f.Name] {
+			t.Errorf("%s: SDKType = %q, want %q", f.Name, f.SDKType, want[f.Name])
+		}
+	}
+	if fields[0].IsPointer {
+		t.Error("description: nullable wrapper should not be a pointer")
+	}
+}
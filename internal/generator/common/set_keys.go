@@ -0,0 +1,79 @@
+package common
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// CheckSetKeys checks that the fields with set element keys, at any depth, are sets of objects
+// whose elements have the key attributes
+func CheckSetKeys(fields []FieldInfo) error {
+	return checkSetKeys(fields, "")
+}
+
+func checkSetKeys(fields []FieldInfo, prefix string) error {
+	for _, f := range fields {
+		path := prefix + f.Name
+		if len(f.SetKeys) > 0 {
+			if f.GoType != TFTypeSet || f.ItemSchema == nil || len(f.ItemSchema.Properties) == 0 {
+				return fmt.Errorf("field %s: keys require a set of objects", path)
+			}
+			for _, key := range f.SetKeys {
+				if !slices.ContainsFunc(f.ItemSchema.Properties, func(p FieldInfo) bool { return p.Name == key }) {
+					return fmt.Errorf("field %s: key %s is not an attribute of the set elements", path, key)
+				}
+			}
+		}
+		if err := checkSetKeys(nestedProperties(f), path+"."); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetKeysModifier returns the common.SetElementKeys plan modifier of a set of objects identified by keys,
+// e.g. `common.SetElementKeys{Keys: []string{"protocol"}}`, or "" when the field has no keys.
+// The modifier also matches the keyed sets nested in the elements, through nested objects.
+func SetKeysModifier(f FieldInfo) string {
+	if len(f.SetKeys) == 0 {
+		return ""
+	}
+	return "common.SetElementKeys" + setKeysLiteral(f.SetKeys, nestedProperties(f))
+}
+
+// setKeysLiteral returns the composite literal of the keys of a set or object and of the keyed sets nested in it
+func setKeysLiteral(keys []string, properties []FieldInfo) string {
+	var parts []string
+	if len(keys) > 0 {
+		quoted := make([]string, len(keys))
+		for i, key := range keys {
+			quoted[i] = strconv.Quote(key)
+		}
+		parts = append(parts, "Keys: []string{"+strings.Join(quoted, ", ")+"}")
+	}
+	var nested []string
+	for _, p := range properties {
+		if lit := nestedKeysLiteral(p); lit != "" {
+			nested = append(nested, strconv.Quote(p.Name)+": "+lit)
+		}
+	}
+	if len(nested) > 0 {
+		parts = append(parts, "Nested: map[string]common.SetElementKeys{"+strings.Join(nested, ", ")+"}")
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// nestedKeysLiteral returns the literal of a keyed set, or of an object holding keyed sets, and "" otherwise
+func nestedKeysLiteral(f FieldInfo) string {
+	switch {
+	case len(f.SetKeys) > 0:
+		return setKeysLiteral(f.SetKeys, nestedProperties(f))
+	case f.GoType == TFTypeObject:
+		if lit := setKeysLiteral(nil, f.Properties); lit != "{}" {
+			return lit
+		}
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestSetKeysModifier(t *testing.T) {
+	ranges := FieldInfo{
+		Name: "ip_ranges", GoType: TFTypeSet, SetKeys: []string{"cidr"},
+		ItemSchema: &FieldInfo{Properties: []FieldInfo{{Name: "cidr"}, {Name: "id"}}},
+	}
+	rules := FieldInfo{
+		Name: "rules", GoType: TFTypeSet, SetKeys: []string{"protocol", "port"},
+		ItemSchema: &FieldInfo{Properties: []FieldInfo{
+			{Name: "protocol"},
+			{Name: "port"},
+			{Name: "source", GoType: TFTypeObject, Properties: []FieldInfo{ranges}},
+			{Name: "target", GoType: TFTypeObject, Properties: []FieldInfo{{Name: "name"}}},
+		}},
+	}
+
+	want := `common.SetElementKeys{Keys: []string{"protocol", "port"}, Nested: map[string]common.SetElementKeys{"source": {Nested: map[string]common.SetElementKeys{"ip_ranges": {Keys: []string{"cidr"}}}}}}`
+	if got := SetKeysModifier(rules); got != want {
+		t.Errorf("SetKeysModifier() = %s, want %s", got, want)
+	}
+	if got := SetKeysModifier(FieldInfo{Name: "tags", GoType: TFTypeSet}); got != "" {
+		t.Errorf("SetKeysModifier() without keys = %s", got)
+	}
+
+	if err := CheckSetKeys([]FieldInfo{rules}); err != nil {
+		t.Errorf("CheckSetKeys() = %v", err)
+	}
+	rules.SetKeys = []string{"protocol", "direction"}
+	if err := CheckSetKeys([]FieldInfo{rules}); err == nil {
+		t.Error("CheckSetKeys() accepted a key that is not an element attribute")
+	}
+	list := FieldInfo{Name: "ports", GoType: TFTypeList, SetKeys: []string{"id"}, ItemSchema: &FieldInfo{Properties: []FieldInfo{{Name: "id"}}}}
+	if err := CheckSetKeys([]FieldInfo{list}); err == nil {
+		t.Error("CheckSetKeys() accepted keys on a list")
+	}
+}
@@ -0,0 +1,61 @@
+package common
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+)
+
+// FieldTransform describes the generated conversions of a transformation
+type FieldTransform struct {
+	GoType  string // Terraform type of the fields it applies to
+	ToAPI   string // Function converting the attribute value sent to the API, empty to send it as given
+	FromAPI string // Function converting the value read from the API
+}
+
+// FieldTransforms maps transformations to the conversion functions of the generated common package
+var FieldTransforms = map[string]FieldTransform{
+	config.TransformMBToGB:         {GoType: TFTypeInt64, ToAPI: "common.GBToMB", FromAPI: "common.MBToGB"},
+	config.TransformLowercase:      {GoType: TFTypeString, ToAPI: "common.Lowercase", FromAPI: "common.Lowercase"},
+	config.TransformStripURLToUUID: {GoType: TFTypeString, FromAPI: "common.URLToUUID"},
+}
+
+// ApplyTransforms sets the transformations configured for top-level fields. Bounds of sizes are
+// converted to gigabytes, lowercase values are compared case-insensitively, so that values written
+// in another case are not reported as changes, and UUIDs taken from URLs are compared as written.
+func ApplyTransforms(cfg SchemaConfig, fieldSets ...[]FieldInfo) error {
+	for _, fields := range fieldSets {
+		for i := range fields {
+			f := &fields[i]
+			override, ok := cfg.FieldOverrides[f.Name]
+			if !ok || override.Transform == "" {
+				continue
+			}
+			transform := FieldTransforms[override.Transform]
+			if f.GoType != transform.GoType || f.JSON || f.URLReference || f.Format == "date-time" {
+				return fmt.Errorf("field %s: transform %s is not supported on %s fields", f.Name, override.Transform, f.GoType)
+			}
+			f.Transform = override.Transform
+			switch f.Transform {
+			case config.TransformMBToGB:
+				if f.Minimum != nil {
+					minimum := math.Ceil(*f.Minimum / 1024)
+					f.Minimum = &minimum
+				}
+				if f.Maximum != nil {
+					maximum := math.Floor(*f.Maximum / 1024)
+					f.Maximum = &maximum
+				}
+				f.Description = AppendSentence(f.Description, "Configured in gigabytes (GB).")
+			case config.TransformLowercase:
+				f.Normalize = config.NormalizeCaseInsensitive
+			case config.TransformStripURLToUUID:
+				f.Normalize = ""
+				f.Format = ""
+			}
+			CalculateSDKType(f)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+)
+
+func TestApplyTransforms(t *testing.T) {
+	newField := func(name, openAPIType, format string) FieldInfo {
+		f := FieldInfo{Name: name, Type: openAPIType, Format: format}
+		switch openAPIType {
+		case OpenAPITypeInteger:
+			f.GoType = TFTypeInt64
+		default:
+			f.GoType = TFTypeString
+		}
+		CalculateSDKType(&f)
+		return f
+	}
+	minimum, maximum := 1500.0, 2147483647.0
+	size := newField("size", OpenAPITypeInteger, "")
+	size.Description = "Size in MiB."
+	size.Minimum, size.Maximum = &minimum, &maximum
+	fields := []FieldInfo{
+		size,
+		newField("name", OpenAPITypeString, ""),
+		newField("image", OpenAPITypeString, "uri"),
+		newField("description", OpenAPITypeString, ""),
+	}
+	cfg := SchemaConfig{FieldOverrides: map[string]config.FieldConfig{
+		"size":  {Transform: config.TransformMBToGB},
+		"name":  {Transform: config.TransformLowercase},
+		"image": {Transform: config.TransformStripURLToUUID},
+	}}
+	if err := ApplyTransforms(cfg, fields); err != nil {
+		t.Fatalf("ApplyTransforms failed: %v", err)
+	}
+
+	size = fields[0]
+	if size.TypeMeta.ToAPITransform != "common.GBToMB" || size.TypeMeta.FromAPITransform != "common.MBToGB" {
+		t.Errorf("size transforms = %q, %q", size.TypeMeta.ToAPITransform, size.TypeMeta.FromAPITransform)
+	}
+	if *size.Minimum != 2 || *size.Maximum != 2097151 {
+		t.Errorf("size bounds = %v, %v, want 2, 2097151", *size.Minimum, *size.Maximum)
+	}
+	if size.Description != "Size in MiB. Configured in gigabytes (GB)." {
+		t.Errorf("size description = %q", size.Description)
+	}
+	if name := fields[1]; name.Normalize != config.NormalizeCaseInsensitive || name.TypeMeta.ToAPITransform != "common.Lowercase" {
+		t.Errorf("name normalize = %q, to API = %q", name.Normalize, name.TypeMeta.ToAPITransform)
+	}
+	if image := fields[2]; image.Format != "" || image.TypeMeta.ToAPITransform != "" || image.TypeMeta.FromAPITransform != "common.URLToUUID" {
+		t.Errorf("image format = %q, transforms = %q, %q", image.Format, image.TypeMeta.ToAPITransform, image.TypeMeta.FromAPITransform)
+	}
+	if description := fields[3]; description.Transform != "" || description.TypeMeta.FromAPITransform != "" {
+		t.Errorf("description was transformed: %q", description.Transform)
+	}
+
+	// Transformations must match the field type
+	cfg.FieldOverrides = map[string]config.FieldConfig{"name": {Transform: config.TransformMBToGB}}
+	if err := ApplyTransforms(cfg, []FieldInfo{newField("name", OpenAPITypeString, "")}); err == nil {
+		t.Error("expected an error for mb_to_gb on a string field")
+	}
+}
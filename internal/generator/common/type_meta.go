@@ -12,29 +12,53 @@ type TypeMeta struct {
 	PlanModImport string // e.g., "stringplanmodifier", "listplanmodifier"
 	PlanModType   string // e.g., "planmodifier.String", "planmodifier.List"
 
+	// Schema defaults
+	DefaultFunc string // e.g., "stringdefault.StaticString", empty for types without static defaults
+
+	// Custom types
+	CustomType string // Custom attribute type of normalized strings (e.g., "common.URLType{}")
+	ModelType  string // Model Go type of normalized strings (e.g., "common.URL")
+
 	// Value conversion (API response → TF model)
 	FromAPIFunc string // e.g., "types.StringPointerValue", "types.Int64PointerValue"
 	// Value conversion (TF model → API request)
 	ToAPIMethod string // e.g., "ValueStringPointer", "ValueInt64Pointer"
+	// Value transformation (set_fields transform)
+	FromAPITransform string // Applied to the API value before FromAPIFunc (e.g., "common.MBToGB")
+	ToAPITransform   string // Applied to the result of ToAPIMethod (e.g., "common.GBToMB")
 
 	// Validators
 	ValidatorType   string // e.g., "String", "Int64", "Float64"
 	ValidatorImport string // e.g., "stringvalidator", "int64validator", "float64validator"
+	FormatPattern   string // Regex validating the string format (uuid, uri, email), empty for other formats
+	FormatMessage   string // Error message of the format validator
 
 	// Classification flags
 	IsNested   bool // true if needs NestedAttribute (objects in list/set, or single object)
 	IsComplex  bool // true if list/set/map/object (not a simple scalar)
 	IsDateTime bool // true if string with format="date-time" (needs timetypes)
+	IsJSON     bool // true if a dynamic object exposed as a JSON string (needs jsontypes)
 }
 
 // CalculateTypeMeta populates TypeMeta on a FieldInfo based on its Type, GoType, ItemType, and Format.
 // This should be called after GoType and SDKType are already set.
 func CalculateTypeMeta(f *FieldInfo) {
+	// Start over, as the type may have changed since the last calculation
+	f.TypeMeta = TypeMeta{}
 	m := &f.TypeMeta
 
 	switch f.GoType {
 	case TFTypeString:
-		if f.Format == "date-time" {
+		if f.JSON {
+			m.IsJSON = true
+			m.SchemaAttrType = "schema.StringAttribute"
+			m.AttrValueType = "jsontypes.NormalizedType{}"
+			m.PlanModImport = "stringplanmodifier"
+			m.PlanModType = "planmodifier.String"
+			m.FromAPIFunc = "common.JSONValue"
+			m.ToAPIMethod = "" // Special: uses common.JSONRawMessage
+			m.ValidatorImport = "stringvalidator"
+		} else if f.Format == "date-time" {
 			m.IsDateTime = true
 			m.SchemaAttrType = "schema.StringAttribute"
 			m.AttrValueType = "types.StringType"
@@ -43,6 +67,20 @@ func CalculateTypeMeta(f *FieldInfo) {
 			m.FromAPIFunc = "" // Special: uses timetypes.NewRFC3339PointerValue
 			m.ToAPIMethod = "ValueStringPointer"
 			m.ValidatorImport = "stringvalidator"
+		} else if modelType, ok := NormalizedStringTypes[f.Normalize]; ok {
+			m.SchemaAttrType = "schema.StringAttribute"
+			m.CustomType = modelType + "Type{}"
+			m.ModelType = modelType
+			m.AttrValueType = m.CustomType
+			m.PlanModImport = "stringplanmodifier"
+			m.PlanModType = "planmodifier.String"
+			m.FromAPIFunc = "common.NormalizedStringPointerValue[" + modelType + "Normalization]"
+			m.ToAPIMethod = "ValueStringPointer"
+			m.ValidatorImport = "stringvalidator"
+			if v, ok := StringFormatValidators[f.Format]; ok {
+				m.FormatPattern = v.Pattern
+				m.FormatMessage = v.Message
+			}
 		} else {
 			m.SchemaAttrType = "schema.StringAttribute"
 			m.AttrValueType = "types.StringType"
@@ -51,6 +89,14 @@ func CalculateTypeMeta(f *FieldInfo) {
 			m.FromAPIFunc = "common.StringPointerValue"
 			m.ToAPIMethod = "ValueStringPointer"
 			m.ValidatorImport = "stringvalidator"
+			m.DefaultFunc = "stringdefault.StaticString"
+			if f.URLReference {
+				m.FromAPIFunc = "common.URLReferenceValue"
+			}
+			if v, ok := StringFormatValidators[f.Format]; ok {
+				m.FormatPattern = v.Pattern
+				m.FormatMessage = v.Message
+			}
 		}
 
 	case TFTypeInt64:
@@ -61,6 +107,7 @@ func CalculateTypeMeta(f *FieldInfo) {
 		m.FromAPIFunc = "types.Int64PointerValue"
 		m.ToAPIMethod = "ValueInt64Pointer"
 		m.ValidatorImport = "int64validator"
+		m.DefaultFunc = "int64default.StaticInt64"
 
 	case TFTypeBool:
 		m.SchemaAttrType = "schema.BoolAttribute"
@@ -70,6 +117,7 @@ func CalculateTypeMeta(f *FieldInfo) {
 		m.FromAPIFunc = "types.BoolPointerValue"
 		m.ToAPIMethod = "ValueBoolPointer"
 		m.ValidatorImport = "" // No dedicated bool validator import typically
+		m.DefaultFunc = "booldefault.StaticBool"
 
 	case TFTypeFloat64:
 		m.SchemaAttrType = "schema.Float64Attribute"
@@ -79,6 +127,7 @@ func CalculateTypeMeta(f *FieldInfo) {
 		m.FromAPIFunc = "types.Float64PointerValue"
 		m.ToAPIMethod = "ValueFloat64Pointer"
 		m.ValidatorImport = "float64validator"
+		m.DefaultFunc = "float64default.StaticFloat64"
 
 	case TFTypeList:
 		m.IsComplex = true
@@ -106,10 +155,15 @@ func CalculateTypeMeta(f *FieldInfo) {
 
 	case TFTypeMap:
 		m.IsComplex = true
-		m.SchemaAttrType = "schema.MapAttribute"
 		m.PlanModImport = "mapplanmodifier"
 		m.PlanModType = "planmodifier.Map"
-		m.ElemType = itemTypeToAttrType(f.ItemType)
+		if f.IsObjectMap() {
+			m.IsNested = true
+			m.SchemaAttrType = "schema.MapNestedAttribute"
+		} else {
+			m.SchemaAttrType = "schema.MapAttribute"
+			m.ElemType = itemTypeToAttrType(f.ItemType)
+		}
 
 	case TFTypeObject:
 		m.IsComplex = true
@@ -122,6 +176,11 @@ func CalculateTypeMeta(f *FieldInfo) {
 	}
 
 	m.ValidatorType = GoTypeToValidatorType(f.GoType)
+
+	if t, ok := FieldTransforms[f.Transform]; ok {
+		m.FromAPITransform = t.FromAPI
+		m.ToAPITransform = t.ToAPI
+	}
 }
 
 // GoTypeToValidatorType maps a Terraform framework type to the corresponding validator type name.
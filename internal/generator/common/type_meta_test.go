@@ -1,6 +1,7 @@
 package common
 
 import (
+	"regexp"
 	"testing"
 )
 
@@ -35,6 +36,43 @@ func TestCalculateTypeMeta_StringDateTime(t *testing.T) {
 	}
 }
 
+func TestCalculateTypeMeta_StringFormats(t *testing.T) {
+	tests := []struct {
+		format  string
+		valid   string
+		invalid string
+	}{
+		{"uuid", "a7b3f8c2d1e04f5a9b6c8d7e6f5a4b3c", "not-a-uuid"},
+		{"uuid", "a7b3f8c2-d1e0-4f5a-9b6c-8d7e6f5a4b3c", "a7b3f8c2-d1e0-4f5a-9b6c"},
+		{"uri", "https://example.com/api/projects/1/", "/api/projects/1/"},
+		{"email", "admin@example.com", "admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			f := FieldInfo{Type: OpenAPITypeString, GoType: TFTypeString, Format: tt.format}
+			CalculateTypeMeta(&f)
+
+			re := regexp.MustCompile(f.TypeMeta.FormatPattern)
+			if f.TypeMeta.FormatMessage == "" {
+				t.Error("Expected a format message")
+			}
+			if !re.MatchString(tt.valid) {
+				t.Errorf("Expected %q to match the %s pattern", tt.valid, tt.format)
+			}
+			if re.MatchString(tt.invalid) {
+				t.Errorf("Expected %q not to match the %s pattern", tt.invalid, tt.format)
+			}
+		})
+	}
+
+	f := FieldInfo{Type: OpenAPITypeString, GoType: TFTypeString, Format: "ipv4"}
+	CalculateTypeMeta(&f)
+	if f.TypeMeta.FormatPattern != "" {
+		t.Errorf("Expected no format validator for ipv4, got %s", f.TypeMeta.FormatPattern)
+	}
+}
+
 func TestCalculateTypeMeta_Int64(t *testing.T) {
 	f := FieldInfo{Type: OpenAPITypeInteger, GoType: TFTypeInt64}
 	CalculateTypeMeta(&f)
@@ -157,6 +195,30 @@ func TestCalculateTypeMeta_Map(t *testing.T) {
 	}
 }
 
+func TestCalculateTypeMeta_MapOfObjects(t *testing.T) {
+	f := FieldInfo{
+		Type:       OpenAPITypeObject,
+		GoType:     TFTypeMap,
+		ItemType:   OpenAPITypeObject,
+		ItemSchema: &FieldInfo{Type: OpenAPITypeObject, GoType: TFTypeObject},
+	}
+	CalculateTypeMeta(&f)
+
+	if f.TypeMeta.SchemaAttrType != "schema.MapNestedAttribute" {
+		t.Errorf("Expected schema.MapNestedAttribute, got %s", f.TypeMeta.SchemaAttrType)
+	}
+	if !f.TypeMeta.IsNested {
+		t.Error("Map of objects should be nested")
+	}
+
+	// Without a value schema the object values are dynamic
+	f.ItemSchema = nil
+	CalculateTypeMeta(&f)
+	if f.TypeMeta.SchemaAttrType != "schema.MapAttribute" || f.TypeMeta.IsNested {
+		t.Errorf("Expected a flat schema.MapAttribute, got %+v", f.TypeMeta)
+	}
+}
+
 func TestCalculateTypeMeta_Object(t *testing.T) {
 	f := FieldInfo{Type: OpenAPITypeObject, GoType: TFTypeObject}
 	CalculateTypeMeta(&f)
@@ -24,15 +24,19 @@ type FieldInfo struct {
 	IsPathParam        bool   // Whether field is a path parameter (should not be in JSON body)
 
 	// Complex type support
-	Enum       []string    // For enums: allowed values (only for string type)
+	Enum       []string    // For enums: allowed string, integer or number values, as written in Go source
 	ItemType   string      // For arrays: type of items ("string", "integer", "object", etc.)
 	ItemSchema *FieldInfo  // For arrays of objects: nested schema
 	Properties []FieldInfo // For nested objects: object properties
 
 	// Validation support
-	Minimum *float64 // Minimum value for numeric fields
-	Maximum *float64 // Maximum value for numeric fields
-	Pattern string   // Regex pattern for string fields
+	Minimum   *float64 // Minimum value for numeric fields
+	Maximum   *float64 // Maximum value for numeric fields
+	Pattern   string   // Regex pattern for string fields
+	MinLength int64    // Minimum length for string fields (0 when unconstrained)
+	MaxLength *int64   // Maximum length for string fields
+	MinItems  int64    // Minimum number of items for array fields (0 when unconstrained)
+	MaxItems  *int64   // Maximum number of items for array fields
 
 	// Type Information
 	SDKType   string   // Pre-calculated Go SDK type string (e.g., "*string", "[]common.Tag")
@@ -40,19 +44,57 @@ type FieldInfo struct {
 	TypeMeta  TypeMeta // Pre-calculated type-specific strings for templates
 
 	// Ref support
-	RefName       string // Ref name for object type
-	ItemRefName   string // Ref name for array item type
-	SchemaSkip    bool   // Whether to skip this field in Terraform schema generation
-	IsDataSource  bool   // Whether this field is part of a Data Source schema
-	AttrTypeRef   string // Reference name for attribute type (helper function name)
-	JsonTag       string // Custom JSON tag (optional)
-	HasDefault    bool   // Whether field has a default value in OpenAPI schema
-	UnknownIfNull bool   // Whether to use UnknownIfNull plan modifier
+	RefName        string // Ref name for object type
+	ItemRefName    string // Ref name for array item type
+	SchemaSkip     bool   // Whether to skip this field in Terraform schema generation
+	IsDataSource   bool   // Whether this field is part of a Data Source schema
+	AttrTypeRef    string // Reference name for attribute type (helper function name)
+	SharedAttrType bool   // Whether the attribute type helper is declared once in the common package
+	JsonTag        string // Custom JSON tag (optional)
+	HasDefault     bool   // Whether field has a default value in OpenAPI schema
+	Default        string // Go literal of the OpenAPI default, used as the schema default of optional attributes
+	UnknownIfNull  bool   // Whether to use UnknownIfNull plan modifier
+	WriteOnly      bool   // Whether the value is only read from config and never persisted to state
+	Nullable       bool   // Whether the schema allows null values
+	Secret         bool   // Whether the schema marks the value writeOnly: sensitive and never read back from the API
+	Deprecated     bool   // Whether the schema marks the property as deprecated
+	URLReference   bool   // Whether the value is the URL of an object the API may return expanded, such as one referring back to an enclosing schema
+	SendNull       bool   // Whether update requests send an explicit null when the attribute is cleared
+	DynamicObject  bool   // Whether the schema is an object with arbitrary properties (free-form or additionalProperties)
+	JSON           bool   // Whether the value is exposed as a normalized JSON string
+	TypeConflict   string // Side kept when the request and response types conflict ("request" or "response"), empty without a resolution
+	Normalize      string // Normalization of a top-level string compared semantically ("url", "case_insensitive" or "whitespace_insensitive"), empty for plain strings
+	Transform      string // Conversion of a top-level value between the attribute and the API (e.g., "mb_to_gb"), empty for none
+	ReferencePath  string // Retrieve path of the objects a top-level URL refers to (e.g., "/api/projects/{uuid}/"), whose UUIDs are resolved to URLs
+	FixedValue     string // Go expression of the only value a top-level request field accepts, sent without an attribute (e.g., `common.Ptr("OpenStack.Instance")`)
+
+	KeepState      bool       // Whether the mapper keeps the value already in state instead of refreshing it
+	KeepStatePaths [][]string // Nested attribute paths whose values are kept from state
+	KeepIfMissing  bool       // Whether the mapper keeps the value already in state when the response lacks the field
+	SetKeys        []string   // Attributes identifying the elements of a set of objects, matched with state to keep their computed attributes
+	ReplaceIf      string     // Condition on the old and new values under which a change replaces the resource (e.g., "new < old")
+
+	Discriminator      string // For discriminated unions: JSON property selecting the variant
+	DiscriminatorValue string // For union variant blocks: discriminator value sent when the block is set
+	OfferingType       string // For attribute blocks of order resources: offering type whose specific attributes the block holds
+}
+
+// StateUpgrader migrates the state written by a prior schema version of a resource to the current one
+type StateUpgrader struct {
+	Version int64         // Prior schema version
+	Changes []StateChange // Changes of each version from Version to the current one, in order
+}
+
+// StateChange describes how the state layout changed from one schema version to the next
+type StateChange struct {
+	Renamed map[string]string // Attribute paths mapped to their new names
+	Moved   map[string]string // Attribute paths mapped to the paths they moved to
 }
 
 // ResourceData holds all data required to generate resource/sdk code
 type ResourceData struct {
 	Name                  string
+	TypeName              string // Terraform type name without the provider prefix
 	Service               string // e.g., "openstack", "marketplace"
 	CleanName             string // e.g., "instance", "order"
 	Plugin                string
@@ -67,35 +109,146 @@ type ResourceData struct {
 	Source                *config.LinkResourceConfig
 	Target                *config.LinkResourceConfig
 	LinkCheckKey          string
+	Members               *config.LinkMembersConfig // Set when a link resource manages all sources of one target
 	OfferingType          string
+	OfferingTypes         []string // Offering types accepted by an order resource with attribute blocks per type
+	OfferingPath          string   // Retrieve path of public offerings, read to check attribute blocks against the offering type
 	UpdateActions         []UpdateAction
 	StandaloneActions     []UpdateAction
+	ActionTriggers        []UpdateAction    // Standalone actions also run during update when their <name>_trigger attribute changes
+	PreflightChecks       []PreflightCheck  // Validation endpoints called while planning
+	NestedOperations      []NestedOperation // Nested lists updated item by item instead of replacing the resource
+	APIErrorAttributes    map[string]string // API field names mapped to the attributes their validation errors are reported on
 	TerminationAttributes []config.ParameterConfig
 	CreateOperation       *config.CreateOperationConfig
 	CompositeKeys         []string
-	NestedStructs         []FieldInfo // Only used for legacy resource generation if needed
+	IDField               string            // Field used to look up the resource on import, empty for UUID
+	VirtualFields         []VirtualField    // Computed attributes derived from the API response
+	LifecycleFields       []LifecycleField  // Server metadata mapped into the lifecycle_meta attribute
+	ConfigValidators      []ConfigValidator // Cross-attribute validators rendered as ConfigValidators
+	ProviderDefaults      []ProviderDefault // Required create fields filled in with provider defaults when omitted
+	NestedStructs         []FieldInfo       // Only used for legacy resource generation if needed
 	FilterParams          []FilterParam
-	BaseOperationID       string // Base operation ID for actions
-	HasDataSource         bool   // True if a corresponding data source exists
-	SkipPolling           bool   // True if resource does not need polling (e.g. Structure Project)
+	Pagination            *Pagination     // How the list operation pages through results, nil when it is not paginated
+	Ordering              *Ordering       // How the list operation orders results, nil when it cannot
+	CreateUpload          *Upload         // How the create request uploads files, nil when it is sent as JSON
+	CreateFollowsLocation bool            // Create response may only carry the new object URL in its Location header
+	CreateAsync           bool            // Create answers 202 Accepted with a task to wait for
+	CreateAsyncOrder      bool            // The task of an asynchronous create is a marketplace order, otherwise the object itself
+	UpdateUpload          *Upload         // How the update request uploads files, nil when it is sent as JSON
+	ErrorResponses        []ErrorResponse // Typed client error responses of the create, update and delete operations
+	BaseOperationID       string          // Base operation ID for actions
+	HasDataSource         bool            // True if a corresponding data source exists
+	DataSourceNames       []string        // Data sources generated from this entity's SDK
+	EphemeralNames        []string        // Ephemeral resources generated from this entity's SDK
+	ListDataSourceNames   []string        // Data sources returning all matching objects of this entity
+	ProviderName          string          // Provider name used to build full type names
+	Aliases               []string        // Previous type names registered for the same implementation
+	SchemaVersion         int64           // Version of the resource schema
+	StateUpgraders        []StateUpgrader // Migrations of the state written by each prior schema version
+	SkipPolling           bool            // True if resource does not need polling (e.g. Structure Project)
+	DeletionProtection    bool            // Adds a deletion_protection attribute checked before Delete
+	WaitFor               bool            // Adds a wait_for attribute controlling the wait for creation to complete
+	Polling               *PollingOptions // Custom polling behavior, nil for defaults
+	CreateTimeout         string          // Go expression for the default create timeout
+	UpdateTimeout         string          // Go expression for the default update timeout
+	DeleteTimeout         string          // Go expression for the default delete timeout
+	DeleteParams          []BodyParam     // Request body of a custom POST delete operation, sorted by key
+	Steps                 []ResourceStep  // Additional API calls executed after creation, in order
+	RollbackSteps         []ResourceStep  // Steps in reverse order, for rollback and deletion
+	DeprecationMessage    string          // Set when the resource is deprecated
 	TemplateFiles         []string
 }
 
+// Pagination describes the page-based pagination of a list operation
+type Pagination struct {
+	PageParam     string // Query parameter selecting the page
+	PageSizeParam string // Query parameter setting the page size, empty if the operation has none
+	CountHeader   string // Response header holding the total number of results, empty if not declared
+}
+
+// Ordering describes the "o" query parameter ordering the results of a list operation
+type Ordering struct {
+	Values  []string // Accepted orderings, prefixed with "-" for descending order, empty if not declared
+	Explode bool     // Several orderings are sent as repeated parameters instead of one comma-separated value
+}
+
+// Upload describes a request sent as multipart/form-data
+type Upload struct {
+	Files    []string // Fields uploaded as file parts, sorted
+	FormOnly bool     // Whether the operation only accepts multipart bodies, so JSON is never sent
+}
+
+// ErrorResponse is a client error response documented with a schema
+type ErrorResponse struct {
+	Operation  string      // Resource operation answering with the error: "Create", "Update" or "Delete"
+	StatusCode int         // HTTP status code, e.g. 400
+	Fields     []FieldInfo // Properties of the error schema, sorted by name
+}
+
 // UpdateAction represents an enriched update action with resolved API path
 type UpdateAction struct {
-	Name       string // Action name (e.g., "update_limits")
-	Operation  string // OpenAPI operation ID
-	Param      string // Parameter name for payload
-	CompareKey string // Field to compare for changes
-	Path       string // Resolved API path from OpenAPI
+	Name       string      // Action name (e.g., "update_limits")
+	Operation  string      // OpenAPI operation ID
+	Param      string      // Parameter name for payload
+	CompareKey string      // Field to compare for changes
+	Path       string      // Resolved API path from OpenAPI
+	Params     []FieldInfo // Scalar request body fields of a standalone action
+}
+
+// ResourceStep represents a resolved creation step
+type ResourceStep struct {
+	Index          int         // Position of the step in the creation sequence
+	Name           string      // Step name
+	Path           string      // Resolved API path of the step action
+	ParentAttr     string      // Model attribute (title case) holding the {uuid} path parameter
+	Params         []BodyParam // Request body parameters, sorted by key
+	UUIDAttr       string      // Model attribute (title case) storing the created object's UUID
+	RollbackPath   string      // Resolved API path of the rollback operation, empty if the step cannot be undone
+	RollbackMethod string      // HTTP method of the rollback operation
+}
+
+// PreflightCheck is a validation endpoint called with the planned values of a resource
+type PreflightCheck struct {
+	Operation      string      // OpenAPI operation ID of the check
+	Method         string      // HTTP method, GET or POST
+	Path           string      // Resolved API path of the check
+	ParentAttr     string      // Model attribute (title case) holding the {uuid} path parameter, empty without one
+	Params         []BodyParam // Query parameters of GET checks, or the request body of POST checks, sorted by key
+	Attrs          []string    // Model attributes (title case) the check depends on, sorted
+	SuccessField   string      // Boolean response field that is false when the check fails, empty if only rejected requests fail
+	Message        string      // Reported when the success field is false
+	ErrorAttribute string      // Attribute the failure is reported on, empty when the check depends on several
+	OnUpdate       bool        // Also check updates that change Attrs
+}
+
+// NestedOperation updates a nested list one item at a time through the add and remove actions of its parent
+type NestedOperation struct {
+	Name           string            // Attribute name of the nested list
+	Attr           string            // Model attribute (title case)
+	AddPath        string            // Resolved API path of the action adding an item
+	RemovePath     string            // Resolved API path of the action removing an item
+	AddParams      map[string]string // Request body keys of add mapped to item attributes; items are compared by these
+	RemoveParams   map[string]string // Request body keys of remove mapped to item attributes
+	Normalizations map[string]string // Add request keys mapped to the normalizations their values are compared with (e.g., "common.URLNormalization")
+	InCreate       bool              // Whether the create request sends the items, so they are not added after creation
+}
+
+// BodyParam maps a request body key to a model attribute
+type BodyParam struct {
+	Key         string // Request body key
+	Attr        string // Model attribute (title case)
+	ValueMethod string // Accessor on the attribute value (e.g., "ValueString")
 }
 
 // FilterParam describes a query parameter for filtering
 type FilterParam struct {
 	Name        string
-	Type        string // String, Int64, Bool, Float64
+	Type        string // String, Int64, Bool, Float64 or List
+	ItemType    string // Element type of List filters: String, Int64, Bool or Float64
+	Explode     bool   // Whether List values are sent as repeated parameters instead of one comma-separated value
 	Description string
-	Enum        []string // Allowed values for enum filters
+	Enum        []string // Allowed values for enum filters, or for the elements of List filters
 }
 
 // Clone creates a deep copy of FilterParam
@@ -127,3 +280,29 @@ func (f FieldInfo) Clone() FieldInfo {
 	}
 	return clone
 }
+
+// IsObjectMap reports whether the field is a map whose values are objects with known properties
+// (e.g., quotas: {<name>: {limit, usage}}), described by ItemSchema
+func (f FieldInfo) IsObjectMap() bool {
+	return f.GoType == TFTypeMap && f.ItemType == OpenAPITypeObject && f.ItemSchema != nil
+}
+
+// VirtualField is a computed attribute derived from a path into the API response
+type VirtualField struct {
+	FieldInfo
+	Steps []VirtualStep // Guarded accessors leading to the value, outermost first
+	Value string        // Go expression converting the innermost accessor into a Terraform value
+}
+
+// VirtualStep is a single guarded accessor of a virtual field expression
+type VirtualStep struct {
+	Var  string // Variable holding the accessor result (e.g., "v0")
+	Expr string // Go expression being accessed (e.g., "apiResp.Ports")
+	Cond string // Condition that must hold before going deeper (e.g., "v0 != nil && len(*v0) > 0")
+}
+
+// ConfigValidator is a resourcevalidator call over a group of root attributes
+type ConfigValidator struct {
+	Func  string   // resourcevalidator function (e.g., "Conflicting", "ExactlyOneOf")
+	Attrs []string // Attribute names passed as path.MatchRoot expressions
+}
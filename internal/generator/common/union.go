@@ -0,0 +1,313 @@
+package common
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+)
+
+// unionModel describes how a oneOf/anyOf union of objects is exposed in the schema
+type unionModel struct {
+	Schema        *openapi3.SchemaRef // Object schema replacing the union
+	Discriminator string              // Discriminator property (discriminator strategy only)
+	Values        map[string]string   // Variant block names mapped to their discriminator values
+}
+
+// UnionStrategy returns the strategy used for the union at path. Rules for the dotted path
+// take precedence over rules for the plain field name, which take precedence over the global default.
+// Without any rule, unions declaring an OpenAPI discriminator use the discriminator strategy.
+func UnionStrategy(cfg SchemaConfig, path, name string, schema *openapi3.Schema) string {
+	for _, key := range []string{path, name} {
+		if override, ok := cfg.FieldOverrides[key]; ok && override.Union != "" {
+			return override.Union
+		}
+	}
+	if cfg.UnionStrategy != "" {
+		return cfg.UnionStrategy
+	}
+	if schema != nil && schema.Discriminator != nil && schema.Discriminator.PropertyName != "" {
+		return config.UnionDiscriminator
+	}
+	return config.UnionFirst
+}
+
+// objectVariants returns the oneOf/anyOf variants of a schema when every variant is an object
+func objectVariants(schema *openapi3.Schema) openapi3.SchemaRefs {
+	variants := schema.OneOf
+	if len(variants) == 0 {
+		variants = schema.AnyOf
+	}
+	if len(variants) == 0 {
+		return nil
+	}
+	for _, v := range variants {
+		if v == nil || v.Value == nil || GetSchemaType(v.Value) != OpenAPITypeObject || len(schemaProperties(v.Value)) == 0 {
+			return nil
+		}
+	}
+	return variants
+}
+
+// schemaProperties returns the properties of a schema, including those inherited through allOf
+func schemaProperties(schema *openapi3.Schema) openapi3.Schemas {
+	props := make(openapi3.Schemas)
+	for _, sub := range schema.AllOf {
+		if sub.Value != nil {
+			for name, prop := range schemaProperties(sub.Value) {
+				props[name] = prop
+			}
+		}
+	}
+	for name, prop := range schema.Properties {
+		props[name] = prop
+	}
+	return props
+}
+
+// schemaRequired returns the required properties of a schema, including those inherited through allOf
+func schemaRequired(schema *openapi3.Schema) []string {
+	required := append([]string{}, schema.Required...)
+	for _, sub := range schema.AllOf {
+		if sub.Value != nil {
+			required = append(required, schemaRequired(sub.Value)...)
+		}
+	}
+	return required
+}
+
+// resolveUnion returns the object schema used in place of a union, or nil when the first variant is used
+func resolveUnion(cfg SchemaConfig, path, name string, schema *openapi3.Schema) (*unionModel, error) {
+	variants := objectVariants(schema)
+	if variants == nil {
+		return nil, nil
+	}
+	switch UnionStrategy(cfg, path, name, schema) {
+	case config.UnionMerge:
+		return mergeUnion(schema, variants), nil
+	case config.UnionDiscriminator:
+		if schema.Discriminator == nil || schema.Discriminator.PropertyName == "" {
+			// Nothing selects the variant, so fall back to one object holding every field
+			return mergeUnion(schema, variants), nil
+		}
+		return discriminatedUnion(path, schema, variants)
+	}
+	return nil, nil
+}
+
+// apply records the discriminator of a union on the extracted object field. It is a no-op for nil unions.
+func (u *unionModel) apply(field *FieldInfo) {
+	if u == nil || u.Discriminator == "" {
+		return
+	}
+	field.Discriminator = u.Discriminator
+	for i := range field.Properties {
+		field.Properties[i].DiscriminatorValue = u.Values[field.Properties[i].Name]
+	}
+}
+
+// mergeUnion builds a single object with the fields of every variant, all optional.
+// When variants define the same field, the first definition wins.
+func mergeUnion(schema *openapi3.Schema, variants openapi3.SchemaRefs) *unionModel {
+	merged := &openapi3.Schema{
+		Type:        &openapi3.Types{OpenAPITypeObject},
+		Description: schema.Description,
+		Properties:  make(openapi3.Schemas),
+	}
+	for _, v := range variants {
+		for name, prop := range schemaProperties(v.Value) {
+			if _, exists := merged.Properties[name]; !exists {
+				merged.Properties[name] = prop
+			}
+		}
+	}
+	return &unionModel{Schema: &openapi3.SchemaRef{Value: merged}}
+}
+
+// discriminatedUnion builds an object with one optional nested block per variant.
+// Blocks are named after the discriminator value, which is sent with the fields of the block that is set.
+func discriminatedUnion(path string, schema *openapi3.Schema, variants openapi3.SchemaRefs) (*unionModel, error) {
+	disc := schema.Discriminator
+	model := &unionModel{
+		Discriminator: disc.PropertyName,
+		Values:        make(map[string]string),
+	}
+	blocks := &openapi3.Schema{
+		Type:        &openapi3.Types{OpenAPITypeObject},
+		Description: schema.Description,
+		Properties:  make(openapi3.Schemas),
+	}
+
+	for i, v := range variants {
+		value := discriminatorValue(disc, v)
+		if value == "" {
+			return nil, fmt.Errorf("union %s: variant %d has no discriminator value", path, i+1)
+		}
+		block := unionBlockName(value)
+		if _, exists := model.Values[block]; exists {
+			return nil, fmt.Errorf("union %s: discriminator values map to the same block %s", path, block)
+		}
+		model.Values[block] = value
+
+		variant := &openapi3.Schema{
+			Type:        &openapi3.Types{OpenAPITypeObject},
+			Description: v.Value.Description,
+			Properties:  make(openapi3.Schemas),
+		}
+		if variant.Description == "" {
+			variant.Description = fmt.Sprintf("Fields used when %s is %s", disc.PropertyName, value)
+		}
+		for name, prop := range schemaProperties(v.Value) {
+			if name != disc.PropertyName {
+				variant.Properties[name] = prop
+			}
+		}
+		for _, name := range schemaRequired(v.Value) {
+			if name != disc.PropertyName {
+				variant.Required = append(variant.Required, name)
+			}
+		}
+		sort.Strings(variant.Required)
+		blocks.Properties[block] = &openapi3.SchemaRef{Value: variant}
+	}
+
+	model.Schema = &openapi3.SchemaRef{Value: blocks}
+	return model, nil
+}
+
+// discriminatorValue returns the discriminator value of a variant: its key in the discriminator mapping,
+// the single value its discriminator property allows, or else the name of the schema it references
+func discriminatorValue(disc *openapi3.Discriminator, variant *openapi3.SchemaRef) string {
+	if variant.Ref != "" {
+		keys := make([]string, 0, len(disc.Mapping))
+		for key := range disc.Mapping {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if target := disc.Mapping[key]; target == variant.Ref || strings.HasSuffix(variant.Ref, "/"+target) {
+				return key
+			}
+		}
+	}
+	if prop, ok := schemaProperties(variant.Value)[disc.PropertyName]; ok && prop.Value != nil && len(prop.Value.Enum) == 1 {
+		if value, ok := prop.Value.Enum[0].(string); ok {
+			return value
+		}
+	}
+	if variant.Ref == "" {
+		return ""
+	}
+	parts := strings.Split(variant.Ref, "/")
+	return parts[len(parts)-1]
+}
+
+// unionBlockName converts a discriminator value into an attribute name
+func unionBlockName(value string) string {
+	var b strings.Builder
+	for i, r := range value {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			if i > 0 && !strings.HasSuffix(b.String(), "_") {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r + ('a' - 'A'))
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+				b.WriteByte('_')
+			}
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+// OfferingTypeSchema combines the order attributes of several offering types into one object. Fields that every
+// type declares alike stay top-level, the others are grouped into one optional block per type. It returns the
+// combined schema and the block names mapped to their offering types; types without specific fields get no block.
+func OfferingTypeSchema(types []string, variants openapi3.SchemaRefs) (*openapi3.SchemaRef, map[string]string, error) {
+	combined := &openapi3.Schema{
+		Type:       &openapi3.Types{OpenAPITypeObject},
+		Properties: make(openapi3.Schemas),
+	}
+	props := make([]openapi3.Schemas, len(variants))
+	for i, v := range variants {
+		props[i] = schemaProperties(v.Value)
+	}
+
+	shared := make(map[string]bool)
+	for name, prop := range props[0] {
+		alike := true
+		for _, other := range props[1:] {
+			if !sameFieldSchema(prop, other[name]) {
+				alike = false
+				break
+			}
+		}
+		if !alike {
+			continue
+		}
+		shared[name] = true
+		combined.Properties[name] = prop
+		required := true
+		for _, v := range variants {
+			required = required && slices.Contains(schemaRequired(v.Value), name)
+		}
+		if required {
+			combined.Required = append(combined.Required, name)
+		}
+	}
+	sort.Strings(combined.Required)
+
+	blocks := make(map[string]string)
+	for i, v := range variants {
+		block := &openapi3.Schema{
+			Type:        &openapi3.Types{OpenAPITypeObject},
+			Description: fmt.Sprintf("Attributes of %s offerings", types[i]),
+			Properties:  make(openapi3.Schemas),
+		}
+		for name, prop := range props[i] {
+			if !shared[name] {
+				block.Properties[name] = prop
+			}
+		}
+		if len(block.Properties) == 0 {
+			continue
+		}
+		for _, name := range schemaRequired(v.Value) {
+			if block.Properties[name] != nil {
+				block.Required = append(block.Required, name)
+			}
+		}
+		sort.Strings(block.Required)
+
+		name := unionBlockName(types[i])
+		if _, exists := combined.Properties[name]; exists {
+			return nil, nil, fmt.Errorf("offering type %s: block %s clashes with another attribute", types[i], name)
+		}
+		combined.Properties[name] = &openapi3.SchemaRef{Value: block}
+		blocks[name] = types[i]
+	}
+	return &openapi3.SchemaRef{Value: combined}, blocks, nil
+}
+
+// sameFieldSchema reports whether two declarations of a field describe the same value
+func sameFieldSchema(a, b *openapi3.SchemaRef) bool {
+	if a == nil || b == nil || a.Value == nil || b.Value == nil {
+		return false
+	}
+	if a.Ref != "" || b.Ref != "" {
+		return a.Ref == b.Ref
+	}
+	if GetSchemaType(a.Value) != GetSchemaType(b.Value) || a.Value.Format != b.Value.Format {
+		return false
+	}
+	if a.Value.Items != nil || b.Value.Items != nil {
+		return sameFieldSchema(a.Value.Items, b.Value.Items)
+	}
+	return GetSchemaType(a.Value) != OpenAPITypeObject
+}
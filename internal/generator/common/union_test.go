@@ -0,0 +1,248 @@
+package common
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+)
+
+func TestUnionStrategy(t *testing.T) {
+	cfg := SchemaConfig{
+		UnionStrategy: config.UnionMerge,
+		FieldOverrides: map[string]config.FieldConfig{
+			"attributes":      {Union: config.UnionDiscriminator},
+			"plan.attributes": {Union: config.UnionFirst},
+			"options":         {Computed: true},
+		},
+	}
+
+	tests := []struct {
+		path string
+		name string
+		want string
+	}{
+		{"attributes", "attributes", config.UnionDiscriminator},
+		{"plan.attributes", "attributes", config.UnionFirst},
+		{"options", "options", config.UnionMerge},
+	}
+	for _, tt := range tests {
+		if got := UnionStrategy(cfg, tt.path, tt.name, nil); got != tt.want {
+			t.Errorf("UnionStrategy(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	if got := UnionStrategy(SchemaConfig{}, "attributes", "attributes", &openapi3.Schema{}); got != config.UnionFirst {
+		t.Errorf("default UnionStrategy = %q, want %q", got, config.UnionFirst)
+	}
+	discriminated := &openapi3.Schema{Discriminator: &openapi3.Discriminator{PropertyName: "type"}}
+	if got := UnionStrategy(SchemaConfig{}, "attributes", "attributes", discriminated); got != config.UnionDiscriminator {
+		t.Errorf("default UnionStrategy with discriminator = %q, want %q", got, config.UnionDiscriminator)
+	}
+}
+
+func TestUnionBlockName(t *testing.T) {
+	tests := map[string]string{
+		"cat":               "cat",
+		"HotDog":            "hot_dog",
+		"Marketplace.Slurm": "marketplace_slurm",
+		"OpenStack-Tenant":  "open_stack_tenant",
+	}
+	for value, want := range tests {
+		if got := unionBlockName(value); got != want {
+			t.Errorf("unionBlockName(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+// petSchema returns an object with a "kind" property that is a oneOf of two objects
+func petSchema(discriminator *openapi3.Discriminator) *openapi3.SchemaRef {
+	cat := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/Cat",
+		Value: &openapi3.Schema{
+			Type:     &openapi3.Types{"object"},
+			Required: []string{"pet_type", "lives"},
+			Properties: openapi3.Schemas{
+				"pet_type": {Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+				"lives":    {Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}}},
+			},
+		},
+	}
+	dog := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/Dog",
+		Value: &openapi3.Schema{
+			Type: &openapi3.Types{"object"},
+			Properties: openapi3.Schemas{
+				"pet_type": {Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+				"good_boy": {Value: &openapi3.Schema{Type: &openapi3.Types{"boolean"}}},
+			},
+		},
+	}
+	return &openapi3.SchemaRef{
+		Value: &openapi3.Schema{
+			Type: &openapi3.Types{"object"},
+			Properties: openapi3.Schemas{
+				"kind": {Value: &openapi3.Schema{
+					OneOf:         openapi3.SchemaRefs{cat, dog},
+					Discriminator: discriminator,
+				}},
+			},
+		},
+	}
+}
+
+func TestExtractFields_UnionFirst(t *testing.T) {
+	fields, err := ExtractFields(SchemaConfig{}, petSchema(nil), false)
+	if err != nil {
+		t.Fatalf("ExtractFields failed: %v", err)
+	}
+	// The union itself declares no properties, so with the first variant it stays a generic map
+	if len(fields) != 1 || fields[0].GoType != TFTypeMap {
+		t.Fatalf("expected kind to be a map, got %+v", fields)
+	}
+}
+
+func TestExtractFields_UnionMerge(t *testing.T) {
+	cfg := SchemaConfig{UnionStrategy: config.UnionMerge}
+	fields, err := ExtractFields(cfg, petSchema(nil), false)
+	if err != nil {
+		t.Fatalf("ExtractFields failed: %v", err)
+	}
+	if len(fields) != 1 || fields[0].GoType != TFTypeObject {
+		t.Fatalf("expected kind to be an object, got %+v", fields)
+	}
+
+	names := []string{}
+	for _, p := range fields[0].Properties {
+		names = append(names, p.Name)
+		if p.Required {
+			t.Errorf("merged field %s should be optional", p.Name)
+		}
+	}
+	if got := len(names); got != 3 {
+		t.Errorf("expected 3 merged fields, got %v", names)
+	}
+	if fields[0].Discriminator != "" {
+		t.Errorf("merged union should have no discriminator, got %s", fields[0].Discriminator)
+	}
+}
+
+func TestExtractFields_UnionDiscriminator(t *testing.T) {
+	// A declared discriminator is honored without configuration
+	cfg := SchemaConfig{}
+	disc := &openapi3.Discriminator{
+		PropertyName: "pet_type",
+		Mapping:      openapi3.StringMap{"cat": "#/components/schemas/Cat"},
+	}
+	fields, err := ExtractFields(cfg, petSchema(disc), false)
+	if err != nil {
+		t.Fatalf("ExtractFields failed: %v", err)
+	}
+	if len(fields) != 1 {
+		t.Fatalf("expected 1 field, got %d", len(fields))
+	}
+	kind := fields[0]
+	if kind.Discriminator != "pet_type" {
+		t.Errorf("expected discriminator pet_type, got %q", kind.Discriminator)
+	}
+
+	want := map[string]string{"cat": "cat", "dog": "Dog"} // Mapping key, then schema name
+	if len(kind.Properties) != len(want) {
+		t.Fatalf("expected %d variant blocks, got %+v", len(want), kind.Properties)
+	}
+	for _, block := range kind.Properties {
+		if block.DiscriminatorValue != want[block.Name] {
+			t.Errorf("block %s: discriminator value %q, want %q", block.Name, block.DiscriminatorValue, want[block.Name])
+		}
+		if block.Required {
+			t.Errorf("block %s should be optional", block.Name)
+		}
+		for _, p := range block.Properties {
+			if p.Name == "pet_type" {
+				t.Errorf("block %s should not expose the discriminator property", block.Name)
+			}
+			if p.Name == "lives" && !p.Required {
+				t.Errorf("block %s: lives should stay required", block.Name)
+			}
+		}
+	}
+}
+
+func TestDiscriminatorValue(t *testing.T) {
+	disc := &openapi3.Discriminator{
+		PropertyName: "type",
+		Mapping:      openapi3.StringMap{"slurm": "SlurmAttributes"},
+	}
+	typed := func(values ...any) openapi3.Schemas {
+		return openapi3.Schemas{"type": {Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Enum: values}}}
+	}
+
+	tests := []struct {
+		name    string
+		variant *openapi3.SchemaRef
+		want    string
+	}{
+		{"mapping by schema name", &openapi3.SchemaRef{Ref: "#/components/schemas/SlurmAttributes", Value: &openapi3.Schema{}}, "slurm"},
+		{"single enum value", &openapi3.SchemaRef{Value: &openapi3.Schema{Properties: typed("OpenStack.Tenant")}}, "OpenStack.Tenant"},
+		{"schema name", &openapi3.SchemaRef{Ref: "#/components/schemas/RancherAttributes", Value: &openapi3.Schema{Properties: typed("a", "b")}}, "RancherAttributes"},
+		{"inline without value", &openapi3.SchemaRef{Value: &openapi3.Schema{}}, ""},
+	}
+	for _, tt := range tests {
+		if got := discriminatorValue(disc, tt.variant); got != tt.want {
+			t.Errorf("%s: discriminatorValue() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOfferingTypeSchema(t *testing.T) {
+	str := func() *openapi3.SchemaRef {
+		return &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	}
+	num := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}}}
+	instance := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type:       &openapi3.Types{"object"},
+		Required:   []string{"name", "flavor"},
+		Properties: openapi3.Schemas{"name": str(), "description": str(), "flavor": str(), "size": str()},
+	}}
+	volume := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type:       &openapi3.Types{"object"},
+		Required:   []string{"name"},
+		Properties: openapi3.Schemas{"name": str(), "description": str(), "size": num},
+	}}
+	tenant := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type:       &openapi3.Types{"object"},
+		Required:   []string{"name"},
+		Properties: openapi3.Schemas{"name": str(), "description": str()},
+	}}
+
+	schema, blocks, err := OfferingTypeSchema(
+		[]string{"OpenStack.Instance", "OpenStack.Volume", "OpenStack.Tenant"},
+		openapi3.SchemaRefs{instance, volume, tenant},
+	)
+	if err != nil {
+		t.Fatalf("OfferingTypeSchema failed: %v", err)
+	}
+	wantBlocks := map[string]string{"open_stack_instance": "OpenStack.Instance", "open_stack_volume": "OpenStack.Volume"}
+	if !reflect.DeepEqual(blocks, wantBlocks) {
+		t.Errorf("blocks = %v, want %v", blocks, wantBlocks)
+	}
+
+	props := schema.Value.Properties
+	if props["name"] == nil || props["description"] == nil || props["size"] != nil || props["flavor"] != nil {
+		t.Errorf("expected only name and description to be shared, got %v", slices.Sorted(maps.Keys(props)))
+	}
+	if !reflect.DeepEqual(schema.Value.Required, []string{"name"}) {
+		t.Errorf("shared required = %v, want [name]", schema.Value.Required)
+	}
+	// Fields declared with different types stay in the block of each type
+	block := props["open_stack_instance"].Value
+	if block.Properties["flavor"] == nil || block.Properties["size"] == nil || !reflect.DeepEqual(block.Required, []string{"flavor"}) {
+		t.Errorf("unexpected open_stack_instance block: %+v", block)
+	}
+	if props["open_stack_volume"].Value.Properties["size"] != num {
+		t.Errorf("open_stack_volume block should hold its own size")
+	}
+}
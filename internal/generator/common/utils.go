@@ -1,6 +1,12 @@
 package common
 
-import "strings"
+import (
+	"go/token"
+	"strings"
+	"unicode"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+)
 
 // SanitizeString replaces problematic characters in descriptions
 func SanitizeString(s string) string {
@@ -17,6 +23,12 @@ func SanitizeString(s string) string {
 	return strings.TrimSpace(s)
 }
 
+// MarkdownTableCell escapes text for a cell of a markdown table, which ends at a pipe or a line break
+func MarkdownTableCell(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
 // SplitResourceName splits a resource name into service and clean name
 func SplitResourceName(name string) (string, string) {
 	parts := strings.SplitN(name, "_", 2)
@@ -26,16 +38,56 @@ func SplitResourceName(name string) (string, string) {
 	return "core", name // Fallback to core
 }
 
-// ToTitle converts a string to title case for use in templates
+// ResolveResourceName splits a resource name using the configured service prefixes,
+// falling back to SplitResourceName when none matches. Both names are valid Go package names.
+func ResolveResourceName(naming config.NamingConfig, name string) (string, string) {
+	best := ""
+	for prefix := range naming.Services {
+		if strings.HasPrefix(name, prefix+"_") && len(prefix) > len(best) {
+			best = prefix
+		}
+	}
+	service, cleanName := naming.Services[best], strings.TrimPrefix(name, best+"_")
+	if best == "" {
+		service, cleanName = SplitResourceName(name)
+	}
+	return goPackageName(service), goPackageName(cleanName)
+}
+
+// initialisms are name segments written in upper case in Go identifiers
+var initialisms = map[string]string{
+	"id": "ID", "ids": "IDs",
+	"ip": "IP", "ips": "IPs",
+	"url": "URL", "urls": "URLs",
+	"uuid": "UUID", "uuids": "UUIDs",
+}
+
+// ToTitle converts a snake_case name to the TitleCase Go identifier used in templates,
+// e.g. "floating_ip_uuid" -> "FloatingIPUUID".
+// Characters that cannot appear in identifiers separate words.
 func ToTitle(s string) string {
-	// Convert snake_case to TitleCase
-	parts := strings.Split(s, "_")
-	for i, part := range parts {
-		if len(part) > 0 {
-			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+	var b strings.Builder
+	words := strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	for _, word := range words {
+		if initialism, ok := initialisms[word]; ok {
+			b.WriteString(initialism)
+		} else {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
 		}
 	}
-	return strings.Join(parts, "")
+	name := b.String()
+	if name != "" && unicode.IsDigit(rune(name[0])) {
+		name = "X" + name
+	}
+	return name
+}
+
+// goPackageName returns a name usable as a Go package name, suffixing Go keywords (e.g. "type")
+func goPackageName(name string) string {
+	if token.IsKeyword(name) {
+		return name + "_"
+	}
+	return name
 }
 
 // Humanize converts snake_case to Title Case with spaces
@@ -57,3 +109,15 @@ func DisplayName(s string) string {
 	}
 	return ToTitle(name)
 }
+
+// AppendSentence appends a sentence to a description, ending the description with a period first
+func AppendSentence(description, sentence string) string {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return sentence
+	}
+	if !strings.HasSuffix(description, ".") {
+		description += "."
+	}
+	return description + " " + sentence
+}
@@ -1,6 +1,10 @@
 package common
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+)
 
 func TestSanitizeString(t *testing.T) {
 	tests := []struct {
@@ -25,6 +29,25 @@ func TestSanitizeString(t *testing.T) {
 	}
 }
 
+func TestMarkdownTableCell(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"simple string", "simple string"},
+		{"either a | b", "either a \\| b"},
+		{"line\nbreaks", "line breaks"},
+		{"`quoted` \"text\"", "`quoted` \"text\""},
+	}
+
+	for _, tt := range tests {
+		result := MarkdownTableCell(tt.input)
+		if result != tt.expected {
+			t.Errorf("MarkdownTableCell(%q) = %q, expected %q", tt.input, result, tt.expected)
+		}
+	}
+}
+
 func TestSplitResourceName(t *testing.T) {
 	tests := []struct {
 		input        string
@@ -45,6 +68,33 @@ func TestSplitResourceName(t *testing.T) {
 	}
 }
 
+func TestResolveResourceName(t *testing.T) {
+	naming := config.NamingConfig{
+		Services: map[string]string{
+			"marketplace":        "marketplace",
+			"marketplace_public": "offerings",
+		},
+	}
+	tests := []struct {
+		input        string
+		expectedServ string
+		expectedName string
+	}{
+		{"marketplace_public_offering", "offerings", "offering"},
+		{"marketplace_order", "marketplace", "order"},
+		{"marketplace_publication", "marketplace", "publication"},
+		{"openstack_instance", "openstack", "instance"},
+		{"single", "core", "single"},
+	}
+
+	for _, tt := range tests {
+		serv, name := ResolveResourceName(naming, tt.input)
+		if serv != tt.expectedServ || name != tt.expectedName {
+			t.Errorf("ResolveResourceName(%q) = (%q, %q), expected (%q, %q)", tt.input, serv, name, tt.expectedServ, tt.expectedName)
+		}
+	}
+}
+
 func TestToTitle(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -52,7 +102,17 @@ func TestToTitle(t *testing.T) {
 	}{
 		{"snake_case", "SnakeCase"},
 		{"multiple_word_snake_case", "MultipleWordSnakeCase"},
+		{"floating_ip_uuid", "FloatingIPUUID"},
+		{"access_url", "AccessURL"},
+		{"backend_id", "BackendID"},
+		{"subnet_ids", "SubnetIDs"},
+		{"identity", "Identity"},
 		{"alreadyTitle", "AlreadyTitle"},
+		{"ip_address", "IPAddress"},
+		{"x-forwarded-for", "XForwardedFor"},
+		{"2fa_enabled", "X2faEnabled"},
+		{"cores__gte", "CoresGte"},
+		{"type", "Type"},
 		{"", ""},
 	}
 
@@ -64,6 +124,13 @@ func TestToTitle(t *testing.T) {
 	}
 }
 
+func TestResolveResourceName_Keywords(t *testing.T) {
+	service, cleanName := ResolveResourceName(config.NamingConfig{}, "marketplace_type")
+	if service != "marketplace" || cleanName != "type_" {
+		t.Errorf("ResolveResourceName() = %q, %q, want a valid package name", service, cleanName)
+	}
+}
+
 func TestHumanize(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -101,3 +168,16 @@ func TestDisplayName(t *testing.T) {
 		}
 	}
 }
+
+func TestAppendSentence(t *testing.T) {
+	tests := []struct{ description, want string }{
+		{"Size in MiB", "Size in MiB. Set in GB."},
+		{"Size in MiB. ", "Size in MiB. Set in GB."},
+		{"", "Set in GB."},
+	}
+	for _, tt := range tests {
+		if got := AppendSentence(tt.description, "Set in GB."); got != tt.want {
+			t.Errorf("AppendSentence(%q) = %q, want %q", tt.description, got, tt.want)
+		}
+	}
+}
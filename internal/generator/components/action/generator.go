@@ -20,6 +20,7 @@ func GenerateImplementation(cfg *config.Config, renderer common.Renderer, rd *co
 
 		data := ActionTemplateData{
 			ResourceName:    rd.Name,
+			TypeName:        rd.TypeName,
 			Service:         rd.Service,
 			CleanName:       rd.CleanName,
 			ActionName:      action.Name,
@@ -30,6 +31,8 @@ func GenerateImplementation(cfg *config.Config, renderer common.Renderer, rd *co
 			Path:            action.Path,
 			IdentifierParam: "uuid",
 			IdentifierDesc:  "UUID of the resource",
+			Polling:         rd.Polling,
+			Params:          action.Params,
 		}
 
 		if err := renderer.RenderTemplate(
@@ -1,8 +1,11 @@
 package action
 
+import "github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+
 // ActionTemplateData holds data for generating resource action files
 type ActionTemplateData struct {
 	ResourceName    string
+	TypeName        string // Terraform type name of the resource
 	Service         string
 	CleanName       string
 	ActionName      string
@@ -14,4 +17,6 @@ type ActionTemplateData struct {
 	ProviderName    string
 	Path            string
 	Method          string
+	Polling         *common.PollingOptions
+	Params          []common.FieldInfo // Scalar request body fields, exposed as optional or required attributes
 }
@@ -1,11 +1,13 @@
 package datasource
 
 import (
+	"fmt"
 	"path/filepath"
 	"sort"
 
 	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
 	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/plugins"
 	"github.com/waldur/terraform-provider-waldur-generator/internal/openapi"
 )
 
@@ -46,6 +48,61 @@ func setIsDataSourceRecursive(fields []common.FieldInfo) {
 
 // GenerateImplementation generates a data source file
 func GenerateImplementation(cfg *config.Config, renderer common.Renderer, rd *common.ResourceData, dataSource *config.DataSource) error {
+	data := templateData(cfg, rd, dataSource)
+	fileName := "datasource.go"
+	if dataSource != nil && dataSource.Name != rd.Name {
+		// Data sources referencing another resource live alongside it
+		fileName = dataSource.Name + "_datasource.go"
+	}
+
+	return renderer.RenderTemplate(
+		"datasource.go.tmpl",
+		[]string{"templates/shared/*.tmpl", "components/datasource/lookup.tmpl", "components/datasource/datasource.go.tmpl"},
+		data,
+		filepath.Join(cfg.Generator.OutputDir, "services", rd.Service, rd.CleanName),
+		fileName,
+	)
+}
+
+// GenerateListImplementation generates the plural data source of a data source configured with list,
+// which returns all objects matching its filters instead of exactly one.
+func GenerateListImplementation(cfg *config.Config, renderer common.Renderer, rd *common.ResourceData, dataSource *config.DataSource) error {
+	data := templateData(cfg, rd, dataSource)
+	data.TypeName = dataSource.ListTypeName(cfg.Naming)
+	fileName := "list_datasource.go"
+	if dataSource.Name != rd.Name {
+		fileName = dataSource.Name + "_list_datasource.go"
+	}
+
+	return renderer.RenderTemplate(
+		"list_datasource.go.tmpl",
+		[]string{"templates/shared/*.tmpl", "components/datasource/list_datasource.go.tmpl"},
+		data,
+		filepath.Join(cfg.Generator.OutputDir, "services", rd.Service, rd.CleanName),
+		fileName,
+	)
+}
+
+// GenerateEphemeralImplementation generates an ephemeral resource file. Ephemeral resources look up
+// objects like data sources, but their values are only kept for the current Terraform operation.
+func GenerateEphemeralImplementation(cfg *config.Config, renderer common.Renderer, rd *common.ResourceData, dataSource *config.DataSource) error {
+	data := templateData(cfg, rd, dataSource)
+	fileName := "ephemeral.go"
+	if dataSource.Name != rd.Name {
+		fileName = dataSource.Name + "_ephemeral.go"
+	}
+
+	return renderer.RenderTemplate(
+		"ephemeral.go.tmpl",
+		[]string{"templates/shared/*.tmpl", "components/datasource/lookup.tmpl", "components/datasource/ephemeral.go.tmpl"},
+		data,
+		filepath.Join(cfg.Generator.OutputDir, "services", rd.Service, rd.CleanName),
+		fileName,
+	)
+}
+
+// templateData builds the template data of a data source or ephemeral resource
+func templateData(cfg *config.Config, rd *common.ResourceData, dataSource *config.DataSource) DataSourceTemplateData {
 	// For datasources, all fields must be IsDataSource = true
 	// We clone fields to avoid modifying the originals which are shared with Resources.
 	responseFields := cloneFields(rd.ResponseFields)
@@ -59,38 +116,40 @@ func GenerateImplementation(cfg *config.Config, renderer common.Renderer, rd *co
 
 	data := DataSourceTemplateData{
 		Name:           rd.Name,
+		TypeName:       rd.TypeName,
+		ResourceName:   rd.Name,
 		Service:        rd.Service,
 		CleanName:      rd.CleanName,
 		Operations:     rd.Operations,
 		ListPath:       rd.APIPaths["Base"],
 		RetrievePath:   rd.APIPaths["Retrieve"],
 		FilterParams:   filterParams,
+		Ordering:       rd.Ordering,
 		ResponseFields: responseFields,
 		ModelFields:    modelFields,
+		VirtualFields:  rd.VirtualFields,
 	}
-
-	return renderer.RenderTemplate(
-		"datasource.go.tmpl",
-		[]string{"templates/shared/*.tmpl", "components/datasource/datasource.go.tmpl"},
-		data,
-		filepath.Join(cfg.Generator.OutputDir, "services", rd.Service, rd.CleanName),
-		"datasource.go",
-	)
+	if dataSource != nil {
+		data.Name = dataSource.Name
+		data.TypeName = cfg.Naming.TypeName(dataSource.Name)
+		data.DeprecationMessage = common.SanitizeString(dataSource.Deprecated)
+	}
+	return data
 }
 
 // PrepareData creates minimal ResourceData for a datasource-only definition
-func PrepareData(parser *openapi.Parser, dataSource *config.DataSource, schemaCfg common.SchemaConfig) (*common.ResourceData, error) {
+func PrepareData(cfg *config.Config, parser *openapi.Parser, dataSource *config.DataSource, schemaCfg common.SchemaConfig) (*common.ResourceData, error) {
 	ops := dataSource.OperationIDs()
 
 	// Extract API paths from OpenAPI operations
 	listPath := ""
 	retrievePath := ""
 
-	if _, path, _, err := parser.GetOperation(ops.List); err == nil {
+	if path, ok := parser.OperationPath(ops.List); ok {
 		listPath = path
 	}
 
-	if _, retPath, _, err := parser.GetOperation(ops.Retrieve); err == nil {
+	if retPath, ok := parser.OperationPath(ops.Retrieve); ok {
 		retrievePath = retPath
 	}
 
@@ -108,10 +167,21 @@ func PrepareData(parser *openapi.Parser, dataSource *config.DataSource, schemaCf
 		}
 	}
 
-	// Extract filter parameters
+	responseFields = common.DropSecretFields(responseFields)
+	common.ApplyDynamicObjects(schemaCfg, responseFields)
+	common.ApplyNormalization(schemaCfg, responseFields)
+	if err := common.ApplyTransforms(schemaCfg, responseFields); err != nil {
+		return nil, fmt.Errorf("data source %s: %w", dataSource.Name, err)
+	}
+
+	// Extract filter parameters and pagination
 	var filterParams []common.FilterParam
+	var pagination *common.Pagination
+	var ordering *common.Ordering
 	if op, _, _, err := parser.GetOperation(ops.List); err == nil {
 		filterParams = common.ExtractFilterParams(op, common.Humanize(dataSource.Name))
+		pagination = common.ExtractPagination(op)
+		ordering = common.ExtractOrdering(op)
 	}
 
 	// Use response fields for model
@@ -129,10 +199,18 @@ func PrepareData(parser *openapi.Parser, dataSource *config.DataSource, schemaCf
 	sort.Slice(modelFields, func(i, j int) bool { return modelFields[i].Name < modelFields[j].Name })
 
 	// Split name into service and clean name
-	service, cleanName := common.SplitResourceName(dataSource.Name)
+	service, cleanName := common.ResolveResourceName(cfg.Naming, dataSource.Name)
+
+	apiPaths := map[string]string{
+		"Base":     listPath,
+		"Retrieve": retrievePath,
+	}
+	plugins.AddMediaTypes(parser, apiPaths, "Base", ops.List)
+	plugins.AddMediaTypes(parser, apiPaths, "Retrieve", ops.Retrieve)
 
 	return &common.ResourceData{
 		Name:             dataSource.Name,
+		TypeName:         cfg.Naming.TypeName(dataSource.Name),
 		Service:          service,
 		CleanName:        cleanName,
 		ResponseFields:   responseFields,
@@ -140,10 +218,9 @@ func PrepareData(parser *openapi.Parser, dataSource *config.DataSource, schemaCf
 		IsDatasourceOnly: true,
 		HasDataSource:    true,
 		FilterParams:     filterParams,
-		APIPaths: map[string]string{
-			"Base":     listPath,
-			"Retrieve": retrievePath,
-		},
-		Operations: ops,
+		Pagination:       pagination,
+		Ordering:         ordering,
+		APIPaths:         apiPaths,
+		Operations:       ops,
 	}, nil
 }
@@ -7,13 +7,18 @@ import (
 
 // DataSourceTemplateData holds data for generating data source files
 type DataSourceTemplateData struct {
-	Name           string
-	Service        string
-	CleanName      string
-	Operations     config.OperationSet
-	ListPath       string
-	RetrievePath   string
-	FilterParams   []common.FilterParam
-	ResponseFields []common.FieldInfo
-	ModelFields    []common.FieldInfo
+	Name               string
+	TypeName           string // Terraform type name without the provider prefix
+	ResourceName       string // Entity whose SDK client and model are shared
+	Service            string
+	CleanName          string
+	Operations         config.OperationSet
+	ListPath           string
+	RetrievePath       string
+	FilterParams       []common.FilterParam
+	Ordering           *common.Ordering // Ordering of the list operation, nil when it cannot order results
+	ResponseFields     []common.FieldInfo
+	ModelFields        []common.FieldInfo
+	VirtualFields      []common.VirtualField // Computed attributes shared with the resource model
+	DeprecationMessage string                // Set when the data source is deprecated
 }
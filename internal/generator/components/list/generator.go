@@ -12,6 +12,7 @@ func GenerateImplementation(cfg *config.Config, renderer common.Renderer, rd *co
 	// data for template - list resource template expects some specific flags
 	data := ListResourceData{
 		Name:              rd.Name,
+		TypeName:          rd.TypeName,
 		Service:           rd.Service,
 		CleanName:         rd.CleanName,
 		APIPaths:          rd.APIPaths,
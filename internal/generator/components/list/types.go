@@ -7,6 +7,7 @@ import (
 // ListResourceData holds data for generating list resource files
 type ListResourceData struct {
 	Name              string
+	TypeName          string // Terraform type name of the listed resource
 	Service           string
 	CleanName         string
 	APIPaths          map[string]string
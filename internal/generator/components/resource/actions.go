@@ -0,0 +1,42 @@
+package resource
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/openapi"
+)
+
+// reservedActionAttributes are the attributes every generated action has besides its request fields
+var reservedActionAttributes = []string{"uuid", "timeout"}
+
+// buildActionParams extracts the scalar request body fields of a standalone action, which become
+// attributes of the action. Other fields are left out with a warning. Fields named like the
+// attributes every action has cannot be set.
+func buildActionParams(parser *openapi.Parser, schemaCfg common.SchemaConfig, operationID string) ([]common.FieldInfo, error) {
+	schema, err := parser.GetOperationRequestSchema(operationID)
+	if err != nil || schema == nil {
+		return nil, nil
+	}
+	fields, err := common.ExtractFields(schemaCfg, schema, false)
+	if err != nil {
+		return nil, err
+	}
+	var params []common.FieldInfo
+	for _, f := range fields {
+		if f.ReadOnly {
+			continue
+		}
+		if slices.Contains(reservedActionAttributes, f.Name) {
+			return nil, fmt.Errorf("request field %s conflicts with the %s attribute of the action", f.Name, f.Name)
+		}
+		switch f.GoType {
+		case common.TFTypeString, common.TFTypeInt64, common.TFTypeFloat64, common.TFTypeBool:
+			params = append(params, f)
+		default:
+			fmt.Printf("Warning: action %s: %s is not a scalar and cannot be set\n", operationID, f.Name)
+		}
+	}
+	return params, nil
+}
@@ -0,0 +1,83 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+)
+
+const actionsSpec = `
+openapi: 3.0.3
+info: {title: test, version: "1"}
+paths:
+  /api/servers/{uuid}/resize/:
+    post:
+      operationId: servers_resize
+      parameters:
+        - {name: uuid, in: path, required: true, schema: {type: string}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [flavor]
+              properties:
+                flavor: {type: string}
+                force: {type: boolean}
+                tags: {type: array, items: {type: string}}
+                state: {type: string, readOnly: true}
+      responses:
+        "200": {description: OK}
+  /api/servers/{uuid}/restart/:
+    post:
+      operationId: servers_restart
+      parameters:
+        - {name: uuid, in: path, required: true, schema: {type: string}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                timeout: {type: integer}
+      responses:
+        "200": {description: OK}
+  /api/servers/{uuid}/move/:
+    post:
+      operationId: servers_move
+      parameters:
+        - {name: uuid, in: path, required: true, schema: {type: string}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                uuid: {type: string}
+      responses:
+        "200": {description: OK}
+`
+
+func TestBuildActionParams(t *testing.T) {
+	parser := newTestParser(t, actionsSpec)
+
+	params, err := buildActionParams(parser, common.SchemaConfig{}, "servers_resize")
+	if err != nil {
+		t.Fatalf("buildActionParams() error = %v", err)
+	}
+	var got []string
+	for _, p := range params {
+		got = append(got, p.Name)
+	}
+	// Read-only and non-scalar fields are left out
+	if len(got) != 2 || got[0] != "flavor" || got[1] != "force" {
+		t.Errorf("buildActionParams() = %v, want [flavor force]", got)
+	}
+
+	// Request fields named like the uuid and timeout attributes of the action are rejected
+	for _, operationID := range []string{"servers_restart", "servers_move"} {
+		if _, err := buildActionParams(parser, common.SchemaConfig{}, operationID); err == nil {
+			t.Errorf("buildActionParams(%s) succeeded, want an error", operationID)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package resource
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/openapi"
+)
+
+// buildErrorResponses extracts the documented client error schemas of the create, update and
+// delete operations. Schemas without properties, such as plain strings, are left out.
+func buildErrorResponses(parser *openapi.Parser, schemaCfg common.SchemaConfig, createOp, updateOp, deleteOp string) ([]common.ErrorResponse, error) {
+	var responses []common.ErrorResponse
+	for _, op := range []struct{ name, id string }{{"Create", createOp}, {"Update", updateOp}, {"Delete", deleteOp}} {
+		if op.id == "" {
+			continue
+		}
+		schemas := parser.GetOperationErrorResponseSchemas(op.id)
+		for _, code := range openapi.ErrorStatusCodes {
+			schema, ok := schemas[code]
+			if !ok {
+				continue
+			}
+			fields, err := common.ExtractFields(schemaCfg, schema, false)
+			if err != nil {
+				return nil, fmt.Errorf("%s error response %d: %w", op.id, code, err)
+			}
+			if len(fields) == 0 {
+				continue
+			}
+			slices.SortFunc(fields, func(a, b common.FieldInfo) int { return strings.Compare(a.Name, b.Name) })
+			responses = append(responses, common.ErrorResponse{Operation: op.name, StatusCode: code, Fields: fields})
+		}
+	}
+	return responses, nil
+}
+
+// buildAPIErrorAttributes maps the top-level request fields that API validation errors name to the
+// attributes they are reported on. Fields are keyed by their JSON name, which differs from the
+// attribute name when the field has a custom JSON tag. Fields that are never sent are left out.
+func buildAPIErrorAttributes(modelFields []common.FieldInfo) map[string]string {
+	attributes := make(map[string]string)
+	for _, f := range modelFields {
+		if f.SchemaSkip || f.JsonTag == "-" || f.IsPathParam {
+			continue
+		}
+		name, _, _ := strings.Cut(f.JsonTag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		attributes[name] = f.Name
+	}
+	return attributes
+}
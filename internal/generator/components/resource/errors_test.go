@@ -0,0 +1,27 @@
+package resource
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+)
+
+func TestBuildAPIErrorAttributes(t *testing.T) {
+	modelFields := []common.FieldInfo{
+		{Name: "name"},
+		{Name: "flavor_name", JsonTag: "flavor,omitempty"}, // Renamed attribute
+		{Name: "disk_size", Transform: "mb_to_gb"},
+		{Name: "display", JsonTag: "-"},
+		{Name: "nested", SchemaSkip: true},
+		{Name: "project_uuid", IsPathParam: true},
+	}
+	want := map[string]string{
+		"name":      "name",
+		"flavor":    "flavor_name",
+		"disk_size": "disk_size",
+	}
+	if got := buildAPIErrorAttributes(modelFields); !reflect.DeepEqual(got, want) {
+		t.Errorf("buildAPIErrorAttributes() = %v, want %v", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package resource
 
 import (
 	"fmt"
+	"net/http"
 	"path/filepath"
 	"slices"
 	"sort"
@@ -10,6 +11,7 @@ import (
 	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
 	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
 	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/plugins"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/plugins/bulk"
 	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/plugins/link"
 	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/plugins/order"
 	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/plugins/standard"
@@ -32,19 +34,7 @@ func PrepareData(cfg *config.Config, parser *openapi.Parser, resource *config.Re
 	ops := resource.OperationIDs()
 
 	// 0. Construct SchemaConfig
-	schemaCfg := getSchemaConfig()
-	if schemaCfg.FieldOverrides == nil {
-		schemaCfg.FieldOverrides = make(map[string]config.FieldConfig)
-	}
-	for k, v := range resource.SetFields {
-		schemaCfg.FieldOverrides[k] = v
-	}
-	if schemaCfg.ExcludedFields == nil {
-		schemaCfg.ExcludedFields = make(map[string]bool)
-	}
-	for _, f := range resource.ExcludedFields {
-		schemaCfg.ExcludedFields[f] = true
-	}
+	schemaCfg := getSchemaConfig().ForResource(resource)
 
 	// 1. Choose builder
 	var builder plugins.ResourceBuilder
@@ -53,6 +43,8 @@ func PrepareData(cfg *config.Config, parser *openapi.Parser, resource *config.Re
 		builder = &order.OrderBuilder{BaseBuilder: base}
 	} else if resource.Plugin == "link" || resource.LinkOp != "" {
 		builder = &link.LinkBuilder{BaseBuilder: base}
+	} else if resource.Plugin == "bulk" {
+		builder = &bulk.BulkBuilder{BaseBuilder: base}
 	} else {
 		builder = &standard.StandardBuilder{BaseBuilder: base}
 	}
@@ -68,10 +60,15 @@ func PrepareData(cfg *config.Config, parser *openapi.Parser, resource *config.Re
 	if err != nil {
 		return nil, err
 	}
+	common.MarkSendNull(updateFields)
 	responseFields, err := builder.BuildResponseFields()
 	if err != nil {
 		return nil, err
 	}
+	responseFields = common.DropSecretFields(responseFields)
+	for _, fields := range [][]common.FieldInfo{createFields, updateFields, responseFields} {
+		common.ApplyDynamicObjects(schemaCfg, fields)
+	}
 
 	// 3. Common Enriched Logic (Actions, Filters, etc.)
 	// Resolve update action paths from OpenAPI schema
@@ -93,7 +90,7 @@ func PrepareData(cfg *config.Config, parser *openapi.Parser, resource *config.Re
 		if action.CompareKey == "" {
 			action.CompareKey = action.Param
 		}
-		if _, actionPath, _, err := parser.GetOperation(actionConfig.Operation); err == nil {
+		if actionPath, ok := parser.OperationPath(actionConfig.Operation); ok {
 			action.Path = actionPath
 		}
 		updateActions = append(updateActions, action)
@@ -107,16 +104,77 @@ func PrepareData(cfg *config.Config, parser *openapi.Parser, resource *config.Re
 			Name:      actionName,
 			Operation: operationID,
 		}
-		if _, actionPath, _, err := parser.GetOperation(operationID); err == nil {
+		if actionPath, ok := parser.OperationPath(operationID); ok {
 			action.Path = actionPath
 		}
+		params, err := buildActionParams(parser, schemaCfg, operationID)
+		if err != nil {
+			return nil, fmt.Errorf("resource %s: action %s: %w", resource.Name, actionName, err)
+		}
+		action.Params = params
 		standaloneActions = append(standaloneActions, action)
 	}
+	var actionTriggers []common.UpdateAction
+	for _, action := range standaloneActions {
+		if !slices.Contains(resource.ActionTriggers, action.Name) {
+			continue
+		}
+		for _, p := range action.Params {
+			if p.Required {
+				return nil, fmt.Errorf("resource %s: action %s cannot be a trigger, as it requires %s", resource.Name, action.Name, p.Name)
+			}
+		}
+		actionTriggers = append(actionTriggers, action)
+	}
 
-	// Extract filter parameters
+	// Extract filter parameters and pagination
 	var filterParams []common.FilterParam
+	var pagination *common.Pagination
+	var ordering *common.Ordering
 	if op, _, _, err := parser.GetOperation(ops.List); err == nil {
 		filterParams = common.ExtractFilterParams(op, common.Humanize(resource.Name))
+		pagination = common.ExtractPagination(op)
+		ordering = common.ExtractOrdering(op)
+	}
+
+	// Operations accepting multipart forms upload their binary fields as files,
+	// create operations documenting a Location header may return the new object only there,
+	// create operations answering 202 Accepted return a task to wait for,
+	// and documented client error schemas become typed errors
+	var createUpload, updateUpload *common.Upload
+	var createFollowsLocation, createAsync, createAsyncOrder bool
+	var errorResponses []common.ErrorResponse
+	_, isStandard := builder.(*standard.StandardBuilder)
+	createOp := ops.Create
+	if resource.CreateOperation != nil && resource.CreateOperation.OperationID != "" {
+		createOp = resource.CreateOperation.OperationID
+	}
+	if isStandard {
+		deleteOp := ops.Destroy
+		if resource.DeleteOperation != nil {
+			deleteOp = resource.DeleteOperation.OperationID
+		}
+		errorResponses, err = buildErrorResponses(parser, schemaCfg, createOp, ops.PartialUpdate, deleteOp)
+		if err != nil {
+			return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+		}
+		createFollowsLocation = slices.Contains(parser.GetOperationResponseHeaders(createOp), "Location")
+		createAsync = parser.IsAsyncOperation(createOp)
+		createAsyncOrder = parser.IsOrderOperation(createOp)
+		createUpload = common.BuildUpload(createFields,
+			parser.AcceptsRequestContentType(createOp, "multipart/form-data"),
+			parser.AcceptsRequestContentType(createOp, "application/json"))
+		updateUpload = common.BuildUpload(updateFields,
+			parser.AcceptsRequestContentType(ops.PartialUpdate, "multipart/form-data"),
+			parser.AcceptsRequestContentType(ops.PartialUpdate, "application/json"))
+	}
+	uploadFields := make(map[string]bool)
+	for _, upload := range []*common.Upload{createUpload, updateUpload} {
+		if upload != nil {
+			for _, name := range upload.Files {
+				uploadFields[name] = true
+			}
+		}
 	}
 
 	// 4. Merge Fields for Model
@@ -124,6 +182,29 @@ func PrepareData(cfg *config.Config, parser *openapi.Parser, resource *config.Re
 	if err != nil {
 		return nil, err
 	}
+	createFields, updateFields, responseFields = common.ResolveTypeConflicts(modelFields, createFields, updateFields, responseFields)
+	common.ApplyNormalization(schemaCfg, modelFields)
+	if err := common.ApplyTransforms(schemaCfg, modelFields, createFields, updateFields, responseFields); err != nil {
+		return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
+	// Fields accepting a single value are sent without an attribute
+	if isStandard {
+		modelFields, responseFields = common.ApplyFixedFields(createFields, modelFields, responseFields)
+	}
+
+	// Resources identified by a field other than the UUID are looked up through the list filters
+	idField := resource.IDField
+	if idField == "uuid" {
+		idField = ""
+	}
+	if idField != "" {
+		if !slices.ContainsFunc(modelFields, func(f common.FieldInfo) bool { return f.Name == idField && f.GoType == common.TFTypeString }) {
+			return nil, fmt.Errorf("resource %s: id_field %s must be a string attribute", resource.Name, idField)
+		}
+		if !slices.ContainsFunc(filterParams, func(p common.FilterParam) bool { return p.Name == idField }) {
+			return nil, fmt.Errorf("resource %s: id_field %s is not a filter of %s", resource.Name, idField, ops.List)
+		}
+	}
 
 	// 5. Special Overrides (Marketplace Attributes, Path Params)
 	if resource.Name == "marketplace_order" {
@@ -133,6 +214,7 @@ func PrepareData(cfg *config.Config, parser *openapi.Parser, resource *config.Re
 				modelFields[i].ItemType = common.OpenAPITypeString
 				modelFields[i].Type = common.OpenAPITypeObject
 				modelFields[i].Properties = nil
+				modelFields[i].JSON = false
 				common.CalculateSDKType(&modelFields[i])
 			}
 		}
@@ -142,15 +224,20 @@ func PrepareData(cfg *config.Config, parser *openapi.Parser, resource *config.Re
 				createFields[i].ItemType = common.OpenAPITypeString
 				createFields[i].Type = common.OpenAPITypeObject
 				createFields[i].Properties = nil
+				createFields[i].JSON = false
 				common.CalculateSDKType(&createFields[i])
 			}
 		}
 	}
 
-	if resource.CreateOperation != nil && len(resource.CreateOperation.PathParams) > 0 {
+	if resource.CreateOperation != nil && resource.CreateOperation.OperationID != "" {
+		pathParamFields, err := buildPathParamFields(parser, resource.CreateOperation)
+		if err != nil {
+			return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+		}
 		pathParamSet := make(map[string]bool)
-		for _, v := range resource.CreateOperation.PathParams {
-			pathParamSet[v] = true
+		for _, f := range pathParamFields {
+			pathParamSet[f.Name] = true
 		}
 		for i := range modelFields {
 			if pathParamSet[modelFields[i].Name] {
@@ -160,19 +247,8 @@ func PrepareData(cfg *config.Config, parser *openapi.Parser, resource *config.Re
 			}
 		}
 		// Ensure path params are in createFields as well
-		for _, name := range resource.CreateOperation.PathParams {
-			found := false
-			for _, f := range createFields {
-				if f.Name == name {
-					found = true
-					break
-				}
-			}
-			if !found {
-				f := common.FieldInfo{
-					Name: name, Type: common.OpenAPITypeString, Description: "Required path parameter", GoType: common.TFTypeString, Required: true, IsPathParam: true,
-				}
-				common.CalculateSDKType(&f)
+		for _, f := range pathParamFields {
+			if !slices.ContainsFunc(createFields, func(c common.FieldInfo) bool { return c.Name == f.Name }) {
 				createFields = append(createFields, f)
 			}
 		}
@@ -183,18 +259,91 @@ func PrepareData(cfg *config.Config, parser *openapi.Parser, resource *config.Re
 	for _, f := range updateFields {
 		validUpdateFields[f.Name] = true
 	}
+	// A nested compare_key (e.g., "attributes.tags") makes its top-level field updatable,
+	// but only the compared attribute within it
+	inferenceFields := updateFields
 	for _, action := range updateActions {
 		validUpdateFields[action.Param] = true
+		root, _, nested := strings.Cut(action.CompareKey, ".")
+		validUpdateFields[root] = true
+		if nested {
+			inferenceFields = common.AddUpdatePath(inferenceFields, modelFields, action.CompareKey)
+		}
+	}
+	// Changed through switch_plan and update_limits marketplace orders, when the API has them
+	if apiPaths["SwitchPlan"] != "" {
+		validUpdateFields["plan"] = true
+	}
+	if apiPaths["UpdateLimits"] != "" {
+		validUpdateFields["limits"] = true
 	}
 
 	common.FillDescriptions(modelFields, common.Humanize(resource.Name))
 	for i := range modelFields {
-		if !modelFields[i].ReadOnly && !validUpdateFields[modelFields[i].Name] {
-			modelFields[i].ForceNew = true
+		f := &modelFields[i]
+		inferred := !f.ReadOnly && !validUpdateFields[f.Name]
+		if explicit := resource.SetFields[f.Name].ForceNew; explicit != nil {
+			if *explicit != inferred && !f.ReadOnly {
+				if inferred {
+					fmt.Printf("Warning: resource %s: %s has force_new: false but is not updatable in place\n", resource.Name, f.Name)
+				} else {
+					fmt.Printf("Warning: resource %s: %s has force_new: true but can be updated in place\n", resource.Name, f.Name)
+				}
+			}
+			f.ForceNew = *explicit
+		} else if inferred {
+			f.ForceNew = true
 		}
 	}
+	for _, conflict := range common.InferNestedForceNew(modelFields, inferenceFields, resource.SetFields) {
+		fmt.Printf("Warning: resource %s: %s\n", resource.Name, conflict)
+	}
 
 	common.CalculateSchemaStatusRecursive(modelFields, createFields, responseFields)
+	if err := common.CheckSetKeys(modelFields); err != nil {
+		return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
+	if err := common.CheckReplaceIf(modelFields); err != nil {
+		return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
+
+	var deleteParams []common.BodyParam
+	if resource.DeleteOperation != nil {
+		switch apiPaths["DeleteMethod"] {
+		case http.MethodDelete:
+		case http.MethodPost:
+			deleteParams, err = buildBodyParams(resource.DeleteOperation.Params, modelFieldTypes(modelFields))
+			if err != nil {
+				return nil, fmt.Errorf("resource %s: delete_operation: %w", resource.Name, err)
+			}
+		case "":
+			return nil, fmt.Errorf("resource %s: delete operation not found: %s", resource.Name, resource.DeleteOperation.OperationID)
+		default:
+			return nil, fmt.Errorf("resource %s: delete operation %s must be a DELETE or POST, got %s", resource.Name, resource.DeleteOperation.OperationID, apiPaths["DeleteMethod"])
+		}
+	}
+
+	// Creation steps expose the UUIDs they create as computed attributes
+	steps, err := buildSteps(parser, resource, modelFields)
+	if err != nil {
+		return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
+	for _, step := range resource.Steps {
+		modelFields = append(modelFields, stepUUIDField(step))
+	}
+	rollbackSteps := slices.Clone(steps)
+	slices.Reverse(rollbackSteps)
+
+	preflightChecks, err := buildPreflightChecks(parser, resource, modelFields)
+	if err != nil {
+		return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
+
+	// Nested lists with add and remove operations are updated item by item instead of replacing the resource
+	nestedOperations, err := buildNestedOperations(parser, schemaCfg, resource, modelFields, createFields)
+	if err != nil {
+		return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
 
 	// Update responseFields to use merged field definitions
 	modelMap := make(map[string]common.FieldInfo)
@@ -207,6 +356,92 @@ func PrepareData(cfg *config.Config, parser *openapi.Parser, resource *config.Re
 		}
 	}
 
+	if err := applySkipFieldsInState(resource.SkipFieldsInState, responseFields); err != nil {
+		return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
+	// The API returns the URL of an uploaded file, so the configured source is kept from state
+	for i := range responseFields {
+		if uploadFields[responseFields[i].Name] {
+			responseFields[i].KeepState = true
+		}
+	}
+	// Fields missing from the responses of API versions without them are kept from state rather than cleared
+	for i := range responseFields {
+		f := &responseFields[i]
+		f.KeepIfMissing = !f.SchemaSkip && !f.KeepState && f.JsonTag == "" && resource.SetFields[f.Name].OnMissing != config.OnMissingNull
+	}
+
+	// Virtual fields are computed from paths into the response
+	var virtualFields []common.VirtualField
+	for _, cfg := range resource.VirtualFields {
+		if _, exists := findField(modelFields, cfg.Name); exists || cfg.Name == "id" || cfg.Name == "lifecycle_meta" {
+			return nil, fmt.Errorf("resource %s: virtual field %s conflicts with an existing attribute", resource.Name, cfg.Name)
+		}
+		vf, err := buildVirtualField(cfg, responseFields)
+		if err != nil {
+			return nil, fmt.Errorf("resource %s: virtual field %s: %w", resource.Name, cfg.Name, err)
+		}
+		virtualFields = append(virtualFields, vf)
+	}
+
+	configValidators, err := buildConfigValidators(resource.Validators, modelFields)
+	if err != nil {
+		return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
+	offeringPath, offeringValidators, err := buildOfferingTypeBlocks(parser, resource, createFields)
+	if err != nil {
+		return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
+	configValidators = append(configValidators, offeringValidators...)
+
+	skipPolling := true
+	for _, f := range responseFields {
+		if f.Name == "state" || f.Name == "status" {
+			skipPolling = false
+			break
+		}
+	}
+
+	// State written by prior schema versions is migrated to the current attributes
+	attributes := map[string]bool{"id": true, "timeouts": true}
+	for _, f := range modelFields {
+		if !f.SchemaSkip {
+			attributes[f.Name] = true
+		}
+	}
+	for _, vf := range virtualFields {
+		attributes[vf.Name] = true
+	}
+	if attributes["lifecycle_meta"] {
+		return nil, fmt.Errorf("resource %s: lifecycle_meta is already an attribute", resource.Name)
+	}
+	attributes["lifecycle_meta"] = true
+	// Resources that wait for their creation to complete can be told not to
+	waitFor := resource.Plugin == "order" || (isStandard && !skipPolling && !createAsync && (apiPaths["Create"] != "" || resource.CreateOperation != nil))
+	if waitFor {
+		if attributes["wait_for"] {
+			return nil, fmt.Errorf("resource %s: wait_for is already an attribute", resource.Name)
+		}
+		attributes["wait_for"] = true
+	}
+	if resource.DeletionProtection {
+		if attributes["deletion_protection"] {
+			return nil, fmt.Errorf("resource %s: deletion_protection is already an attribute", resource.Name)
+		}
+		attributes["deletion_protection"] = true
+	}
+	for _, action := range actionTriggers {
+		name := action.Name + "_trigger"
+		if attributes[name] {
+			return nil, fmt.Errorf("resource %s: %s is already an attribute", resource.Name, name)
+		}
+		attributes[name] = true
+	}
+	stateUpgraders, err := buildStateUpgraders(resource.SchemaVersion, resource.StateUpgrades, attributes)
+	if err != nil {
+		return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
+
 	// Define a generic sorter
 	sortByName := func(a, b common.FieldInfo) int {
 		return strings.Compare(a.Name, b.Name)
@@ -217,13 +452,40 @@ func PrepareData(cfg *config.Config, parser *openapi.Parser, resource *config.Re
 	slices.SortFunc(responseFields, sortByName)
 	slices.SortFunc(modelFields, sortByName)
 
-	service, cleanName := common.SplitResourceName(resource.Name)
-	skipPolling := true
-	for _, f := range responseFields {
-		if f.Name == "state" || f.Name == "status" {
-			skipPolling = false
-			break
-		}
+	service, cleanName := common.ResolveResourceName(cfg.Naming, resource.Name)
+
+	// URL fields referring to API objects accept their UUIDs as well
+	references, err := buildReferences(parser, schemaCfg, service, createFields, updateFields)
+	if err != nil {
+		return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
+	common.ApplyReferences(references, createFields, updateFields, responseFields, modelFields)
+
+	// The project and customer of new resources may come from the provider configuration
+	var providerDefaults []common.ProviderDefault
+	if isStandard || resource.Plugin == "order" {
+		providerDefaults = common.ApplyProviderDefaults(createFields, modelFields)
+	}
+
+	polling, err := common.NewPollingOptions(resource.Polling)
+	if err != nil {
+		return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
+	var timeouts config.TimeoutsConfig
+	if resource.Timeouts != nil {
+		timeouts = *resource.Timeouts
+	}
+	createTimeout, err := common.TimeoutExpr(timeouts.Create, "common.DefaultCreateTimeout")
+	if err != nil {
+		return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
+	updateTimeout, err := common.TimeoutExpr(timeouts.Update, "common.DefaultUpdateTimeout")
+	if err != nil {
+		return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
+	deleteTimeout, err := common.TimeoutExpr(timeouts.Delete, "common.DefaultDeleteTimeout")
+	if err != nil {
+		return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
 	}
 
 	inputFields := make(map[string]bool)
@@ -233,8 +495,22 @@ func PrepareData(cfg *config.Config, parser *openapi.Parser, resource *config.Re
 	common.ApplySchemaSkipRecursive(schemaCfg, modelFields, inputFields)
 	common.ApplySchemaSkipRecursive(schemaCfg, responseFields, inputFields)
 
+	// Fields the create request requires must remain settable
+	if isStandard {
+		if schema, err := parser.GetOperationRequestSchema(createOp); err == nil {
+			for _, issue := range common.CheckRequiredFields(schemaCfg, schema, createFields, modelFields) {
+				if schemaCfg.FieldOverrides[issue.Name].AllowMissing {
+					fmt.Printf("Warning: resource %s: %s is required by %s but is %s\n", resource.Name, issue.Name, createOp, issue.Reason)
+					continue
+				}
+				return nil, fmt.Errorf("resource %s: %s is required by %s but is %s; set allow_missing to generate the resource anyway", resource.Name, issue.Name, createOp, issue.Reason)
+			}
+		}
+	}
+
 	rd := &common.ResourceData{
 		Name:                  resource.Name,
+		TypeName:              cfg.Naming.TypeName(resource.Name),
 		Service:               service,
 		CleanName:             cleanName,
 		Plugin:                resource.Plugin,
@@ -248,22 +524,52 @@ func PrepareData(cfg *config.Config, parser *openapi.Parser, resource *config.Re
 		Source:                resource.Source,
 		Target:                resource.Target,
 		LinkCheckKey:          resource.LinkCheckKey,
+		Members:               resource.Members,
 		OfferingType:          resource.OfferingType,
+		OfferingTypes:         resource.OfferingTypes,
+		OfferingPath:          offeringPath,
 		UpdateActions:         updateActions,
 		StandaloneActions:     standaloneActions,
+		ActionTriggers:        actionTriggers,
+		PreflightChecks:       preflightChecks,
+		NestedOperations:      nestedOperations,
 		TerminationAttributes: resource.TerminationAttributes,
 		CreateOperation:       resource.CreateOperation,
 		CompositeKeys:         resource.CompositeKeys,
+		IDField:               idField,
+		VirtualFields:         virtualFields,
+		ConfigValidators:      configValidators,
 		FilterParams:          filterParams,
+		Pagination:            pagination,
+		Ordering:              ordering,
+		CreateUpload:          createUpload,
+		CreateFollowsLocation: createFollowsLocation,
+		CreateAsync:           createAsync,
+		CreateAsyncOrder:      createAsyncOrder,
+		UpdateUpload:          updateUpload,
+		ErrorResponses:        errorResponses,
+		APIErrorAttributes:    buildAPIErrorAttributes(modelFields),
 		SkipPolling:           skipPolling,
+		Polling:               polling,
+		CreateTimeout:         createTimeout,
+		UpdateTimeout:         updateTimeout,
+		DeleteTimeout:         deleteTimeout,
+		DeleteParams:          deleteParams,
+		Steps:                 steps,
+		RollbackSteps:         rollbackSteps,
+		DeprecationMessage:    common.SanitizeString(resource.Deprecated),
 		BaseOperationID:       resource.BaseOperationID,
 		HasDataSource:         hasDataSource(resource.Name),
+		ProviderName:          cfg.Generator.ProviderName,
+		Aliases:               resource.Aliases,
+		SchemaVersion:         resource.SchemaVersion,
+		StateUpgraders:        stateUpgraders,
+		ProviderDefaults:      providerDefaults,
+		DeletionProtection:    resource.DeletionProtection,
+		WaitFor:               waitFor,
 	}
 
-	seenHashes := make(map[string]string)
-	seenNames := make(map[string]string)
-	common.AssignMissingAttrTypeRefs(schemaCfg, rd.ModelFields, "", seenHashes, seenNames)
-	common.AssignMissingAttrTypeRefs(schemaCfg, rd.ResponseFields, "", seenHashes, seenNames)
+	common.AssignAttrTypeRefs(rd.ModelFields, rd.ResponseFields)
 	rd.NestedStructs = common.CollectUniqueStructs(rd.ModelFields)
 	rd.TemplateFiles = builder.GetTemplateFiles()
 
@@ -0,0 +1,136 @@
+package resource
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/openapi"
+)
+
+// buildNestedOperations resolves the add and remove operations configured for nested lists. The lists and
+// the item attributes sent when adding become configurable, and changing them no longer replaces the resource.
+// Items are compared with the normalizations of their string attributes.
+func buildNestedOperations(parser *openapi.Parser, schemaCfg common.SchemaConfig, resource *config.Resource, modelFields, createFields []common.FieldInfo) ([]common.NestedOperation, error) {
+	names := make([]string, 0, len(resource.NestedOperations))
+	for name := range resource.NestedOperations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []common.NestedOperation
+	for _, name := range names {
+		cfg := resource.NestedOperations[name]
+		i := slices.IndexFunc(modelFields, func(f common.FieldInfo) bool { return f.Name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("nested operations: attribute %s not found", name)
+		}
+		f := &modelFields[i]
+		if (f.GoType != common.TFTypeList && f.GoType != common.TFTypeSet) || f.ItemSchema == nil || len(f.ItemSchema.Properties) == 0 {
+			return nil, fmt.Errorf("nested operations: %s must be a list of objects", name)
+		}
+
+		op := common.NestedOperation{
+			Name:           name,
+			Attr:           common.ToTitle(name),
+			InCreate:       slices.ContainsFunc(createFields, func(c common.FieldInfo) bool { return c.Name == name }),
+			AddParams:      make(map[string]string),
+			RemoveParams:   cfg.RemoveParams,
+			Normalizations: make(map[string]string),
+		}
+		if len(op.RemoveParams) == 0 {
+			op.RemoveParams = map[string]string{"uuid": "uuid"}
+		}
+		var err error
+		if op.AddPath, err = nestedOperationPath(parser, cfg.Add); err != nil {
+			return nil, fmt.Errorf("nested operations of %s: %w", name, err)
+		}
+		if op.RemovePath, err = nestedOperationPath(parser, cfg.Remove); err != nil {
+			return nil, fmt.Errorf("nested operations of %s: %w", name, err)
+		}
+
+		// Items are copied, as their schema may be shared with other fields
+		item := *f.ItemSchema
+		item.Properties = slices.Clone(item.Properties)
+		itemIndex := make(map[string]*common.FieldInfo)
+		for j := range item.Properties {
+			itemIndex[item.Properties[j].Name] = &item.Properties[j]
+		}
+
+		addSchema, err := parser.GetOperationRequestSchema(cfg.Add)
+		if err != nil {
+			return nil, fmt.Errorf("nested operations of %s: %w", name, err)
+		}
+		if addSchema == nil || addSchema.Value == nil || len(addSchema.Value.Properties) == 0 {
+			return nil, fmt.Errorf("nested operations of %s: add operation %s has no request body", name, cfg.Add)
+		}
+		for key := range addSchema.Value.Properties {
+			prop, ok := itemIndex[key]
+			if !ok {
+				if slices.Contains(addSchema.Value.Required, key) {
+					return nil, fmt.Errorf("nested operations of %s: required field %s of %s is not an item attribute", name, key, cfg.Add)
+				}
+				continue
+			}
+			op.AddParams[key] = key
+			if prop.GoType == common.TFTypeString {
+				if t, ok := common.NormalizedStringTypes[common.NormalizeStrategy(schemaCfg, name+"."+key, key, prop.Format)]; ok {
+					op.Normalizations[key] = t + "Normalization"
+				}
+			}
+			prop.ReadOnly = false
+			prop.ForceNew = false
+			prop.Required = slices.Contains(addSchema.Value.Required, key)
+			prop.ServerComputed = !prop.Required
+			prop.UseStateForUnknown = !prop.Required
+		}
+		if len(op.AddParams) == 0 {
+			return nil, fmt.Errorf("nested operations of %s: the request of %s holds no item attribute", name, cfg.Add)
+		}
+
+		removeSchema, err := parser.GetOperationRequestSchema(cfg.Remove)
+		if err != nil {
+			return nil, fmt.Errorf("nested operations of %s: %w", name, err)
+		}
+		for key, attr := range op.RemoveParams {
+			if _, ok := itemIndex[attr]; !ok {
+				return nil, fmt.Errorf("nested operations of %s: remove param %s references unknown item attribute %q", name, key, attr)
+			}
+			if removeSchema == nil || removeSchema.Value == nil || removeSchema.Value.Properties[key] == nil {
+				return nil, fmt.Errorf("nested operations of %s: %s is not a request field of %s", name, key, cfg.Remove)
+			}
+		}
+
+		// The list is set in configuration, or kept from state when omitted
+		f.ItemSchema = &item
+		f.ReadOnly = false
+		f.Required = false
+		f.ForceNew = false
+		f.ServerComputed = true
+		f.UseStateForUnknown = true
+		result = append(result, op)
+	}
+	return result, nil
+}
+
+// nestedOperationPath returns the path of a POST action taking the {uuid} of the resource
+func nestedOperationPath(parser *openapi.Parser, operationID string) (string, error) {
+	_, path, method, err := parser.GetOperation(operationID)
+	if err != nil {
+		return "", err
+	}
+	if method != http.MethodPost {
+		return "", fmt.Errorf("operation %s must be a POST, got %s", operationID, method)
+	}
+	pathParams, err := parser.GetOperationPathParams(operationID)
+	if err != nil {
+		return "", fmt.Errorf("operation %s: %w", operationID, err)
+	}
+	if len(pathParams) != 1 || pathParams[0].Name != "uuid" {
+		return "", fmt.Errorf("operation %s: only a {uuid} path parameter is supported", operationID)
+	}
+	return path, nil
+}
@@ -0,0 +1,150 @@
+package resource
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/openapi"
+)
+
+const nestedOperationsSpec = `
+openapi: 3.0.3
+info: {title: test, version: "1"}
+paths:
+  /api/servers/{uuid}/add_rule/:
+    post:
+      operationId: servers_add_rule
+      parameters:
+        - {name: uuid, in: path, required: true, schema: {type: string}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [protocol]
+              properties:
+                protocol: {type: string}
+                remote_group: {type: string, format: uri}
+                description: {type: string}
+      responses:
+        "200": {description: OK}
+  /api/servers/{uuid}/remove_rule/:
+    post:
+      operationId: servers_remove_rule
+      parameters:
+        - {name: uuid, in: path, required: true, schema: {type: string}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                rule_uuid: {type: string}
+      responses:
+        "200": {description: OK}
+`
+
+// newTestParser builds a parser for an OpenAPI document given as YAML
+func newTestParser(t *testing.T, spec string) *openapi.Parser {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(path, []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	parser, err := openapi.NewParser(openapi.FetchOptions{CacheDir: t.TempDir()}, path)
+	if err != nil {
+		t.Fatalf("NewParser() error = %v", err)
+	}
+	return parser
+}
+
+func TestBuildNestedOperations(t *testing.T) {
+	parser := newTestParser(t, nestedOperationsSpec)
+	str := func(name string) common.FieldInfo {
+		return common.FieldInfo{Name: name, GoType: common.TFTypeString, ReadOnly: true}
+	}
+	remoteGroup := str("remote_group")
+	remoteGroup.Format = "uri"
+	modelFields := []common.FieldInfo{
+		{Name: "rules", GoType: common.TFTypeList, ReadOnly: true, ItemSchema: &common.FieldInfo{
+			Properties: []common.FieldInfo{str("uuid"), str("protocol"), remoteGroup, str("description")},
+		}},
+	}
+	resource := &config.Resource{
+		Name: "server",
+		NestedOperations: map[string]config.NestedOperationsConfig{
+			"rules": {Add: "servers_add_rule", Remove: "servers_remove_rule", RemoveParams: map[string]string{"rule_uuid": "uuid"}},
+		},
+		SetFields: map[string]config.FieldConfig{"rules.description": {Normalize: config.NormalizeWhitespaceInsensitive}},
+	}
+	schemaCfg := common.SchemaConfig{FieldOverrides: resource.SetFields}
+
+	ops, err := buildNestedOperations(parser, schemaCfg, resource, modelFields, nil)
+	if err != nil {
+		t.Fatalf("buildNestedOperations() error = %v", err)
+	}
+	want := []common.NestedOperation{{
+		Name:         "rules",
+		Attr:         "Rules",
+		AddPath:      "/api/servers/{uuid}/add_rule/",
+		RemovePath:   "/api/servers/{uuid}/remove_rule/",
+		AddParams:    map[string]string{"protocol": "protocol", "remote_group": "remote_group", "description": "description"},
+		RemoveParams: map[string]string{"rule_uuid": "uuid"},
+		Normalizations: map[string]string{
+			"remote_group": "common.URLNormalization",
+			"description":  "common.WhitespaceInsensitiveNormalization",
+		},
+	}}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("buildNestedOperations() = %+v, want %+v", ops, want)
+	}
+
+	// The list and the attributes sent when adding items become configurable
+	rules := modelFields[0]
+	if rules.ReadOnly || rules.ForceNew || !rules.UseStateForUnknown {
+		t.Errorf("rules = %+v, want an optional list kept from state", rules)
+	}
+	got := map[string][2]bool{}
+	for _, p := range rules.ItemSchema.Properties {
+		got[p.Name] = [2]bool{p.ReadOnly, p.Required}
+	}
+	wantProps := map[string][2]bool{
+		"uuid":         {true, false},
+		"protocol":     {false, true},
+		"remote_group": {false, false},
+		"description":  {false, false},
+	}
+	if !reflect.DeepEqual(got, wantProps) {
+		t.Errorf("item [read-only, required] = %v, want %v", got, wantProps)
+	}
+}
+
+func TestBuildNestedOperationsErrors(t *testing.T) {
+	parser := newTestParser(t, nestedOperationsSpec)
+	item := &common.FieldInfo{Properties: []common.FieldInfo{{Name: "uuid", GoType: common.TFTypeString}}}
+
+	tests := []struct {
+		name  string
+		field common.FieldInfo
+	}{
+		{"not a list", common.FieldInfo{Name: "rules", GoType: common.TFTypeString}},
+		{"required add field missing from items", common.FieldInfo{Name: "rules", GoType: common.TFTypeList, ItemSchema: item}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource := &config.Resource{
+				Name: "server",
+				NestedOperations: map[string]config.NestedOperationsConfig{
+					"rules": {Add: "servers_add_rule", Remove: "servers_remove_rule"},
+				},
+			}
+			if _, err := buildNestedOperations(parser, common.SchemaConfig{}, resource, []common.FieldInfo{tt.field}, nil); err == nil {
+				t.Error("buildNestedOperations() succeeded, want an error")
+			}
+		})
+	}
+}
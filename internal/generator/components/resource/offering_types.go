@@ -0,0 +1,35 @@
+package resource
+
+import (
+	"fmt"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/openapi"
+)
+
+// offeringRetrieveOperation reads the offering whose type selects the attribute block of an order
+const offeringRetrieveOperation = "marketplace_public_offerings_retrieve"
+
+// buildOfferingTypeBlocks returns the path of the offering read to check the attribute blocks of an order
+// resource with several offering types, and a validator keeping the blocks of different types apart.
+func buildOfferingTypeBlocks(parser *openapi.Parser, resource *config.Resource, createFields []common.FieldInfo) (string, []common.ConfigValidator, error) {
+	if len(resource.OfferingTypes) == 0 {
+		return "", nil, nil
+	}
+	_, path, _, err := parser.GetOperation(offeringRetrieveOperation)
+	if err != nil {
+		return "", nil, fmt.Errorf("offering types: %w", err)
+	}
+
+	var blocks []string
+	for _, f := range createFields {
+		if f.OfferingType != "" {
+			blocks = append(blocks, f.Name)
+		}
+	}
+	if len(blocks) < 2 {
+		return path, nil, nil
+	}
+	return path, []common.ConfigValidator{{Func: "Conflicting", Attrs: blocks}}, nil
+}
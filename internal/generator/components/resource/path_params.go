@@ -0,0 +1,58 @@
+package resource
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/openapi"
+)
+
+// buildPathParamFields returns the attributes filling the path parameters of a custom create
+// operation, described from the schema. Every path parameter must be mapped by path_params,
+// and every mapping must name a path parameter of the operation.
+func buildPathParamFields(parser *openapi.Parser, op *config.CreateOperationConfig) ([]common.FieldInfo, error) {
+	params, err := parser.GetOperationPathParams(op.OperationID)
+	if err != nil {
+		return nil, err
+	}
+
+	declared := make(map[string]bool)
+	var fields []common.FieldInfo
+	for _, param := range params {
+		declared[param.Name] = true
+		attr, ok := op.PathParams[param.Name]
+		if !ok {
+			return nil, fmt.Errorf("create_operation %s: path parameter %s is not mapped in path_params", op.OperationID, param.Name)
+		}
+		description := common.SanitizeString(param.Description)
+		if description == "" {
+			description = "Required path parameter"
+		}
+		// The value is substituted into the URL as text, whatever the parameter type
+		f := common.FieldInfo{
+			Name:        attr,
+			Type:        common.OpenAPITypeString,
+			Description: description,
+			GoType:      common.TFTypeString,
+			Required:    true,
+			IsPathParam: true,
+		}
+		common.CalculateSDKType(&f)
+		fields = append(fields, f)
+	}
+
+	var unknown []string
+	for name := range op.PathParams {
+		if !declared[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("create_operation %s: path_params %s are not path parameters of the operation", op.OperationID, strings.Join(unknown, ", "))
+	}
+	return fields, nil
+}
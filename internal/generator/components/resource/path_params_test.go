@@ -0,0 +1,75 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+)
+
+const pathParamsSpec = `
+openapi: 3.0.3
+info: {title: test, version: "1"}
+paths:
+  /api/networks/{uuid}/ports/{index}/create_subnet/:
+    post:
+      operationId: networks_create_subnet
+      parameters:
+        - {name: uuid, in: path, required: true, description: "UUID of the network.", schema: {type: string}}
+        - {name: index, in: path, required: true, schema: {type: integer}}
+      responses:
+        "201": {description: Created}
+`
+
+func TestBuildPathParamFields(t *testing.T) {
+	parser := newTestParser(t, pathParamsSpec)
+
+	fields, err := buildPathParamFields(parser, &config.CreateOperationConfig{
+		OperationID: "networks_create_subnet",
+		PathParams:  map[string]string{"uuid": "network", "index": "port_index"},
+	})
+	if err != nil {
+		t.Fatalf("buildPathParamFields() error = %v", err)
+	}
+	want := []struct{ name, description string }{
+		{"network", "UUID of the network."},
+		{"port_index", "Required path parameter"},
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("buildPathParamFields() returned %d fields, want %d", len(fields), len(want))
+	}
+	for i, f := range fields {
+		// Every parameter is a required string attribute, whatever its schema type
+		if f.Name != want[i].name || f.Description != want[i].description || f.GoType != common.TFTypeString || !f.Required || !f.IsPathParam {
+			t.Errorf("field %d = %+v, want a required string path parameter %s", i, f, want[i].name)
+		}
+	}
+}
+
+func TestBuildPathParamFieldsErrors(t *testing.T) {
+	parser := newTestParser(t, pathParamsSpec)
+
+	tests := []struct {
+		name string
+		op   config.CreateOperationConfig
+	}{
+		{"unmapped parameter", config.CreateOperationConfig{
+			OperationID: "networks_create_subnet",
+			PathParams:  map[string]string{"uuid": "network"},
+		}},
+		{"unknown parameter", config.CreateOperationConfig{
+			OperationID: "networks_create_subnet",
+			PathParams:  map[string]string{"uuid": "network", "index": "port_index", "tenant": "tenant"},
+		}},
+		{"unknown operation", config.CreateOperationConfig{
+			OperationID: "networks_create_port",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := buildPathParamFields(parser, &tt.op); err == nil {
+				t.Error("buildPathParamFields() succeeded, want an error")
+			}
+		})
+	}
+}
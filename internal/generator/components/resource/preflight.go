@@ -0,0 +1,99 @@
+package resource
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/openapi"
+)
+
+// buildPreflightChecks resolves configured preflight checks against the OpenAPI schema and model fields
+func buildPreflightChecks(parser *openapi.Parser, resource *config.Resource, modelFields []common.FieldInfo) ([]common.PreflightCheck, error) {
+	fieldTypes := modelFieldTypes(modelFields)
+
+	var checks []common.PreflightCheck
+	for _, cfg := range resource.Preflight {
+		_, path, method, err := parser.GetOperation(cfg.Operation)
+		if err != nil {
+			return nil, fmt.Errorf("preflight check: %w", err)
+		}
+		if method != http.MethodGet && method != http.MethodPost {
+			return nil, fmt.Errorf("preflight check %s: operation must be a GET or POST, got %s", cfg.Operation, method)
+		}
+
+		pathParams, err := parser.GetOperationPathParams(cfg.Operation)
+		if err != nil {
+			return nil, fmt.Errorf("preflight check %s: %w", cfg.Operation, err)
+		}
+		switch {
+		case len(pathParams) > 1 || (len(pathParams) == 1 && pathParams[0].Name != "uuid"):
+			return nil, fmt.Errorf("preflight check %s: only a {uuid} path parameter is supported", cfg.Operation)
+		case len(pathParams) == 1 && cfg.Parent == "":
+			return nil, fmt.Errorf("preflight check %s: parent is required for the {uuid} path parameter", cfg.Operation)
+		case len(pathParams) == 0 && cfg.Parent != "":
+			return nil, fmt.Errorf("preflight check %s: parent set but the operation has no {uuid} path parameter", cfg.Operation)
+		}
+
+		check := common.PreflightCheck{
+			Operation:    cfg.Operation,
+			Method:       method,
+			Path:         path,
+			SuccessField: cfg.SuccessField,
+			Message:      common.SanitizeString(cfg.Message),
+			OnUpdate:     cfg.OnUpdate,
+		}
+		attrs := make(map[string]bool)
+		if cfg.Parent != "" {
+			if fieldTypes[cfg.Parent] != common.TFTypeString {
+				return nil, fmt.Errorf("preflight check %s: parent must reference a string attribute, got %q", cfg.Operation, cfg.Parent)
+			}
+			check.ParentAttr = modelAttr(cfg.Parent)
+			attrs[check.ParentAttr] = true
+		}
+
+		check.Params, err = buildBodyParams(cfg.Params, fieldTypes)
+		if err != nil {
+			return nil, fmt.Errorf("preflight check %s: %w", cfg.Operation, err)
+		}
+		for _, p := range check.Params {
+			attrs[p.Attr] = true
+		}
+		if len(attrs) == 0 {
+			return nil, fmt.Errorf("preflight check %s: requires params or a parent", cfg.Operation)
+		}
+		for attr := range attrs {
+			check.Attrs = append(check.Attrs, attr)
+		}
+		sort.Strings(check.Attrs)
+		// Failures are reported on the attribute when it is the only one checked
+		if len(cfg.Params) == 1 && cfg.Parent == "" {
+			for _, attr := range cfg.Params {
+				check.ErrorAttribute = attr
+			}
+		}
+
+		if cfg.SuccessField != "" {
+			schema, err := parser.GetOperationResponseSchema(cfg.Operation)
+			if err != nil {
+				return nil, fmt.Errorf("preflight check %s: %w", cfg.Operation, err)
+			}
+			var prop *openapi3.SchemaRef
+			if schema != nil && schema.Value != nil {
+				prop = schema.Value.Properties[cfg.SuccessField]
+			}
+			if prop == nil || prop.Value == nil || !prop.Value.Type.Is(openapi3.TypeBoolean) {
+				return nil, fmt.Errorf("preflight check %s: success_field %s is not a boolean response field", cfg.Operation, cfg.SuccessField)
+			}
+			if check.Message == "" {
+				check.Message = fmt.Sprintf("The %s check failed for the planned values.", common.Humanize(cfg.Operation))
+			}
+		}
+
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
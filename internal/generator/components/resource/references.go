@@ -0,0 +1,45 @@
+package resource
+
+import (
+	"fmt"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/openapi"
+)
+
+// buildReferences returns the retrieve paths of the objects that top-level URL fields of the
+// requests refer to, keyed by field name. Paths come from the reference overrides, or are
+// detected from the names of uri fields.
+func buildReferences(parser *openapi.Parser, schemaCfg common.SchemaConfig, service string, fieldSets ...[]common.FieldInfo) (map[string]string, error) {
+	retrievePaths := parser.RetrievePaths()
+	references := make(map[string]string)
+	for _, fields := range fieldSets {
+		for _, f := range fields {
+			if _, ok := references[f.Name]; ok || f.GoType != common.TFTypeString || f.IsPathParam || f.ReadOnly {
+				continue
+			}
+			override := schemaCfg.FieldOverrides[f.Name]
+			switch override.Reference {
+			case config.ReferenceNone:
+			case "":
+				if f.Format == "uri" {
+					if path := common.FindReferencePath(retrievePaths, service, f.Name); path != "" {
+						references[f.Name] = path
+					}
+				}
+			default:
+				operationPath, ok := parser.OperationPath(override.Reference)
+				if !ok {
+					return nil, fmt.Errorf("field %s: reference operation %s not found", f.Name, override.Reference)
+				}
+				path, ok := common.ReferencePath(operationPath)
+				if !ok {
+					return nil, fmt.Errorf("field %s: reference operation %s must retrieve an object by a single path parameter", f.Name, override.Reference)
+				}
+				references[f.Name] = path
+			}
+		}
+	}
+	return references, nil
+}
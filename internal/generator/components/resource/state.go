@@ -0,0 +1,110 @@
+package resource
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+)
+
+// applySkipFieldsInState marks response fields (or nested paths within them) whose values
+// are kept from state instead of being refreshed from the API
+func applySkipFieldsInState(paths []string, responseFields []common.FieldInfo) error {
+	for _, path := range paths {
+		segments := strings.Split(path, ".")
+		idx := -1
+		for i := range responseFields {
+			if responseFields[i].Name == segments[0] && !responseFields[i].SchemaSkip && responseFields[i].JsonTag != "-" {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return fmt.Errorf("skip_fields_in_state: unknown field %s", segments[0])
+		}
+		root := &responseFields[idx]
+		if len(segments) == 1 {
+			root.KeepState = true
+			continue
+		}
+
+		f := *root
+		for _, segment := range segments[1:] {
+			var nested []common.FieldInfo
+			switch {
+			case f.GoType == common.TFTypeObject:
+				nested = f.Properties
+			case f.GoType == common.TFTypeList && f.ItemSchema != nil:
+				nested = f.ItemSchema.Properties
+			case f.GoType == common.TFTypeSet:
+				return fmt.Errorf("skip_fields_in_state: %s: elements of set %s cannot be kept from state", path, f.Name)
+			default:
+				return fmt.Errorf("skip_fields_in_state: %s: %s is not a nested attribute", path, f.Name)
+			}
+			next, ok := findField(nested, segment)
+			if !ok {
+				return fmt.Errorf("skip_fields_in_state: %s: unknown field %s", path, segment)
+			}
+			f = next
+		}
+		root.KeepStatePaths = append(root.KeepStatePaths, segments[1:])
+	}
+	return nil
+}
+
+// buildStateUpgraders returns the upgraders of every schema version prior to the current one, each applying
+// the renames and moves of its own and the later versions in order. Renamed and moved top-level attributes
+// must end up as attributes of the current schema.
+func buildStateUpgraders(version int64, upgrades []config.StateUpgradeConfig, attributes map[string]bool) ([]common.StateUpgrader, error) {
+	changes := make(map[int64]common.StateChange)
+	for _, u := range upgrades {
+		changes[u.Version] = common.StateChange{Renamed: u.Renamed, Moved: u.Moved}
+	}
+
+	for _, u := range upgrades {
+		for _, from := range slices.Sorted(maps.Keys(u.Renamed)) {
+			if strings.Contains(from, ".") {
+				continue
+			}
+			to := followStateChanges(u.Renamed[from], u.Version+1, version, changes)
+			if name, _, _ := strings.Cut(to, "."); !attributes[name] {
+				return nil, fmt.Errorf("state_upgrades: version %d: %s is renamed to %s, which is not an attribute", u.Version, from, to)
+			}
+		}
+		for _, from := range slices.Sorted(maps.Keys(u.Moved)) {
+			to := followStateChanges(u.Moved[from], u.Version+1, version, changes)
+			if name, _, _ := strings.Cut(to, "."); !attributes[name] {
+				return nil, fmt.Errorf("state_upgrades: version %d: %s is moved to %s, which is not an attribute", u.Version, from, to)
+			}
+		}
+	}
+
+	var upgraders []common.StateUpgrader
+	for v := int64(0); v < version; v++ {
+		upgrader := common.StateUpgrader{Version: v}
+		for next := v; next < version; next++ {
+			if c := changes[next]; len(c.Renamed) > 0 || len(c.Moved) > 0 {
+				upgrader.Changes = append(upgrader.Changes, c)
+			}
+		}
+		upgraders = append(upgraders, upgrader)
+	}
+	return upgraders, nil
+}
+
+// followStateChanges returns the path that an attribute at path in schema version from has in version to.
+// Within a version, renames are applied before moves.
+func followStateChanges(path string, from, to int64, changes map[int64]common.StateChange) string {
+	for next := from; next < to; next++ {
+		if name, ok := changes[next].Renamed[path]; ok {
+			path = path[:strings.LastIndex(path, ".")+1] + name
+		}
+		if moved, ok := changes[next].Moved[path]; ok {
+			path = moved
+		}
+	}
+	return path
+}
@@ -0,0 +1,57 @@
+package resource
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+)
+
+func TestBuildStateUpgraders(t *testing.T) {
+	attributes := map[string]bool{"flavor": true, "limits": true, "rules": true}
+	upgrades := []config.StateUpgradeConfig{
+		{Version: 0, Renamed: map[string]string{"flavor_name": "flavor_ref", "rules.port": "port_range"}},
+		{Version: 1, Moved: map[string]string{"flavor_ref": "flavor", "cores": "limits.cores"}},
+	}
+	upgraders, err := buildStateUpgraders(3, upgrades, attributes)
+	if err != nil {
+		t.Fatalf("buildStateUpgraders() error = %v", err)
+	}
+	version0 := common.StateChange{Renamed: upgrades[0].Renamed}
+	version1 := common.StateChange{Moved: upgrades[1].Moved}
+	want := []common.StateUpgrader{
+		{Version: 0, Changes: []common.StateChange{version0, version1}},
+		{Version: 1, Changes: []common.StateChange{version1}},
+		{Version: 2},
+	}
+	if !reflect.DeepEqual(upgraders, want) {
+		t.Errorf("buildStateUpgraders() = %+v, want %+v", upgraders, want)
+	}
+}
+
+func TestBuildStateUpgradersErrors(t *testing.T) {
+	attributes := map[string]bool{"flavor": true, "limits": true}
+	tests := []struct {
+		name     string
+		upgrades []config.StateUpgradeConfig
+	}{
+		{"renamed to an unknown attribute", []config.StateUpgradeConfig{
+			{Version: 0, Renamed: map[string]string{"flavor_name": "flavor_ref"}},
+		}},
+		{"moved into an unknown attribute", []config.StateUpgradeConfig{
+			{Version: 0, Moved: map[string]string{"cores": "quotas.cores"}},
+		}},
+		{"renamed, then moved away", []config.StateUpgradeConfig{
+			{Version: 0, Renamed: map[string]string{"flavor_name": "flavor"}},
+			{Version: 1, Moved: map[string]string{"flavor": "specs.flavor"}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := buildStateUpgraders(2, tt.upgrades, attributes); err == nil {
+				t.Error("buildStateUpgraders() succeeded, want an error")
+			}
+		})
+	}
+}
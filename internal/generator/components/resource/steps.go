@@ -0,0 +1,119 @@
+package resource
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/openapi"
+)
+
+// bodyValueMethods maps Terraform types to the accessor used to build request payloads
+var bodyValueMethods = map[string]string{
+	common.TFTypeString:  "ValueString",
+	common.TFTypeInt64:   "ValueInt64",
+	common.TFTypeFloat64: "ValueFloat64",
+	common.TFTypeBool:    "ValueBool",
+}
+
+// stepUUIDField returns the computed attribute that stores the UUID created by a step
+func stepUUIDField(step config.StepConfig) common.FieldInfo {
+	f := common.FieldInfo{
+		Name:               step.Name + "_uuid",
+		Type:               common.OpenAPITypeString,
+		Description:        fmt.Sprintf("UUID of the object created by the %s step", common.Humanize(step.Name)),
+		GoType:             common.TFTypeString,
+		ReadOnly:           true,
+		ServerComputed:     true,
+		UseStateForUnknown: true,
+	}
+	common.CalculateSDKType(&f)
+	return f
+}
+
+// modelAttr returns the Go field name of a model attribute
+func modelAttr(name string) string {
+	if name == "" || name == "uuid" {
+		return "UUID"
+	}
+	return common.ToTitle(name)
+}
+
+// modelFieldTypes maps model attribute names to their Terraform types
+func modelFieldTypes(modelFields []common.FieldInfo) map[string]string {
+	fieldTypes := map[string]string{"uuid": common.TFTypeString}
+	for _, f := range modelFields {
+		fieldTypes[f.Name] = f.GoType
+	}
+	return fieldTypes
+}
+
+// buildBodyParams resolves request body keys mapped to primitive model attributes
+func buildBodyParams(params map[string]string, fieldTypes map[string]string) ([]common.BodyParam, error) {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var result []common.BodyParam
+	for _, key := range keys {
+		attr := params[key]
+		method, ok := bodyValueMethods[fieldTypes[attr]]
+		if !ok {
+			return nil, fmt.Errorf("param %s must reference a primitive attribute, got %q", key, attr)
+		}
+		result = append(result, common.BodyParam{Key: key, Attr: modelAttr(attr), ValueMethod: method})
+	}
+	return result, nil
+}
+
+// buildSteps resolves configured creation steps against the OpenAPI schema and model fields
+func buildSteps(parser *openapi.Parser, resource *config.Resource, modelFields []common.FieldInfo) ([]common.ResourceStep, error) {
+	fieldTypes := modelFieldTypes(modelFields)
+
+	var steps []common.ResourceStep
+	for i, cfg := range resource.Steps {
+		_, path, method, err := parser.GetOperation(cfg.Operation)
+		if err != nil {
+			return nil, fmt.Errorf("step %s: %w", cfg.Name, err)
+		}
+		if method != http.MethodPost {
+			return nil, fmt.Errorf("step %s: operation %s must be a POST, got %s", cfg.Name, cfg.Operation, method)
+		}
+		if cfg.Parent != "" {
+			if _, ok := fieldTypes[cfg.Parent]; !ok {
+				return nil, fmt.Errorf("step %s: unknown parent attribute %s", cfg.Name, cfg.Parent)
+			}
+		}
+
+		step := common.ResourceStep{
+			Index:      i,
+			Name:       cfg.Name,
+			Path:       path,
+			ParentAttr: modelAttr(cfg.Parent),
+			UUIDAttr:   common.ToTitle(cfg.Name + "_uuid"),
+		}
+
+		step.Params, err = buildBodyParams(cfg.Params, fieldTypes)
+		if err != nil {
+			return nil, fmt.Errorf("step %s: %w", cfg.Name, err)
+		}
+
+		if cfg.RollbackOperation != "" {
+			_, rollbackPath, rollbackMethod, err := parser.GetOperation(cfg.RollbackOperation)
+			if err != nil {
+				return nil, fmt.Errorf("step %s: %w", cfg.Name, err)
+			}
+			step.RollbackPath = rollbackPath
+			step.RollbackMethod = rollbackMethod
+		}
+
+		steps = append(steps, step)
+		// Later steps may reference the UUID created by this one
+		fieldTypes[cfg.Name+"_uuid"] = common.TFTypeString
+	}
+	return steps, nil
+}
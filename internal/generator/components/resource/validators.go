@@ -0,0 +1,43 @@
+package resource
+
+import (
+	"fmt"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+)
+
+// buildConfigValidators maps configured validator groups to resourcevalidator functions,
+// checking that every referenced attribute exists and is configurable
+func buildConfigValidators(cfg *config.ValidatorsConfig, modelFields []common.FieldInfo) ([]common.ConfigValidator, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	kinds := []struct {
+		name     string
+		funcName string
+		groups   [][]string
+	}{
+		{"conflicts_with", "Conflicting", cfg.ConflictsWith},
+		{"required_with", "RequiredTogether", cfg.RequiredWith},
+		{"exactly_one_of", "ExactlyOneOf", cfg.ExactlyOneOf},
+		{"at_least_one_of", "AtLeastOneOf", cfg.AtLeastOneOf},
+	}
+
+	var validators []common.ConfigValidator
+	for _, kind := range kinds {
+		for _, attrs := range kind.groups {
+			for _, attr := range attrs {
+				f, ok := findField(modelFields, attr)
+				if !ok || f.SchemaSkip {
+					return nil, fmt.Errorf("validators.%s: unknown attribute %s", kind.name, attr)
+				}
+				if f.ReadOnly {
+					return nil, fmt.Errorf("validators.%s: attribute %s is read-only", kind.name, attr)
+				}
+			}
+			validators = append(validators, common.ConfigValidator{Func: kind.funcName, Attrs: attrs})
+		}
+	}
+	return validators, nil
+}
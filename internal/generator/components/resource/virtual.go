@@ -0,0 +1,141 @@
+package resource
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+)
+
+// virtualSegmentPattern matches one segment of a virtual field expression, e.g. "ports" or "ports[0]"
+var virtualSegmentPattern = regexp.MustCompile(`^([A-Za-z0-9_]+)(?:\[(\d+)\])?$`)
+
+// virtualValueFuncs maps primitive OpenAPI types to the constructor of their Terraform value
+var virtualValueFuncs = map[string]string{
+	common.OpenAPITypeString:  "types.StringValue",
+	common.OpenAPITypeInteger: "types.Int64Value",
+	common.OpenAPITypeBoolean: "types.BoolValue",
+	common.OpenAPITypeNumber:  "types.Float64Value",
+}
+
+// findField returns the field with the given name
+func findField(fields []common.FieldInfo, name string) (common.FieldInfo, bool) {
+	for _, f := range fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return common.FieldInfo{}, false
+}
+
+// buildVirtualField resolves a virtual field expression against the response fields,
+// producing nil- and bounds-checked accessors into the API response
+func buildVirtualField(cfg config.VirtualFieldConfig, responseFields []common.FieldInfo) (common.VirtualField, error) {
+	fields := responseFields
+	expr := "apiResp"
+	var steps []common.VirtualStep
+	var leafType, value string
+
+	segments := strings.Split(cfg.Expression, ".")
+	for i, segment := range segments {
+		m := virtualSegmentPattern.FindStringSubmatch(segment)
+		if m == nil {
+			return common.VirtualField{}, fmt.Errorf("invalid expression segment %q", segment)
+		}
+		f, ok := findField(fields, m[1])
+		if !ok || f.JsonTag == "-" {
+			return common.VirtualField{}, fmt.Errorf("unknown field %q in expression %q", m[1], cfg.Expression)
+		}
+
+		v := fmt.Sprintf("v%d", i)
+		access := expr + "." + common.ToTitle(f.Name)
+		last := i == len(segments)-1
+
+		if m[2] != "" {
+			if f.Type != common.OpenAPITypeArray {
+				return common.VirtualField{}, fmt.Errorf("field %q is not a list", f.Name)
+			}
+			index, _ := strconv.Atoi(m[2])
+			slice := v
+			if f.IsPointer {
+				slice = "(*" + v + ")"
+				steps = append(steps, common.VirtualStep{Var: v, Expr: access, Cond: fmt.Sprintf("%s != nil && len(*%s) > %d", v, v, index)})
+			} else {
+				steps = append(steps, common.VirtualStep{Var: v, Expr: access, Cond: fmt.Sprintf("len(%s) > %d", v, index)})
+			}
+			elem := fmt.Sprintf("%s[%d]", slice, index)
+
+			if f.ItemType == common.OpenAPITypeObject && f.ItemSchema != nil {
+				if last {
+					return common.VirtualField{}, fmt.Errorf("expression %q must end with a primitive field", cfg.Expression)
+				}
+				fields = f.ItemSchema.Properties
+				expr = elem
+				continue
+			}
+			if !last {
+				return common.VirtualField{}, fmt.Errorf("field %q has no nested fields", f.Name)
+			}
+			leafType = f.ItemType
+			value = elem
+			continue
+		}
+
+		switch f.Type {
+		case common.OpenAPITypeObject:
+			if last || f.GoType == common.TFTypeMap {
+				return common.VirtualField{}, fmt.Errorf("expression %q must end with a primitive field", cfg.Expression)
+			}
+			steps = append(steps, common.VirtualStep{Var: v, Expr: access, Cond: v + " != nil"})
+			fields = f.Properties
+			expr = v
+		case common.OpenAPITypeArray:
+			return common.VirtualField{}, fmt.Errorf("list field %q requires an index", f.Name)
+		case common.OpenAPITypeNumber:
+			if !last {
+				return common.VirtualField{}, fmt.Errorf("field %q has no nested fields", f.Name)
+			}
+			// Response numbers are decoded as common.FlexibleNumber values
+			steps = append(steps, common.VirtualStep{Var: v, Expr: access + ".Float64Ptr()", Cond: v + " != nil"})
+			leafType = f.Type
+			value = "*" + v
+		default:
+			if !last {
+				return common.VirtualField{}, fmt.Errorf("field %q has no nested fields", f.Name)
+			}
+			steps = append(steps, common.VirtualStep{Var: v, Expr: access, Cond: v + " != nil"})
+			leafType = f.Type
+			value = "*" + v
+		}
+	}
+
+	valueFunc, ok := virtualValueFuncs[leafType]
+	if !ok {
+		return common.VirtualField{}, fmt.Errorf("expression %q must end with a primitive field", cfg.Expression)
+	}
+	if cfg.Type != "" && cfg.Type != leafType {
+		return common.VirtualField{}, fmt.Errorf("expression %q has type %s, not %s", cfg.Expression, leafType, cfg.Type)
+	}
+
+	description := common.SanitizeString(cfg.Description)
+	if description == "" {
+		description = common.Humanize(cfg.Name)
+	}
+	field := common.FieldInfo{
+		Name:        cfg.Name,
+		Type:        leafType,
+		Description: description,
+		GoType:      common.GetGoType(leafType),
+		ReadOnly:    true,
+	}
+	common.CalculateSDKType(&field)
+
+	return common.VirtualField{
+		FieldInfo: field,
+		Steps:     steps,
+		Value:     fmt.Sprintf("%s(%s)", valueFunc, value),
+	}, nil
+}
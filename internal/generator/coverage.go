@@ -0,0 +1,131 @@
+package generator
+
+import (
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/openapi"
+)
+
+// Ratio counts covered items out of a total
+type Ratio struct {
+	Covered int
+	Total   int
+}
+
+// Percent returns the covered share in percent, or 0 when there is nothing to cover
+func (r Ratio) Percent() float64 {
+	if r.Total == 0 {
+		return 0
+	}
+	return float64(r.Covered) * 100 / float64(r.Total)
+}
+
+// TagCoverage is the operation coverage of one OpenAPI tag
+type TagCoverage struct {
+	Tag        string
+	Operations Ratio
+}
+
+// CoverageReport summarizes how much of an OpenAPI schema a configuration uses
+type CoverageReport struct {
+	Operations Ratio
+	Schemas    Ratio         // Component schemas used by the request and response bodies of covered operations
+	Tags       []TagCoverage // Sorted by tag; operations are counted under their first tag
+	Candidates []string      // Uncovered collections with list, create, retrieve and destroy operations
+}
+
+// untaggedOperations groups the operations without tags in a coverage report
+const untaggedOperations = "(untagged)"
+
+// Coverage reports which operations and component schemas are used by the configured
+// resources and data sources
+func Coverage(cfg *config.Config, parser *openapi.Parser) CoverageReport {
+	covered := make(map[string]bool)
+	configured := make(map[string]bool)
+	var bodies []*openapi3.SchemaRef
+	for i := range cfg.Resources {
+		resource := &cfg.Resources[i]
+		configured[resource.BaseOperationID] = true
+		for _, opID := range resourceOperationIDs(resource) {
+			covered[opID] = true
+		}
+		// Order resources are created from their offering's order attributes
+		if resource.Plugin == "order" {
+			for _, name := range resource.OrderAttributeSchemas() {
+				if schema, err := parser.GetSchema(name); err == nil {
+					bodies = append(bodies, schema)
+				}
+			}
+		}
+	}
+	for _, dataSource := range cfg.DataSources {
+		configured[dataSource.BaseOperationID] = true
+		ops := dataSource.OperationIDs()
+		covered[ops.List] = true
+		covered[ops.Retrieve] = true
+	}
+
+	var report CoverageReport
+	tags := make(map[string]*Ratio)
+	operationIDs := parser.OperationIDs()
+	for _, opID := range operationIDs {
+		info, _ := parser.LookupOperation(opID)
+		tag := untaggedOperations
+		if len(info.Operation.Tags) > 0 {
+			tag = info.Operation.Tags[0]
+		}
+		if tags[tag] == nil {
+			tags[tag] = &Ratio{}
+		}
+		tags[tag].Total++
+		report.Operations.Total++
+		if covered[opID] {
+			tags[tag].Covered++
+			report.Operations.Covered++
+			bodies = append(bodies, operationBodySchemas(info.Operation)...)
+		}
+	}
+
+	for tag, ratio := range tags {
+		report.Tags = append(report.Tags, TagCoverage{Tag: tag, Operations: *ratio})
+	}
+	sort.Slice(report.Tags, func(i, j int) bool { return report.Tags[i].Tag < report.Tags[j].Tag })
+
+	components := parser.Document().Components.Schemas
+	report.Schemas.Total = len(components)
+	for name := range common.ReferencedSchemas(bodies) {
+		if _, ok := components[name]; ok {
+			report.Schemas.Covered++
+		}
+	}
+
+	report.Candidates = common.CRUDCandidates(operationIDs, configured)
+	return report
+}
+
+// operationBodySchemas returns the schemas of an operation's request and response bodies
+func operationBodySchemas(op *openapi3.Operation) []*openapi3.SchemaRef {
+	var schemas []*openapi3.SchemaRef
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		for _, media := range op.RequestBody.Value.Content {
+			if media != nil {
+				schemas = append(schemas, media.Schema)
+			}
+		}
+	}
+	if op.Responses != nil {
+		for _, resp := range op.Responses.Map() {
+			if resp.Value != nil {
+				for _, media := range resp.Value.Content {
+					if media != nil {
+						schemas = append(schemas, media.Schema)
+					}
+				}
+			}
+		}
+	}
+	return schemas
+}
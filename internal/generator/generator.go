@@ -3,7 +3,10 @@ package generator
 import (
 	"embed"
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
 	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
 	actgen "github.com/waldur/terraform-provider-waldur-generator/internal/generator/components/action"
@@ -22,6 +25,12 @@ type Generator struct {
 	parser        *openapi.Parser
 	Resources     map[string]*common.ResourceData
 	ResourceOrder []string
+
+	reportedCycles    map[string]bool    // Circular schema references already warned about
+	reportedConflicts map[string]bool    // Conflicting allOf property definitions already warned about
+	reportedPatterns  map[string]bool    // Invalid regular expressions already warned about
+	auth              common.Auth        // Authentication of the generated client and provider
+	sharedStructs     []common.FieldInfo // Structures whose attribute type helpers are declared in the common package
 }
 
 // New creates a new generator instance
@@ -30,6 +39,10 @@ func New(cfg *config.Config, parser *openapi.Parser) *Generator {
 		config:    cfg,
 		parser:    parser,
 		Resources: make(map[string]*common.ResourceData),
+
+		reportedCycles:    make(map[string]bool),
+		reportedConflicts: make(map[string]bool),
+		reportedPatterns:  make(map[string]bool),
 	}
 }
 
@@ -39,6 +52,18 @@ func (g *Generator) Generate() error {
 	if err := g.validateOperations(); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
+	g.warnDeprecatedOperations()
+
+	// Authentication follows the security schemes of the schema
+	var securitySchemes openapi3.SecuritySchemes
+	if components := g.parser.Document().Components; components != nil {
+		securitySchemes = components.SecuritySchemes
+	}
+	var unsupported []string
+	g.auth, unsupported = common.NewAuth(securitySchemes)
+	for _, name := range unsupported {
+		fmt.Printf("Warning: security scheme %s is not supported by the generated client\n", name)
+	}
 
 	// Create output directory structure
 	if err := g.createDirectoryStructure(); err != nil {
@@ -58,16 +83,42 @@ func (g *Generator) Generate() error {
 
 	for i := range g.config.DataSources {
 		ds := &g.config.DataSources[i]
-		dd, err := dsgen.PrepareData(g.parser, ds, g.GetSchemaConfig())
+		dsCfg := g.dataSourceSchemaConfig(ds)
+		dd, err := dsgen.PrepareData(g.config, g.parser, ds, dsCfg)
 		if err != nil {
 			return err
 		}
 
-		if existing, ok := g.Resources[ds.Name]; ok {
-			// Merge datasource fields into existing resource data
-			existing.ResponseFields = common.MergeFields(existing.ResponseFields, dd.ResponseFields)
-			existing.ModelFields = common.MergeFields(existing.ModelFields, dd.ModelFields)
-			existing.HasDataSource = true
+		if existing, ok := g.Resources[ds.ResourceName()]; ok {
+			// Merge datasource fields into existing resource data. Fields the resource keeps
+			// from the request are not read back from responses, and fixed fields stay hidden.
+			fixed := func(f common.FieldInfo) bool {
+				return slices.ContainsFunc(existing.CreateFields, func(c common.FieldInfo) bool {
+					return c.Name == f.Name && c.FixedValue != ""
+				})
+			}
+			responseFields := slices.DeleteFunc(slices.Clone(dd.ResponseFields), func(f common.FieldInfo) bool {
+				return fixed(f) || slices.ContainsFunc(existing.ModelFields, func(m common.FieldInfo) bool {
+					return m.Name == f.Name && m.TypeConflict == config.PreferRequest
+				})
+			})
+			modelFields := slices.DeleteFunc(slices.Clone(dd.ModelFields), fixed)
+			existing.ResponseFields = common.MergeFields(dsCfg, existing.ResponseFields, responseFields)
+			existing.ModelFields = common.MergeFields(dsCfg, existing.ModelFields, modelFields)
+			if g.config.DataSourceEnabled(ds) {
+				if ds.Ephemeral {
+					existing.EphemeralNames = append(existing.EphemeralNames, ds.Name)
+				} else {
+					existing.HasDataSource = true
+					existing.DataSourceNames = append(existing.DataSourceNames, ds.Name)
+					if ds.List {
+						existing.ListDataSourceNames = append(existing.ListDataSourceNames, ds.Name)
+					}
+				}
+			}
+			if existing.Ordering == nil {
+				existing.Ordering = dd.Ordering
+			}
 			if dd.APIPaths != nil {
 				if existing.APIPaths == nil {
 					existing.APIPaths = make(map[string]string)
@@ -79,11 +130,37 @@ func (g *Generator) Generate() error {
 				}
 			}
 		} else {
+			if ds.Ephemeral {
+				dd.HasDataSource = false
+				dd.EphemeralNames = []string{ds.Name}
+			} else {
+				dd.DataSourceNames = []string{ds.Name}
+				if ds.List {
+					dd.ListDataSourceNames = []string{ds.Name}
+				}
+			}
 			g.Resources[ds.Name] = dd
 			g.ResourceOrder = append(g.ResourceOrder, ds.Name)
 		}
 	}
 
+	// Attribute types of components used by several resources are declared once
+	resources := make([]*common.ResourceData, 0, len(g.ResourceOrder))
+	for _, name := range g.ResourceOrder {
+		resources = append(resources, g.Resources[name])
+	}
+	resourceTypes := g.resourceTypes(resources)
+	for _, rd := range resources {
+		common.AddResourceHints(rd.ModelFields, resourceTypes)
+		common.AddResourceHints(rd.ResponseFields, resourceTypes)
+	}
+	g.sharedStructs = common.ShareAttrTypes(resources)
+
+	// Server metadata is grouped into lifecycle_meta, whether the response fields include it or not
+	for _, rd := range resources {
+		rd.LifecycleFields = common.LifecycleFields(rd.ResponseFields)
+	}
+
 	// 2. Generate provider files
 	if err := g.generateProvider(); err != nil {
 		return fmt.Errorf("failed to generate provider: %w", err)
@@ -121,8 +198,11 @@ func (g *Generator) Generate() error {
 				if err := resgen.GenerateImplementation(g.config, g, rd); err != nil {
 					return fmt.Errorf("failed to generate resource implementation %s: %w", name, err)
 				}
-				if err := lsgen.GenerateImplementation(g.config, g, rd); err != nil {
-					fmt.Printf("Warning: failed to generate list resource %s: %s\n", name, err)
+				// Bulk and members link resources group objects that are listed on their own
+				if configRes.Plugin != "bulk" && configRes.Members == nil {
+					if err := lsgen.GenerateImplementation(g.config, g, rd); err != nil {
+						fmt.Printf("Warning: failed to generate list resource %s: %s\n", name, err)
+					}
 				}
 
 				// Actions
@@ -134,11 +214,22 @@ func (g *Generator) Generate() error {
 			}
 		}
 
-		// If it has a datasource configuration, generate it
+		// Generate the data sources and ephemeral resources sharing this entity's SDK
 		for i := range g.config.DataSources {
-			if g.config.DataSources[i].Name == name {
-				if err := dsgen.GenerateImplementation(g.config, g, rd, &g.config.DataSources[i]); err != nil {
-					return fmt.Errorf("failed to generate data source %s: %w", name, err)
+			ds := &g.config.DataSources[i]
+			if slices.Contains(rd.DataSourceNames, ds.Name) {
+				if err := dsgen.GenerateImplementation(g.config, g, rd, ds); err != nil {
+					return fmt.Errorf("failed to generate data source %s: %w", ds.Name, err)
+				}
+			}
+			if slices.Contains(rd.ListDataSourceNames, ds.Name) {
+				if err := dsgen.GenerateListImplementation(g.config, g, rd, ds); err != nil {
+					return fmt.Errorf("failed to generate list data source %s: %w", ds.Name, err)
+				}
+			}
+			if slices.Contains(rd.EphemeralNames, ds.Name) {
+				if err := dsgen.GenerateEphemeralImplementation(g.config, g, rd, ds); err != nil {
+					return fmt.Errorf("failed to generate ephemeral resource %s: %w", ds.Name, err)
 				}
 			}
 		}
@@ -183,10 +274,32 @@ func (g *Generator) Generate() error {
 }
 
 func (g *Generator) hasDataSource(resourceName string) bool {
-	for _, ds := range g.config.DataSources {
-		if ds.Name == resourceName {
+	for i := range g.config.DataSources {
+		ds := &g.config.DataSources[i]
+		if ds.ResourceName() == resourceName && !ds.Ephemeral && g.config.DataSourceEnabled(ds) {
 			return true
 		}
 	}
 	return false
 }
+
+// resourceTypes maps the object names of the generated resources (e.g., "project") to their
+// full Terraform type names. Names shared by resources of several services are left out.
+func (g *Generator) resourceTypes(resources []*common.ResourceData) map[string]string {
+	types := make(map[string]string)
+	ambiguous := make(map[string]bool)
+	for _, rd := range resources {
+		if rd.IsDatasourceOnly || rd.Plugin == "actions" {
+			continue
+		}
+		name := strings.TrimSuffix(rd.CleanName, "_")
+		if _, exists := types[name]; exists {
+			ambiguous[name] = true
+		}
+		types[name] = g.config.Generator.ProviderName + "_" + rd.TypeName
+	}
+	for name := range ambiguous {
+		delete(types, name)
+	}
+	return types
+}
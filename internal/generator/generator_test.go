@@ -0,0 +1,233 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	goparser "go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+	dsgen "github.com/waldur/terraform-provider-waldur-generator/internal/generator/components/datasource"
+	resgen "github.com/waldur/terraform-provider-waldur-generator/internal/generator/components/resource"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/openapi"
+)
+
+// generate runs the generator with the repository configuration and schema and returns the generated files by path
+func generate(t *testing.T) map[string][]byte {
+	t.Helper()
+	cfg, err := config.LoadConfig("config.yaml")
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	cfg.Generator.OutputDir = t.TempDir()
+
+	parser, err := openapi.NewParser(openapi.FetchOptions{}, cfg.Generator.OpenAPISchema)
+	if err != nil {
+		t.Fatalf("NewParser failed: %v", err)
+	}
+	if err := New(cfg, parser).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	files := make(map[string][]byte)
+	err = filepath.WalkDir(cfg.Generator.OutputDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(cfg.Generator.OutputDir, path)
+		files[rel] = content
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("reading generated files failed: %v", err)
+	}
+	return files
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	if testing.Short() {
+		t.Skip("generates the whole provider")
+	}
+	t.Chdir("../..") // The generator reads the schema and LICENSE relative to the repository root
+	first, second := generate(t), generate(t)
+
+	if len(first) == 0 {
+		t.Fatal("no files generated")
+	}
+	for path, content := range first {
+		other, ok := second[path]
+		if !ok {
+			t.Errorf("%s was only generated by the first run", path)
+		} else if !bytes.Equal(content, other) {
+			t.Errorf("%s differs between runs", path)
+		}
+	}
+	for path := range second {
+		if _, ok := first[path]; !ok {
+			t.Errorf("%s was only generated by the second run", path)
+		}
+	}
+}
+
+func TestGenerateEphemeralImplementation(t *testing.T) {
+	t.Chdir("../..")
+	parser, err := openapi.NewParser(openapi.FetchOptions{}, "waldur_api.yaml")
+	if err != nil {
+		t.Fatalf("NewParser failed: %v", err)
+	}
+	cfg := &config.Config{Generator: config.GeneratorConfig{ProviderName: "waldur", OutputDir: t.TempDir()}}
+	g := New(cfg, parser)
+
+	ds := &config.DataSource{Name: "core_user_token", BaseOperationID: "users_token", Ephemeral: true}
+	rd, err := dsgen.PrepareData(cfg, parser, ds, g.dataSourceSchemaConfig(ds))
+	if err != nil {
+		t.Fatalf("PrepareData failed: %v", err)
+	}
+	if err := dsgen.GenerateEphemeralImplementation(cfg, g, rd, ds); err != nil {
+		t.Fatalf("GenerateEphemeralImplementation failed: %v", err)
+	}
+
+	path := filepath.Join(cfg.Generator.OutputDir, "services", rd.Service, rd.CleanName, "ephemeral.go")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading generated file failed: %v", err)
+	}
+	if _, err := goparser.ParseFile(token.NewFileSet(), path, content, 0); err != nil {
+		t.Fatalf("generated ephemeral resource is not valid Go: %v", err)
+	}
+	// The lookup is shared with data sources, including the direct read by uuid
+	for _, want := range []string{
+		"func (e *CoreUserTokenEphemeralResource) Open(",
+		`"uuid": schema.StringAttribute{`,
+		"uuid := data.LookupUUID.ValueString()",
+		"e.client.Get(ctx, uuid)",
+		"resp.Result.Set(ctx, &data)",
+	} {
+		if !bytes.Contains(content, []byte(want)) {
+			t.Errorf("generated ephemeral resource does not contain %q", want)
+		}
+	}
+}
+
+func TestObjectPaths(t *testing.T) {
+	cfg := &config.Config{Generator: config.GeneratorConfig{ProviderName: "waldur", OutputDir: t.TempDir()}}
+	g := New(cfg, nil)
+	for _, rd := range []*common.ResourceData{
+		{Name: "structure_project", TypeName: "structure_project", APIPaths: map[string]string{"Retrieve": "/api/projects/{uuid}/"}},
+		// A type name starting with the provider name, e.g. from naming.type_names
+		{Name: "waldur_offering", TypeName: "waldur_offering", APIPaths: map[string]string{"Retrieve": "/api/marketplace-offerings/{uuid}/"}},
+		{Name: "offering", TypeName: "offering", APIPaths: map[string]string{"Retrieve": "/api/offerings/{uuid}/"}},
+		{Name: "core_user_token", TypeName: "core_user_token", IsDatasourceOnly: true, APIPaths: map[string]string{"Retrieve": "/api/users/{uuid}/token/"}},
+		{Name: "instance_actions", TypeName: "instance_actions", Plugin: "actions", APIPaths: map[string]string{"Retrieve": "/api/instances/{uuid}/"}},
+		{Name: "project_link", TypeName: "project_link", Source: &config.LinkResourceConfig{}, APIPaths: map[string]string{"Retrieve": "/api/links/{uuid}/"}},
+		{Name: "project_role", TypeName: "project_role", APIPaths: map[string]string{"Retrieve": "/api/projects/{project_uuid}/roles/{uuid}/"}},
+	} {
+		g.Resources[rd.Name] = rd
+		g.ResourceOrder = append(g.ResourceOrder, rd.Name)
+	}
+
+	want := []ObjectPath{
+		{Kind: "offering", Path: "/api/offerings/{uuid}/"}, // waldur_offering is the kind of another object
+		{Kind: "structure_project", TypeName: "waldur_structure_project", Path: "/api/projects/{uuid}/"},
+		{Kind: "waldur_offering", TypeName: "waldur_waldur_offering", Path: "/api/marketplace-offerings/{uuid}/"},
+	}
+	if got := g.objectPaths(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("objectPaths() = %+v, want %+v", got, want)
+	}
+
+	// Kinds are resolved with or without the provider prefix
+	if err := g.generateProvider(); err != nil {
+		t.Fatalf("generateProvider failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(cfg.Generator.OutputDir, "internal", "provider", "functions.go"))
+	if err != nil {
+		t.Fatalf("reading generated file failed: %v", err)
+	}
+	functions := strings.Join(strings.Fields(string(content)), " ") // Ignores the alignment of the map
+	for _, want := range []string{
+		`"structure_project": "/api/projects/{uuid}/",`,
+		`"waldur_structure_project": "/api/projects/{uuid}/",`,
+		`"waldur_offering": "/api/marketplace-offerings/{uuid}/",`,
+		`"waldur_waldur_offering": "/api/marketplace-offerings/{uuid}/",`,
+		`"offering": "/api/offerings/{uuid}/",`,
+		`resp.Name = "path_from_uuid"`,
+	} {
+		if !strings.Contains(functions, want) {
+			t.Errorf("generated functions do not contain %q", want)
+		}
+	}
+}
+
+func TestGenerateUpdateSendsChangedAttributes(t *testing.T) {
+	t.Chdir("../..")
+	parser, err := openapi.NewParser(openapi.FetchOptions{}, "waldur_api.yaml")
+	if err != nil {
+		t.Fatalf("NewParser failed: %v", err)
+	}
+	cfg := &config.Config{Generator: config.GeneratorConfig{ProviderName: "waldur", OutputDir: t.TempDir()}}
+	g := New(cfg, parser)
+
+	res := &config.Resource{
+		Name:            "openstack_subnet",
+		BaseOperationID: "openstack_subnets",
+		CreateOperation: &config.CreateOperationConfig{
+			OperationID: "openstack_networks_create_subnet",
+			PathParams:  map[string]string{"uuid": "network"},
+		},
+	}
+	rd, err := resgen.PrepareData(cfg, parser, res, g.hasDataSource, g.GetSchemaConfig)
+	if err != nil {
+		t.Fatalf("PrepareData failed: %v", err)
+	}
+	if err := resgen.GenerateImplementation(cfg, g, rd); err != nil {
+		t.Fatalf("GenerateImplementation failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(cfg.Generator.OutputDir, "services", rd.Service, rd.CleanName, "resource.go"))
+	if err != nil {
+		t.Fatalf("reading generated file failed: %v", err)
+	}
+
+	// Every field of the update request, scalar or complex, is only set when its attribute changed
+	code := string(content)
+	start := strings.Index(code, "requestBody := OpenstackSubnetUpdateRequest{}")
+	end := strings.Index(code, "if anyChanges {")
+	if start < 0 || end < start {
+		t.Fatal("generated resource has no update request")
+	}
+	assignment := regexp.MustCompile(`requestBody\.(\w+)`)
+	guard := ""
+	var fields []string
+	for _, line := range strings.Split(code[start:end], "\n")[1:] {
+		if strings.HasPrefix(line, "\tif ") {
+			guard = line
+			continue
+		}
+		m := assignment.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		fields = append(fields, m[1])
+		want := fmt.Sprintf("!data.%s.IsUnknown() && !data.%[1]s.Equal(state.%[1]s)", m[1])
+		if !strings.Contains(guard, want) {
+			t.Errorf("requestBody.%s is set under %q, want a check that the attribute changed", m[1], strings.TrimSpace(guard))
+		}
+	}
+	for _, want := range []string{"Name", "AllocationPools", "HostRoutes"} {
+		if !slices.Contains(fields, want) {
+			t.Errorf("update request does not set %s, got %v", want, fields)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"text/template"
 
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
 	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
 )
 
@@ -49,7 +50,27 @@ func (g *Generator) GetSchemaConfig() common.SchemaConfig {
 		setMap[f] = true
 	}
 	return common.SchemaConfig{
-		ExcludedFields: excludedMap,
-		SetFields:      setMap,
+		ExcludedFields:    excludedMap,
+		SetFields:         setMap,
+		UnionStrategy:     g.config.Generator.UnionStrategy,
+		CircularRefs:      g.config.Generator.CircularRefs,
+		ReportedCycles:    g.reportedCycles,
+		ReportedConflicts: g.reportedConflicts,
+		ReportedPatterns:  g.reportedPatterns,
+		IgnoreDefaults:    g.config.Generator.IgnoreDefaults,
+		DynamicObjects:    g.config.Generator.DynamicObjects,
+		TypeCoercions:     g.config.Generator.TypeCoercions,
 	}
 }
+
+// dataSourceSchemaConfig returns the schema configuration of the resource whose SDK the data source shares,
+// so that both extract the same fields with the same types
+func (g *Generator) dataSourceSchemaConfig(ds *config.DataSource) common.SchemaConfig {
+	schemaCfg := g.GetSchemaConfig()
+	for i := range g.config.Resources {
+		if g.config.Resources[i].Name == ds.ResourceName() {
+			return schemaCfg.ForResource(&g.config.Resources[i])
+		}
+	}
+	return schemaCfg
+}
@@ -0,0 +1,101 @@
+package bulk
+
+import (
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/plugins"
+)
+
+// ItemsAttribute is the computed attribute listing the objects created by a bulk resource
+const ItemsAttribute = "items"
+
+// BulkBuilder implements ResourceBuilder for resources creating several objects with one bulk request
+type BulkBuilder struct {
+	plugins.BaseBuilder
+}
+
+// operationID returns the configured bulk create operation, or the one detected from the base operation ID
+func (b *BulkBuilder) operationID() (string, error) {
+	if b.Resource.BulkOperation != "" {
+		return b.Resource.BulkOperation, nil
+	}
+	if operationID, ok := b.Parser.FindBulkCreateOperation(b.Resource.BaseOperationID); ok {
+		return operationID, nil
+	}
+	return "", fmt.Errorf("resource %s: no bulk create operation found for %s, set bulk_operation", b.Resource.Name, b.Resource.BaseOperationID)
+}
+
+func (b *BulkBuilder) BuildCreateFields() ([]common.FieldInfo, error) {
+	operationID, err := b.operationID()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := b.Parser.GetBulkItemsProperty(operationID); err != nil {
+		return nil, fmt.Errorf("resource %s: %w", b.Resource.Name, err)
+	}
+	schema, err := b.Parser.GetOperationRequestSchema(operationID)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := common.ExtractFields(b.SchemaConfig, schema, true)
+	if err != nil {
+		return nil, err
+	}
+	// The objects are created together, so any change replaces all of them
+	for i := range fields {
+		fields[i].ForceNew = true
+	}
+	return fields, nil
+}
+
+func (b *BulkBuilder) BuildUpdateFields() ([]common.FieldInfo, error) {
+	return nil, nil
+}
+
+func (b *BulkBuilder) BuildResponseFields() ([]common.FieldInfo, error) {
+	itemSchema, err := b.Parser.GetOperationResponseSchema(b.Ops.Retrieve)
+	if err != nil {
+		return nil, err
+	}
+	// Created objects are read and deleted through their UUIDs
+	if itemSchema.Value == nil || itemSchema.Value.Properties["uuid"] == nil {
+		return nil, fmt.Errorf("resource %s: %s response has no uuid property", b.Resource.Name, b.Ops.Retrieve)
+	}
+	items := openapi3.NewArraySchema()
+	items.Items = itemSchema
+	items.ReadOnly = true
+	items.Description = "Objects created by the bulk request"
+	wrapper := openapi3.NewObjectSchema().WithProperty(ItemsAttribute, items)
+	return common.ExtractFields(b.SchemaConfig, openapi3.NewSchemaRef("", wrapper), true)
+}
+
+func (b *BulkBuilder) GetAPIPaths() map[string]string {
+	paths := make(map[string]string)
+	if listPath, ok := b.Parser.OperationPath(b.Ops.List); ok {
+		paths["Base"] = listPath
+	}
+	if operationID, err := b.operationID(); err == nil {
+		if bulkPath, ok := b.Parser.OperationPath(operationID); ok {
+			paths["Bulk"] = bulkPath
+		}
+	}
+	if retrievePath, ok := b.Parser.OperationPath(b.Ops.Retrieve); ok {
+		paths["Retrieve"] = retrievePath
+	}
+	if _, deletePath, deleteMethod, err := b.Parser.GetOperation(b.Ops.Destroy); err == nil {
+		paths["Delete"] = deletePath
+		paths["DeleteMethod"] = deleteMethod
+	}
+	plugins.AddMediaTypes(b.Parser, paths, "Base", b.Ops.List)
+	plugins.AddMediaTypes(b.Parser, paths, "Retrieve", b.Ops.Retrieve)
+	if operationID, err := b.operationID(); err == nil {
+		plugins.AddMediaTypes(b.Parser, paths, "Bulk", operationID)
+	}
+	return paths
+}
+
+func (b *BulkBuilder) GetTemplateFiles() []string {
+	return append(b.BaseBuilder.GetTemplateFiles(), "plugins/bulk/resource.tmpl")
+}
@@ -25,13 +25,13 @@ type BaseBuilder struct {
 }
 
 func (b *BaseBuilder) BuildModelFields(createFields, responseFields []common.FieldInfo) ([]common.FieldInfo, error) {
-	return common.MergeFields(createFields, responseFields), nil
+	return common.MergeFields(b.SchemaConfig, createFields, responseFields), nil
 }
 
 func (b *BaseBuilder) GetAPIPaths() map[string]string {
 	paths := make(map[string]string)
 	// Get path from list operation (used as base path)
-	if _, listPath, _, err := b.Parser.GetOperation(b.Ops.List); err == nil {
+	if listPath, ok := b.Parser.OperationPath(b.Ops.List); ok {
 		paths["Base"] = listPath
 	}
 
@@ -39,35 +39,61 @@ func (b *BaseBuilder) GetAPIPaths() map[string]string {
 	createOp := b.Ops.Create
 	if b.Resource.CreateOperation != nil && b.Resource.CreateOperation.OperationID != "" {
 		createOp = b.Resource.CreateOperation.OperationID
-		if _, createPath, _, err := b.Parser.GetOperation(createOp); err == nil {
+		if createPath, ok := b.Parser.OperationPath(createOp); ok {
 			paths["Create"] = createPath
 			paths["CreateOperationID"] = createOp
 			for k, v := range b.Resource.CreateOperation.PathParams {
 				paths["CreatePathParam_"+k] = v
 			}
 		}
-	} else if _, createPath, _, err := b.Parser.GetOperation(createOp); err == nil {
+	} else if createPath, ok := b.Parser.OperationPath(createOp); ok {
 		paths["Create"] = createPath
 	}
 
 	// Get path from retrieve operation
-	if _, retrievePath, _, err := b.Parser.GetOperation(b.Ops.Retrieve); err == nil {
+	if retrievePath, ok := b.Parser.OperationPath(b.Ops.Retrieve); ok {
 		paths["Retrieve"] = retrievePath
 	}
 
 	// Get path from update operation
-	if _, updatePath, _, err := b.Parser.GetOperation(b.Ops.PartialUpdate); err == nil {
+	if updatePath, ok := b.Parser.OperationPath(b.Ops.PartialUpdate); ok {
 		paths["Update"] = updatePath
 	}
 
 	// Get path from delete operation
-	if _, deletePath, _, err := b.Parser.GetOperation(b.Ops.Destroy); err == nil {
+	deleteOp := b.Ops.Destroy
+	if b.Resource.DeleteOperation != nil && b.Resource.DeleteOperation.OperationID != "" {
+		deleteOp = b.Resource.DeleteOperation.OperationID
+	}
+	if _, deletePath, deleteMethod, err := b.Parser.GetOperation(deleteOp); err == nil {
 		paths["Delete"] = deletePath
+		paths["DeleteMethod"] = deleteMethod
 	}
 
+	AddMediaTypes(b.Parser, paths, "Base", b.Ops.List)
+	AddMediaTypes(b.Parser, paths, "Create", createOp)
+	AddMediaTypes(b.Parser, paths, "Retrieve", b.Ops.Retrieve)
+	AddMediaTypes(b.Parser, paths, "Update", b.Ops.PartialUpdate)
+
 	return paths
 }
 
+// AddMediaTypes records the JSON media types of the operation whose path is paths[key], when other than
+// application/json, as <key>ContentType for its request body and <key>Accept for its response, for the
+// client to send
+func AddMediaTypes(parser *openapi.Parser, paths map[string]string, key, operationID string) {
+	if paths[key] == "" {
+		return
+	}
+	request, response := parser.GetOperationMediaTypes(operationID)
+	if request != "" && request != "application/json" {
+		paths[key+"ContentType"] = request
+	}
+	if response != "" && response != "application/json" {
+		paths[key+"Accept"] = response
+	}
+}
+
 func (b *BaseBuilder) GetTemplateFiles() []string {
 	return []string{"templates/shared/*.tmpl", "components/resource/resource.go.tmpl"}
 }
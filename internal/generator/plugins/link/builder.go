@@ -1,6 +1,7 @@
 package link
 
 import (
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
 	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/plugins"
 )
@@ -10,7 +11,33 @@ type LinkBuilder struct {
 	plugins.BaseBuilder
 }
 
+// membersFields returns the attributes of a members link resource: the target and the set of
+// sources linked to it
+func (b *LinkBuilder) membersFields() ([]common.FieldInfo, error) {
+	target := openapi3.NewStringSchema()
+	target.Description = "Target resource UUID or URL"
+	members := openapi3.NewArraySchema()
+	members.Items = openapi3.NewStringSchema().NewRef()
+	members.UniqueItems = true
+	members.Description = "UUIDs of the source resources linked to the target"
+	wrapper := openapi3.NewObjectSchema().
+		WithProperty(b.Resource.Target.Param, target).
+		WithProperty(b.Resource.Members.Attribute, members).
+		WithRequired([]string{b.Resource.Target.Param, b.Resource.Members.Attribute})
+	fields, err := common.ExtractFields(b.SchemaConfig, openapi3.NewSchemaRef("", wrapper), true)
+	if err != nil {
+		return nil, err
+	}
+	for i := range fields {
+		fields[i].ForceNew = fields[i].Name == b.Resource.Target.Param
+	}
+	return fields, nil
+}
+
 func (b *LinkBuilder) BuildCreateFields() ([]common.FieldInfo, error) {
+	if b.Resource.Members != nil {
+		return b.membersFields()
+	}
 	schema, err := b.Parser.GetOperationRequestSchema(b.Resource.LinkOp)
 	if err != nil {
 		return nil, nil
@@ -77,6 +104,9 @@ func (b *LinkBuilder) BuildUpdateFields() ([]common.FieldInfo, error) {
 }
 
 func (b *LinkBuilder) BuildResponseFields() ([]common.FieldInfo, error) {
+	if b.Resource.Members != nil {
+		return b.membersFields()
+	}
 	fields, err := func() ([]common.FieldInfo, error) {
 		if schema, err := b.Parser.GetOperationResponseSchema(b.Ops.Retrieve); err == nil {
 			return common.ExtractFields(b.SchemaConfig, schema, true)
@@ -105,23 +135,30 @@ func (b *LinkBuilder) BuildResponseFields() ([]common.FieldInfo, error) {
 
 func (b *LinkBuilder) GetAPIPaths() map[string]string {
 	paths := make(map[string]string)
-	if _, listPath, _, err := b.Parser.GetOperation(b.Ops.List); err == nil {
+	if listPath, ok := b.Parser.OperationPath(b.Ops.List); ok {
 		paths["Base"] = listPath
 	}
-	if _, retrievePath, _, err := b.Parser.GetOperation(b.Ops.Retrieve); err == nil {
+	if retrievePath, ok := b.Parser.OperationPath(b.Ops.Retrieve); ok {
 		paths["Retrieve"] = retrievePath
 	}
-	if _, linkPath, _, err := b.Parser.GetOperation(b.Resource.LinkOp); err == nil {
+	if linkPath, ok := b.Parser.OperationPath(b.Resource.LinkOp); ok {
 		paths["Link"] = linkPath
 	}
-	if _, unlinkPath, _, err := b.Parser.GetOperation(b.Resource.UnlinkOp); err == nil {
+	if unlinkPath, ok := b.Parser.OperationPath(b.Resource.UnlinkOp); ok {
 		paths["Unlink"] = unlinkPath
 	}
 	if b.Resource.Source != nil && b.Resource.Source.RetrieveOp != "" {
-		if _, sourcePath, _, err := b.Parser.GetOperation(b.Resource.Source.RetrieveOp); err == nil {
+		if sourcePath, ok := b.Parser.OperationPath(b.Resource.Source.RetrieveOp); ok {
 			paths["SourceRetrieve"] = sourcePath
 		}
 	}
+	if b.Resource.Target != nil && b.Resource.Target.RetrieveOp != "" {
+		if targetPath, ok := b.Parser.OperationPath(b.Resource.Target.RetrieveOp); ok {
+			paths["TargetRetrieve"] = targetPath
+		}
+	}
+	plugins.AddMediaTypes(b.Parser, paths, "Base", b.Ops.List)
+	plugins.AddMediaTypes(b.Parser, paths, "Retrieve", b.Ops.Retrieve)
 	return paths
 }
 
@@ -2,8 +2,8 @@ package order
 
 import (
 	"fmt"
-	"strings"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
 	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/plugins"
 )
@@ -14,7 +14,10 @@ type OrderBuilder struct {
 }
 
 func (b *OrderBuilder) BuildCreateFields() ([]common.FieldInfo, error) {
-	schemaName := strings.ReplaceAll(b.Resource.OfferingType, ".", "") + "CreateOrderAttributes"
+	if len(b.Resource.OfferingTypes) > 0 {
+		return b.buildOfferingTypeFields()
+	}
+	schemaName := b.Resource.OrderAttributeSchemas()[0]
 	offeringSchema, err := b.Parser.GetSchema(schemaName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find offering schema %s: %w", schemaName, err)
@@ -28,6 +31,33 @@ func (b *OrderBuilder) BuildCreateFields() ([]common.FieldInfo, error) {
 	return fields, nil
 }
 
+// buildOfferingTypeFields combines the order attributes of every offering type of the resource,
+// grouping the attributes specific to one type into a block named after it
+func (b *OrderBuilder) buildOfferingTypeFields() ([]common.FieldInfo, error) {
+	var variants openapi3.SchemaRefs
+	for _, schemaName := range b.Resource.OrderAttributeSchemas() {
+		offeringSchema, err := b.Parser.GetSchema(schemaName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find offering schema %s: %w", schemaName, err)
+		}
+		variants = append(variants, offeringSchema)
+	}
+	combined, blocks, err := common.OfferingTypeSchema(b.Resource.OfferingTypes, variants)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := common.ExtractFields(b.SchemaConfig, combined, true)
+	if err != nil {
+		return nil, err
+	}
+	for i := range fields {
+		fields[i].OfferingType = blocks[fields[i].Name]
+	}
+	// Add required offering and project fields
+	fields = append(fields, common.OrderCommonFields...)
+	return fields, nil
+}
+
 func (b *OrderBuilder) BuildUpdateFields() ([]common.FieldInfo, error) {
 	schema, err := b.Parser.GetOperationRequestSchema(b.Ops.PartialUpdate)
 	if err != nil {
@@ -59,6 +89,13 @@ func (b *OrderBuilder) BuildModelFields(createFields, responseFields []common.Fi
 
 func (b *OrderBuilder) GetAPIPaths() map[string]string {
 	paths := b.BaseBuilder.GetAPIPaths()
+	// Plan and limits changes are submitted as orders through these marketplace resource actions
+	if path, ok := b.Parser.OperationPath("marketplace_resources_switch_plan"); ok {
+		paths["SwitchPlan"] = path
+	}
+	if path, ok := b.Parser.OperationPath("marketplace_resources_update_limits"); ok {
+		paths["UpdateLimits"] = path
+	}
 	return paths
 }
 
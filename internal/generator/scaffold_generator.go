@@ -5,10 +5,12 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"text/template"
 
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
 	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
 )
 
@@ -41,11 +43,11 @@ func (g *Generator) generateProvider() error {
 	// Collect unique services
 	services := make(map[string]bool)
 	for _, res := range g.config.Resources {
-		service, _ := common.SplitResourceName(res.Name)
+		service, _ := common.ResolveResourceName(g.config.Naming, res.Name)
 		services[service] = true
 	}
 	for _, ds := range g.config.DataSources {
-		service, _ := common.SplitResourceName(ds.Name)
+		service, _ := common.ResolveResourceName(g.config.Naming, ds.ResourceName())
 		services[service] = true
 	}
 
@@ -56,30 +58,85 @@ func (g *Generator) generateProvider() error {
 	sort.Strings(serviceList)
 
 	data := map[string]interface{}{
-		"ProviderName": g.config.Generator.ProviderName,
-		"Services":     serviceList,
+		"ProviderName":       g.config.Generator.ProviderName,
+		"Services":           serviceList,
+		"ProviderAttributes": g.config.Generator.ProviderAttributes,
+		"Auth":               g.auth,
 	}
 
-	return g.RenderTemplate(
+	if err := g.RenderTemplate(
 		"provider.go.tmpl",
 		[]string{"templates/provider.go.tmpl"},
 		data,
 		filepath.Join(g.config.Generator.OutputDir, "internal", "provider"),
 		"provider.go",
+	); err != nil {
+		return err
+	}
+
+	return g.RenderTemplate(
+		"functions.go.tmpl",
+		[]string{"templates/functions.go.tmpl"},
+		map[string]interface{}{
+			"ProviderName": g.config.Generator.ProviderName,
+			"ObjectPaths":  g.objectPaths(),
+		},
+		filepath.Join(g.config.Generator.OutputDir, "internal", "provider"),
+		"functions.go",
 	)
 }
 
+// ObjectPath is the retrieve path of the objects of a Terraform type, used to build their URLs
+type ObjectPath struct {
+	Kind     string // Terraform type name without the provider prefix
+	TypeName string // Terraform type name with the provider prefix, also accepted as the kind unless empty
+	Path     string // Retrieve path with a {uuid} parameter, e.g. "/api/projects/{uuid}/"
+}
+
+// objectPaths returns the retrieve paths of the generated resources and data sources, sorted by kind.
+// Objects whose URL does not end with their UUID, such as links, actions and credentials, are left out.
+// Kinds are resolved by the exact type name, with or without the provider prefix, so that kinds starting
+// with the provider name itself are not confused with prefixed ones.
+func (g *Generator) objectPaths() []ObjectPath {
+	var paths []ObjectPath
+	for _, name := range g.ResourceOrder {
+		rd := g.Resources[name]
+		path := rd.APIPaths["Retrieve"]
+		if rd.Plugin == "actions" || rd.Source != nil || (rd.IsDatasourceOnly && len(rd.DataSourceNames) == 0) {
+			continue
+		}
+		if strings.Count(path, "{") != 1 || !strings.HasSuffix(path, "/{uuid}/") {
+			continue
+		}
+		paths = append(paths, ObjectPath{Kind: rd.TypeName, TypeName: g.config.Generator.ProviderName + "_" + rd.TypeName, Path: path})
+	}
+	sort.Slice(paths, func(i, j int) bool { return paths[i].Kind < paths[j].Kind })
+	// A kind equal to the prefixed type name of another object wins
+	for i := range paths {
+		if slices.ContainsFunc(paths, func(p ObjectPath) bool { return p.Kind == paths[i].TypeName }) {
+			paths[i].TypeName = ""
+		}
+	}
+	return paths
+}
+
 func (g *Generator) generateServiceRegistrations() error {
 	// Group resources by service
 	serviceResources := make(map[string][]*common.ResourceData)
 
 	// Process all prepared resources
+	var services []string
 	for _, name := range g.ResourceOrder {
 		rd := g.Resources[name]
+		if serviceResources[rd.Service] == nil {
+			services = append(services, rd.Service)
+		}
 		serviceResources[rd.Service] = append(serviceResources[rd.Service], rd)
 	}
+	sort.Strings(services)
 
-	for service, resources := range serviceResources {
+	for _, service := range services {
+		resources := serviceResources[service]
 		outputDir := filepath.Join(g.config.Generator.OutputDir, "services", service)
 		if err := os.MkdirAll(outputDir, 0755); err != nil {
 			return err
@@ -217,10 +274,26 @@ func (g *Generator) generateRegistryManifest() error {
 
 // generateReadme creates the README.md file for the generated provider
 func (g *Generator) generateReadme() error {
+	var dataSources, ephemeralResources []config.DataSource
+	for i := range g.config.DataSources {
+		ds := g.config.DataSources[i]
+		if !g.config.DataSourceEnabled(&ds) {
+			continue
+		}
+		if ds.Ephemeral {
+			ephemeralResources = append(ephemeralResources, ds)
+		} else {
+			dataSources = append(dataSources, ds)
+		}
+	}
+
 	data := map[string]interface{}{
-		"ProviderName": g.config.Generator.ProviderName,
-		"Resources":    g.config.Resources,
-		"DataSources":  g.config.DataSources,
+		"ProviderName":       g.config.Generator.ProviderName,
+		"Resources":          g.config.Resources,
+		"DataSources":        dataSources,
+		"EphemeralResources": ephemeralResources,
+		"ProviderAttributes": g.config.Generator.ProviderAttributes,
+		"Naming":             g.config.Naming,
 	}
 
 	return g.RenderTemplate(
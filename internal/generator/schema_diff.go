@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/generator/common"
+	"github.com/waldur/terraform-provider-waldur-generator/internal/openapi"
+)
+
+// ResourceDiff lists the changes of a configured resource between two OpenAPI schemas
+type ResourceDiff struct {
+	Resource string
+	Changes  []string // Removed operations first, then field changes by schema role
+}
+
+// SchemaDiff compares the operations and fields used by each configured resource in two
+// OpenAPI schemas. Resources without changes are left out.
+func SchemaDiff(cfg *config.Config, oldParser, newParser *openapi.Parser) []ResourceDiff {
+	var diffs []ResourceDiff
+	for i := range cfg.Resources {
+		resource := &cfg.Resources[i]
+		var changes []string
+
+		// Operations of the old schema missing from the new one
+		seen := make(map[string]bool)
+		var removed []string
+		for _, opID := range resourceOperationIDs(resource) {
+			if opID == "" || seen[opID] {
+				continue
+			}
+			seen[opID] = true
+			_, inOld := oldParser.LookupOperation(opID)
+			_, inNew := newParser.LookupOperation(opID)
+			if inOld && !inNew {
+				removed = append(removed, opID)
+			}
+		}
+		sort.Strings(removed)
+		for _, opID := range removed {
+			changes = append(changes, fmt.Sprintf("operation %s removed", opID))
+		}
+
+		// Fields of the request and response schemas
+		ops := resource.OperationIDs()
+		createOp := ops.Create
+		if resource.CreateOperation != nil && resource.CreateOperation.OperationID != "" {
+			createOp = resource.CreateOperation.OperationID
+		}
+		roles := []struct {
+			name     string
+			opID     string
+			response bool
+		}{
+			{"create request", createOp, false},
+			{"update request", ops.PartialUpdate, false},
+			{"response", ops.Retrieve, true},
+		}
+		for _, role := range roles {
+			oldFields := operationFields(oldParser, role.opID, role.response)
+			newFields := operationFields(newParser, role.opID, role.response)
+			if oldFields == nil || newFields == nil {
+				continue
+			}
+			changes = append(changes, common.DiffFields(role.name, oldFields, newFields)...)
+		}
+
+		if len(changes) > 0 {
+			diffs = append(diffs, ResourceDiff{Resource: resource.Name, Changes: changes})
+		}
+	}
+	return diffs
+}
+
+// operationFields returns the flattened request or response schema of an operation,
+// or nil when the operation or its schema does not exist
+func operationFields(parser *openapi.Parser, opID string, response bool) map[string]common.SchemaField {
+	if opID == "" {
+		return nil
+	}
+	getSchema := parser.GetOperationRequestSchema
+	if response {
+		getSchema = parser.GetOperationResponseSchema
+	}
+	schema, err := getSchema(opID)
+	if err != nil {
+		return nil
+	}
+	return common.FlattenSchema(schema)
+}
@@ -28,7 +28,7 @@ func (g *Generator) generateSharedSDKTypes() error {
 		return fmt.Errorf("failed to collect used types: %w", err)
 	}
 
-	allFields := g.collectSchemaFields(usedTypes)
+	allFields, enums := g.collectSchemaFields(usedTypes)
 	uniqueStructs := common.CollectUniqueStructs(allFields)
 
 	extraFields := g.calculateIgnoredFields()
@@ -36,15 +36,28 @@ func (g *Generator) generateSharedSDKTypes() error {
 
 	data := map[string]interface{}{
 		"Structs": uniqueStructs,
+		"Enums":   enums,
 		"Package": "common",
 	}
 
-	return g.RenderTemplate(
+	outputDir := filepath.Join(g.config.Generator.OutputDir, "internal", "sdk", "common")
+	if err := g.RenderTemplate(
 		"shared_types.go.tmpl",
 		[]string{"templates/shared/*.tmpl", "templates/shared_types.go.tmpl"},
 		data,
-		filepath.Join(g.config.Generator.OutputDir, "internal", "sdk", "common"),
+		outputDir,
 		"types.go",
+	); err != nil {
+		return err
+	}
+
+	// Attribute type helpers shared by resources of several services
+	return g.RenderTemplate(
+		"attr_types.go.tmpl",
+		[]string{"templates/attr_types.go.tmpl"},
+		map[string]interface{}{"Structs": g.sharedStructs},
+		outputDir,
+		"attr_types.go",
 	)
 }
 
@@ -141,19 +154,27 @@ func (g *Generator) collectUsedTypes() (map[string]bool, error) {
 	// 1. Collect types from Resources
 	// Explicitly add types used in utils.go
 	usedTypes["OrderDetails"] = true
+	// State enums referenced by polling.go
+	usedTypes["CoreStates"] = true
+	usedTypes["OrderState"] = true
 
 	for _, name := range g.ResourceOrder {
 		rd := g.Resources[name]
 		collectTypes(rd.CreateFields)
 		collectTypes(rd.UpdateFields)
 		collectTypes(rd.ResponseFields)
+		for _, er := range rd.ErrorResponses {
+			collectTypes(er.Fields)
+		}
 	}
 
 	return usedTypes, nil
 }
 
-func (g *Generator) collectSchemaFields(usedTypes map[string]bool) []common.FieldInfo {
+// collectSchemaFields returns the used object schemas and the used enum schemas, sorted by name
+func (g *Generator) collectSchemaFields(usedTypes map[string]bool) ([]common.FieldInfo, []common.EnumType) {
 	var allFields []common.FieldInfo
+	var enums []common.EnumType
 
 	// 0. Construct SchemaConfig
 	cfg := g.GetSchemaConfig()
@@ -171,19 +192,9 @@ func (g *Generator) collectSchemaFields(usedTypes map[string]bool) []common.Fiel
 			continue
 		}
 
-		// Detect if it's an enum (string type with enum values)
-		if schemaRef.Value.Type != nil && (*schemaRef.Value.Type)[0] == "string" && len(schemaRef.Value.Enum) > 0 {
-			var enumValues []string
-			for _, e := range schemaRef.Value.Enum {
-				if s, ok := e.(string); ok {
-					enumValues = append(enumValues, s)
-				}
-			}
-			allFields = append(allFields, common.FieldInfo{
-				RefName: name,
-				GoType:  common.TFTypeString,
-				Enum:    enumValues,
-			})
+		// Detect if it's an enum (string, integer or number type with enum values)
+		if enum := common.NewEnumType(name, schemaRef.Value); enum != nil {
+			enums = append(enums, *enum)
 			continue
 		}
 
@@ -194,7 +205,7 @@ func (g *Generator) collectSchemaFields(usedTypes map[string]bool) []common.Fiel
 			Properties: fields,
 		})
 	}
-	return allFields
+	return allFields, enums
 }
 
 func (g *Generator) calculateIgnoredFields() map[string]map[string]common.FieldInfo {
@@ -13,7 +13,7 @@ import (
 func ToAttrType(f common.FieldInfo) string {
 	// If it's an object with a AttrTypeRef, return the helper function call
 	if f.GoType == common.TFTypeObject && f.AttrTypeRef != "" {
-		return f.AttrTypeRef + "Type()"
+		return attrTypeHelper(f)
 	}
 	// If it's a list/set of objects with a AttrTypeRef, return collection with helper function
 	if (f.GoType == common.TFTypeList || f.GoType == common.TFTypeSet) && f.ItemType == common.OpenAPITypeObject && f.ItemSchema != nil && f.ItemSchema.AttrTypeRef != "" {
@@ -23,12 +23,20 @@ func ToAttrType(f common.FieldInfo) string {
 		} else {
 			collectionType = "types.SetType"
 		}
-		return collectionType + "{ElemType: " + f.ItemSchema.AttrTypeRef + "Type()}"
+		return collectionType + "{ElemType: " + attrTypeHelper(*f.ItemSchema) + "}"
 	}
 
 	return ToAttrTypeDefinition(f)
 }
 
+// attrTypeHelper returns the call to the helper declaring the attribute type of a structure
+func attrTypeHelper(f common.FieldInfo) string {
+	if f.SharedAttrType {
+		return "common." + f.AttrTypeRef + "Type()"
+	}
+	return f.AttrTypeRef + "Type()"
+}
+
 // ToAttrTypeDefinition converts FieldInfo to proper attr.Type expression used in Terraform schema
 func ToAttrTypeDefinition(f common.FieldInfo) string {
 	if !f.TypeMeta.IsComplex {
@@ -69,6 +77,9 @@ func ToAttrTypeDefinition(f common.FieldInfo) string {
 		return "types.SetType{ElemType: " + f.TypeMeta.ElemType + "}"
 
 	case common.TFTypeMap:
+		if f.IsObjectMap() {
+			return "types.MapType{ElemType: " + ToAttrType(*f.ItemSchema) + "}"
+		}
 		return "types.MapType{ElemType: " + f.TypeMeta.ElemType + "}"
 
 	case common.TFTypeObject:
@@ -106,6 +117,11 @@ func GetFuncMap() template.FuncMap {
 		"toAttrType":           ToAttrType,
 		"toAttrTypeDefinition": ToAttrTypeDefinition,
 		"formatValidator":      formatValidatorValue,
+		"attrDescription":      common.AttributeDescription,
+		"sanitize":             common.SanitizeString,
+		"tableCell":            common.MarkdownTableCell,
+		"setKeysModifier":      common.SetKeysModifier,
+		"replaceIfModifier":    common.ReplaceIfModifier,
 		"replace": func(old, new, s string) string {
 			return strings.ReplaceAll(s, old, new)
 		},
@@ -157,11 +173,17 @@ func GetFuncMap() template.FuncMap {
 			} else if f.Type == common.OpenAPITypeArray && f.ItemType == common.OpenAPITypeObject && sdkType == "[]" {
 				elemType := prefix + common.ToTitle(f.Name) + suffix
 				sdkType = "[]" + elemType
+			} else if f.IsObjectMap() && sdkType == "map[string]" {
+				sdkType = "map[string]" + prefix + common.ToTitle(f.Name) + suffix
 			}
 
 			// Handle package prefixes for references
 			if pkgName != "common" {
-				if f.Type == common.OpenAPITypeObject && f.RefName != "" {
+				if f.IsObjectMap() {
+					if f.ItemRefName != "" {
+						sdkType = "map[string]common." + f.ItemRefName
+					}
+				} else if f.Type == common.OpenAPITypeObject && f.RefName != "" {
 					sdkType = "common." + f.RefName
 				} else if f.Type == common.OpenAPITypeArray && f.ItemRefName != "" {
 					sdkType = "[]common." + f.ItemRefName
@@ -178,6 +200,11 @@ func GetFuncMap() template.FuncMap {
 				isPointer = false
 			}
 
+			// Runtime types such as URLReference are declared in the common package itself
+			if pkgName == "common" {
+				sdkType = strings.ReplaceAll(sdkType, "common.", "")
+			}
+
 			if isPointer {
 				return "*" + sdkType
 			}
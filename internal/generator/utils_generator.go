@@ -12,9 +12,18 @@ func (g *Generator) generateSharedUtils() error {
 	}{
 		{"modifiers.go.tmpl", "modifiers.go"},
 		{"waldur.go.tmpl", "waldur.go"},
+		{"errors.go.tmpl", "errors.go"},
 		{"filters.go.tmpl", "filters.go"},
 		{"population.go.tmpl", "population.go"},
 		{"polling.go.tmpl", "polling.go"},
+		{"polling_test.go.tmpl", "polling_test.go"}, // Not in client_test.go, as the client package cannot import common
+		{"state.go.tmpl", "state.go"},
+		{"state_test.go.tmpl", "state_test.go"},
+		{"union.go.tmpl", "union.go"},
+		{"normalized.go.tmpl", "normalized.go"},
+		{"transforms.go.tmpl", "transforms.go"},
+		{"upgrade.go.tmpl", "upgrade.go"},
+		{"lifecycle.go.tmpl", "lifecycle.go"},
 	}
 
 	outputDir := filepath.Join(g.config.Generator.OutputDir, "internal", "sdk", "common")
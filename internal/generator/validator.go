@@ -2,6 +2,10 @@ package generator
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
 )
 
 // validateOperations checks that all referenced operations exist in the OpenAPI schema
@@ -29,10 +33,20 @@ func (g *Generator) validateOperations() error {
 			if resource.Source != nil && resource.Source.RetrieveOp != "" {
 				operationsToCheck["source_retrieve"] = resource.Source.RetrieveOp
 			}
+			if resource.Target != nil && resource.Target.RetrieveOp != "" {
+				operationsToCheck["target_retrieve"] = resource.Target.RetrieveOp
+			}
 			// Don't validate standard CRUD for link resources
 			delete(operationsToCheck, "list")
 			delete(operationsToCheck, "retrieve")
 			delete(operationsToCheck, "partial_update")
+		} else if resource.Plugin == "bulk" {
+			// Bulk resources are replaced on change, so they are never updated
+			delete(operationsToCheck, "partial_update")
+			if resource.BulkOperation != "" {
+				operationsToCheck["bulk"] = resource.BulkOperation
+			}
+			operationsToCheck["destroy"] = ops.Destroy
 		} else if resource.Plugin != "order" {
 			// Use custom create operation if specified
 			if resource.CreateOperation != nil && resource.CreateOperation.OperationID != "" {
@@ -57,9 +71,80 @@ func (g *Generator) validateOperations() error {
 	for _, dataSource := range g.config.DataSources {
 		ops := dataSource.OperationIDs()
 		if err := g.parser.ValidateOperationExists(ops.List); err != nil {
-			return fmt.Errorf("data source %s: %w", dataSource.Name, err)
+			// Ephemeral resources may only be looked up by UUID, e.g. the token of a user
+			if !dataSource.Ephemeral || g.parser.ValidateOperationExists(ops.Retrieve) != nil {
+				return fmt.Errorf("data source %s: %w", dataSource.Name, err)
+			}
 		}
 	}
 
 	return nil
 }
+
+// warnDeprecatedOperations prints a warning for each config entry relying on operations
+// marked as deprecated in the OpenAPI schema
+func (g *Generator) warnDeprecatedOperations() {
+	for _, resource := range g.config.Resources {
+		if deprecated := g.deprecatedOperations(resourceOperationIDs(&resource)); len(deprecated) > 0 {
+			fmt.Printf("Warning: resource %s relies on deprecated operations: %s\n", resource.Name, strings.Join(deprecated, ", "))
+		}
+	}
+
+	for _, dataSource := range g.config.DataSources {
+		ops := dataSource.OperationIDs()
+		if deprecated := g.deprecatedOperations([]string{ops.List, ops.Retrieve}); len(deprecated) > 0 {
+			fmt.Printf("Warning: data source %s relies on deprecated operations: %s\n", dataSource.Name, strings.Join(deprecated, ", "))
+		}
+	}
+}
+
+// resourceOperationIDs returns the IDs of the operations a resource may rely on, unsorted and
+// possibly including empty or duplicate IDs
+func resourceOperationIDs(resource *config.Resource) []string {
+	ops := resource.OperationIDs()
+	opIDs := []string{ops.List, ops.Create, ops.Retrieve, ops.PartialUpdate, ops.Destroy, resource.LinkOp, resource.UnlinkOp, resource.BulkOperation}
+	if resource.CreateOperation != nil {
+		opIDs = append(opIDs, resource.CreateOperation.OperationID)
+	}
+	if resource.DeleteOperation != nil {
+		opIDs = append(opIDs, resource.DeleteOperation.OperationID)
+	}
+	for _, action := range resource.UpdateActions {
+		opIDs = append(opIDs, action.Operation)
+	}
+	for _, action := range resource.Actions {
+		opIDs = append(opIDs, fmt.Sprintf("%s_%s", resource.BaseOperationID, action))
+	}
+	for _, step := range resource.Steps {
+		opIDs = append(opIDs, step.Operation, step.RollbackOperation)
+	}
+	for _, check := range resource.Preflight {
+		opIDs = append(opIDs, check.Operation)
+	}
+	for _, ops := range resource.NestedOperations {
+		opIDs = append(opIDs, ops.Add, ops.Remove)
+	}
+	for _, linked := range []*config.LinkResourceConfig{resource.Source, resource.Target} {
+		if linked != nil {
+			opIDs = append(opIDs, linked.RetrieveOp)
+		}
+	}
+	return opIDs
+}
+
+// deprecatedOperations returns the sorted, unique IDs of the deprecated operations among opIDs
+func (g *Generator) deprecatedOperations(opIDs []string) []string {
+	seen := make(map[string]bool)
+	var deprecated []string
+	for _, opID := range opIDs {
+		if opID == "" || seen[opID] {
+			continue
+		}
+		seen[opID] = true
+		if info, ok := g.parser.LookupOperation(opID); ok && info.Operation.Deprecated {
+			deprecated = append(deprecated, opID)
+		}
+	}
+	sort.Strings(deprecated)
+	return deprecated
+}
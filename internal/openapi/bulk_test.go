@@ -0,0 +1,144 @@
+package openapi
+
+import "testing"
+
+const bulkSpec = `
+openapi: 3.0.3
+info: {title: test, version: "1"}
+paths:
+  /api/accounts/bulk_create/:
+    post:
+      operationId: accounts_bulk_create
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                accounts:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      name: {type: string}
+                tags:
+                  type: array
+                  items: {type: string}
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {type: object}
+  /api/users/create_bulk/:
+    post:
+      operationId: users_create_bulk
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                users:
+                  type: array
+                  items:
+                    type: object
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema: {type: object}
+  /api/groups/bulk_create/:
+    post:
+      operationId: groups_bulk_create
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                groups:
+                  type: array
+                  items: {type: object}
+                members:
+                  type: array
+                  items: {type: object}
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {type: object}
+  /api/tags/bulk_create/:
+    post:
+      operationId: tags_bulk_create
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                names:
+                  type: array
+                  items: {type: string}
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {type: string}
+`
+
+func TestGetBulkItemsProperty(t *testing.T) {
+	p := newTestParser(t, bulkSpec)
+
+	tests := []struct {
+		operationID string
+		want        string
+		wantErr     bool
+	}{
+		{"accounts_bulk_create", "accounts", false},
+		{"users_create_bulk", "", true},   // Response is not an array
+		{"groups_bulk_create", "", true},  // Two arrays of objects
+		{"tags_bulk_create", "", true},    // No array of objects
+		{"unknown_bulk_create", "", true}, // Unknown operation
+	}
+	for _, tt := range tests {
+		t.Run(tt.operationID, func(t *testing.T) {
+			got, err := p.GetBulkItemsProperty(tt.operationID)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("GetBulkItemsProperty() = %q, %v, want %q (error %v)", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFindBulkCreateOperation(t *testing.T) {
+	p := newTestParser(t, bulkSpec)
+
+	tests := []struct {
+		baseOperationID string
+		want            string
+		wantFound       bool
+	}{
+		{"accounts", "accounts_bulk_create", true},
+		{"users", "", false}, // The create_bulk operation is not a valid bulk operation
+		{"groups", "", false},
+		{"projects", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.baseOperationID, func(t *testing.T) {
+			got, found := p.FindBulkCreateOperation(tt.baseOperationID)
+			if got != tt.want || found != tt.wantFound {
+				t.Errorf("FindBulkCreateOperation() = %q, %v, want %q, %v", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package openapi
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// normalizeDocument downgrades an OpenAPI 3.1 document to 3.0 semantics:
+//   - type arrays containing "null" become a single type with nullable: true
+//   - numeric exclusiveMinimum/exclusiveMaximum become minimum/maximum with the boolean flag
+//   - const becomes a single-value enum and schema examples become example
+//   - {type: "null"} variants of oneOf/anyOf are dropped in favour of nullable: true
+//
+// Documents declaring any other version are returned unchanged.
+func normalizeDocument(data []byte) ([]byte, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil || len(root.Content) == 0 {
+		// Leave syntax errors to the loader
+		return data, nil
+	}
+	doc := root.Content[0]
+	version := mappingValue(doc, "openapi")
+	if version == nil || !strings.HasPrefix(version.Value, "3.1") {
+		return data, nil
+	}
+
+	version.Value = "3.0.3"
+	removeKey(doc, "jsonSchemaDialect")
+	removeKey(doc, "webhooks")
+	if info := mappingValue(doc, "info"); info != nil {
+		removeKey(info, "summary")
+		if license := mappingValue(info, "license"); license != nil {
+			removeKey(license, "identifier")
+		}
+	}
+	walkNode(doc, false)
+
+	return yaml.Marshal(&root)
+}
+
+// walkNode visits every schema reachable from node. isSchema reports whether node is in a schema position.
+func walkNode(node *yaml.Node, isSchema bool) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			walkNode(item, false)
+		}
+		return
+	case yaml.MappingNode:
+	default:
+		return
+	}
+
+	if isSchema {
+		normalizeSchema(node)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		switch {
+		case strings.HasPrefix(key, "x-"), key == "example", key == "examples":
+			// Free-form values
+		case isSchema && (key == "default" || key == "enum"):
+		case key == "schema", key == "items", key == "additionalProperties", key == "not":
+			walkNode(value, true)
+		case key == "allOf", key == "anyOf", key == "oneOf":
+			for _, item := range value.Content {
+				walkNode(item, true)
+			}
+		case key == "properties", key == "schemas" && !isSchema:
+			for j := 1; j < len(value.Content); j += 2 {
+				walkNode(value.Content[j], true)
+			}
+		default:
+			walkNode(value, false)
+		}
+	}
+}
+
+// normalizeSchema rewrites the 3.1 keywords of a single schema object
+func normalizeSchema(node *yaml.Node) {
+	if typ := mappingValue(node, "type"); typ != nil && typ.Kind == yaml.SequenceNode {
+		var types []*yaml.Node
+		for _, t := range typ.Content {
+			if t.Value == "null" {
+				setKey(node, "nullable", trueNode())
+				continue
+			}
+			types = append(types, t)
+		}
+		switch len(types) {
+		case 0:
+			removeKey(node, "type")
+		case 1:
+			*typ = *types[0]
+		default:
+			typ.Content = types
+		}
+	}
+
+	for _, bound := range [][2]string{{"exclusiveMinimum", "minimum"}, {"exclusiveMaximum", "maximum"}} {
+		value := mappingValue(node, bound[0])
+		if value == nil || value.Tag == "!!bool" {
+			continue
+		}
+		setKey(node, bound[1], &yaml.Node{Kind: yaml.ScalarNode, Tag: value.Tag, Value: value.Value})
+		*value = *trueNode()
+	}
+
+	if value := mappingValue(node, "const"); value != nil {
+		if mappingValue(node, "enum") == nil {
+			setKey(node, "enum", &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{value}})
+		}
+		removeKey(node, "const")
+	}
+
+	if examples := mappingValue(node, "examples"); examples != nil {
+		if examples.Kind == yaml.SequenceNode && len(examples.Content) > 0 && mappingValue(node, "example") == nil {
+			setKey(node, "example", examples.Content[0])
+		}
+		removeKey(node, "examples")
+	}
+
+	for _, key := range []string{"oneOf", "anyOf"} {
+		variants := mappingValue(node, key)
+		if variants == nil || variants.Kind != yaml.SequenceNode {
+			continue
+		}
+		kept := variants.Content[:0]
+		for _, variant := range variants.Content {
+			if t := mappingValue(variant, "type"); t != nil && t.Value == "null" && len(variant.Content) == 2 {
+				setKey(node, "nullable", trueNode())
+				continue
+			}
+			kept = append(kept, variant)
+		}
+		variants.Content = kept
+	}
+}
+
+// mappingValue returns the value stored under key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setKey stores value under key in a mapping node, replacing any existing value
+func setKey(node *yaml.Node, key string, value *yaml.Node) {
+	if existing := mappingValue(node, key); existing != nil {
+		*existing = *value
+		return
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// removeKey deletes key from a mapping node
+func removeKey(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// trueNode returns a boolean true scalar
+func trueNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"}
+}
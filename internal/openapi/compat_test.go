@@ -0,0 +1,73 @@
+package openapi
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestNormalizeDocument(t *testing.T) {
+	data, err := os.ReadFile("testdata/openapi_3_1.yaml")
+	if err != nil {
+		t.Fatalf("reading fixture failed: %v", err)
+	}
+	normalized, err := normalizeDocument(data)
+	if err != nil {
+		t.Fatalf("normalizeDocument() error = %v", err)
+	}
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(normalized)
+	if err != nil {
+		t.Fatalf("LoadFromData() error = %v\n%s", err, normalized)
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		t.Fatalf("normalized document is invalid: %v\n%s", err, normalized)
+	}
+	if doc.OpenAPI != "3.0.3" {
+		t.Errorf("openapi = %s, want 3.0.3", doc.OpenAPI)
+	}
+
+	properties := doc.Components.Schemas["Volume"].Value.Properties
+	ptr := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name  string
+		check func(s *openapi3.Schema) bool
+		want  string
+	}{
+		{"name", func(s *openapi3.Schema) bool { return s.Type.Is("string") && s.Nullable }, "nullable string"},
+		{"size", func(s *openapi3.Schema) bool {
+			return s.Type.Is("integer") && reflect.DeepEqual(s.Min, ptr(0)) && s.ExclusiveMin &&
+				reflect.DeepEqual(s.Max, ptr(1024)) && s.ExclusiveMax
+		}, "integer with exclusive bounds 0 and 1024"},
+		{"kind", func(s *openapi3.Schema) bool { return reflect.DeepEqual(s.Enum, []any{"block"}) }, "enum [block]"},
+		{"label", func(s *openapi3.Schema) bool { return s.Example == "fast" }, "example fast"},
+		{"owner", func(s *openapi3.Schema) bool { return s.Nullable && len(s.OneOf) == 1 }, "nullable with one oneOf variant"},
+		{"tags", func(s *openapi3.Schema) bool { return s.Items.Value.Type.Is("string") && s.Items.Value.Nullable }, "list of nullable strings"},
+		{"x-extension", func(s *openapi3.Schema) bool { return s.Type.Is("string") && s.Nullable }, "nullable string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			property := properties[tt.name]
+			if property == nil || property.Value == nil {
+				t.Fatalf("property %s is missing", tt.name)
+			}
+			if !tt.check(property.Value) {
+				t.Errorf("%s = %+v, want %s", tt.name, property.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeDocumentKeeps30(t *testing.T) {
+	data := []byte("openapi: 3.0.3\ninfo: {title: test, version: \"1\"}\npaths: {}\n")
+	normalized, err := normalizeDocument(data)
+	if err != nil {
+		t.Fatalf("normalizeDocument() error = %v", err)
+	}
+	if string(normalized) != string(data) {
+		t.Errorf("normalizeDocument() changed a 3.0 document: %s", normalized)
+	}
+}
@@ -0,0 +1,63 @@
+package openapi
+
+import (
+	"mime"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// isJSONMediaType reports whether a media type carries JSON: application/json with any
+// parameters (e.g. "application/json; version=2") or a structured +json suffix type
+func isJSONMediaType(mediaType string) bool {
+	base, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		base = strings.TrimSpace(strings.SplitN(mediaType, ";", 2)[0])
+	}
+	base = strings.ToLower(base)
+	return base == "application/json" || (strings.HasPrefix(base, "application/") && strings.HasSuffix(base, "+json"))
+}
+
+// jsonMediaType returns the best JSON-compatible media type of a content map: plain
+// application/json first, then the other JSON media types in name order
+func jsonMediaType(content openapi3.Content) *openapi3.MediaType {
+	if name := jsonMediaTypeName(content); name != "" {
+		return content[name]
+	}
+	return nil
+}
+
+// jsonMediaTypeName returns the name of the media type selected by jsonMediaType, "" when there is none
+func jsonMediaTypeName(content openapi3.Content) string {
+	if content["application/json"] != nil {
+		return "application/json"
+	}
+	for _, name := range mediaTypeNames(content) {
+		if isJSONMediaType(name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// mediaTypeNames returns the sorted media types of a content map
+func mediaTypeNames(content openapi3.Content) []string {
+	names := make([]string, 0, len(content))
+	for name := range content {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// describeMediaTypes lists unique media types for error messages
+func describeMediaTypes(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	names = slices.Clone(names)
+	sort.Strings(names)
+	return strings.Join(slices.Compact(names), ", ")
+}
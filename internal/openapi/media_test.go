@@ -0,0 +1,90 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestJSONMediaTypeName(t *testing.T) {
+	tests := []struct {
+		name       string
+		mediaTypes []string
+		want       string
+	}{
+		{"plain JSON first", []string{"application/vnd.waldur+json", "application/json; version=2", "application/json"}, "application/json"},
+		{"parameters", []string{"text/csv", "application/json; version=2"}, "application/json; version=2"},
+		{"structured suffix", []string{"application/problem+json", "text/html"}, "application/problem+json"},
+		{"name order", []string{"application/vnd.b+json", "application/json; version=3", "application/vnd.a+json"}, "application/json; version=3"},
+		{"no JSON", []string{"text/csv", "multipart/form-data", "text/json+html"}, ""},
+		{"none", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := openapi3.Content{}
+			for _, name := range tt.mediaTypes {
+				content[name] = openapi3.NewMediaType()
+			}
+			if got := jsonMediaTypeName(content); got != tt.want {
+				t.Errorf("jsonMediaTypeName() = %q, want %q", got, tt.want)
+			}
+			if mt := jsonMediaType(content); (mt != nil) != (tt.want != "") || (mt != nil && mt != content[tt.want]) {
+				t.Errorf("jsonMediaType() = %v, want the %q media type", mt, tt.want)
+			}
+		})
+	}
+}
+
+const mediaTypesSpec = `
+openapi: 3.0.3
+info: {title: test, version: "1"}
+paths:
+  /api/reports/:
+    post:
+      operationId: reports_create
+      requestBody:
+        content:
+          application/json; version=2:
+            schema: {type: object}
+          text/csv:
+            schema: {type: string}
+      responses:
+        "201":
+          description: Created
+          content:
+            application/vnd.waldur+json:
+              schema: {type: object}
+  /api/reports/{uuid}/:
+    get:
+      operationId: reports_retrieve
+      parameters:
+        - {name: uuid, in: path, required: true, schema: {type: string}}
+      responses:
+        "200":
+          description: OK
+          content:
+            text/csv:
+              schema: {type: string}
+`
+
+func TestGetOperationMediaTypes(t *testing.T) {
+	p := newTestParser(t, mediaTypesSpec)
+
+	tests := []struct {
+		operationID  string
+		wantRequest  string
+		wantResponse string
+	}{
+		{"reports_create", "application/json; version=2", "application/vnd.waldur+json"},
+		{"reports_retrieve", "", ""},
+		{"unknown", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.operationID, func(t *testing.T) {
+			request, response := p.GetOperationMediaTypes(tt.operationID)
+			if request != tt.wantRequest || response != tt.wantResponse {
+				t.Errorf("GetOperationMediaTypes() = %q, %q, want %q, %q", request, response, tt.wantRequest, tt.wantResponse)
+			}
+		})
+	}
+}
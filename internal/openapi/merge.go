@@ -0,0 +1,116 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// mergeDocument merges the paths and components of an additional document into doc.
+// Paths may be shared as long as they define different methods. Operation IDs must be unique,
+// and components with the same name must be identical.
+func mergeDocument(doc, fragment *openapi3.T, source string) error {
+	operationIDs := make(map[string]string)
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			operationIDs[op.OperationID] = method + " " + path
+		}
+	}
+
+	fragmentPaths := fragment.Paths.Map()
+	paths := make([]string, 0, len(fragmentPaths))
+	for path := range fragmentPaths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := fragmentPaths[path]
+		existing := doc.Paths.Value(path)
+		if existing == nil {
+			existing = &openapi3.PathItem{}
+			doc.Paths.Set(path, existing)
+		}
+		for method, op := range item.Operations() {
+			if existing.GetOperation(method) != nil {
+				return fmt.Errorf("%s: %s %s is already defined", source, method, path)
+			}
+			if op.OperationID != "" {
+				if other, ok := operationIDs[op.OperationID]; ok {
+					return fmt.Errorf("%s: operationId %s of %s %s is already used by %s", source, op.OperationID, method, path, other)
+				}
+				operationIDs[op.OperationID] = method + " " + path
+			}
+			existing.SetOperation(method, op)
+		}
+		if len(existing.Parameters) == 0 {
+			existing.Parameters = item.Parameters
+		}
+	}
+
+	if fragment.Components == nil {
+		return nil
+	}
+	if doc.Components == nil {
+		doc.Components = &openapi3.Components{}
+	}
+	c, f := doc.Components, fragment.Components
+	if c.Schemas == nil {
+		c.Schemas = openapi3.Schemas{}
+	}
+	if c.Parameters == nil {
+		c.Parameters = openapi3.ParametersMap{}
+	}
+	if c.RequestBodies == nil {
+		c.RequestBodies = openapi3.RequestBodies{}
+	}
+	if c.Responses == nil {
+		c.Responses = openapi3.ResponseBodies{}
+	}
+	if c.SecuritySchemes == nil {
+		c.SecuritySchemes = openapi3.SecuritySchemes{}
+	}
+	if err := mergeComponents(c.Schemas, f.Schemas, source, "schema"); err != nil {
+		return err
+	}
+	if err := mergeComponents(c.Parameters, f.Parameters, source, "parameter"); err != nil {
+		return err
+	}
+	if err := mergeComponents(c.RequestBodies, f.RequestBodies, source, "request body"); err != nil {
+		return err
+	}
+	if err := mergeComponents(c.Responses, f.Responses, source, "response"); err != nil {
+		return err
+	}
+	return mergeComponents(c.SecuritySchemes, f.SecuritySchemes, source, "security scheme")
+}
+
+// mergeComponents adds fragment components to target, rejecting different definitions under the same name
+func mergeComponents[V any](target, fragment map[string]V, source, kind string) error {
+	names := make([]string, 0, len(fragment))
+	for name := range fragment {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := fragment[name]
+		if existing, ok := target[name]; ok {
+			if !sameComponent(existing, value) {
+				return fmt.Errorf("%s: %s %s conflicts with an existing definition", source, kind, name)
+			}
+			continue
+		}
+		target[name] = value
+	}
+	return nil
+}
+
+// sameComponent reports whether two components serialize to the same JSON
+func sameComponent(a, b any) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(aJSON) == string(bJSON)
+}
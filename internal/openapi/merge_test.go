@@ -0,0 +1,132 @@
+package openapi
+
+import (
+	"strings"
+	"testing"
+)
+
+const mergeBase = `
+openapi: 3.0.3
+info: {title: base, version: "1"}
+paths:
+  /api/projects/:
+    get:
+      operationId: projects_list
+      responses:
+        "200": {description: OK}
+components:
+  schemas:
+    Project:
+      type: object
+      properties:
+        name: {type: string}
+`
+
+func TestMergeDocument(t *testing.T) {
+	doc := loadTestDocument(t, mergeBase)
+	fragment := loadTestDocument(t, `
+openapi: 3.0.3
+info: {title: plugin, version: "1"}
+paths:
+  /api/projects/:
+    post:
+      operationId: projects_create
+      responses:
+        "201": {description: Created}
+  /api/clusters/:
+    get:
+      operationId: clusters_list
+      responses:
+        "200": {description: OK}
+components:
+  schemas:
+    Project:
+      type: object
+      properties:
+        name: {type: string}
+    Cluster:
+      type: object
+`)
+
+	if err := mergeDocument(doc, fragment, "plugin.yaml"); err != nil {
+		t.Fatalf("mergeDocument() error = %v", err)
+	}
+	projects := doc.Paths.Value("/api/projects/")
+	if projects.Get == nil || projects.Post == nil {
+		t.Errorf("methods of a shared path were not merged: %+v", projects)
+	}
+	if doc.Paths.Value("/api/clusters/") == nil {
+		t.Error("path of the fragment was not added")
+	}
+	if doc.Components.Schemas["Cluster"] == nil || doc.Components.Schemas["Project"] == nil {
+		t.Error("components were not merged")
+	}
+}
+
+func TestMergeDocumentCollisions(t *testing.T) {
+	tests := []struct {
+		name     string
+		fragment string
+		wantErr  string
+	}{
+		{
+			name: "same method on the same path",
+			fragment: `
+openapi: 3.0.3
+info: {title: plugin, version: "1"}
+paths:
+  /api/projects/:
+    get:
+      operationId: plugin_projects_list
+      responses:
+        "200": {description: OK}
+`,
+			wantErr: "plugin.yaml: GET /api/projects/ is already defined",
+		},
+		{
+			name: "reused operation ID",
+			fragment: `
+openapi: 3.0.3
+info: {title: plugin, version: "1"}
+paths:
+  /api/plugin-projects/:
+    get:
+      operationId: projects_list
+      responses:
+        "200": {description: OK}
+`,
+			wantErr: "plugin.yaml: operationId projects_list of GET /api/plugin-projects/ is already used by GET /api/projects/",
+		},
+		{
+			name: "component defined differently",
+			fragment: `
+openapi: 3.0.3
+info: {title: plugin, version: "1"}
+paths: {}
+components:
+  schemas:
+    Project:
+      type: object
+      properties:
+        name: {type: integer}
+`,
+			wantErr: "plugin.yaml: schema Project conflicts with an existing definition",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := loadTestDocument(t, mergeBase)
+			err := mergeDocument(doc, loadTestDocument(t, tt.fragment), "plugin.yaml")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("mergeDocument() error = %v, want %q", err, tt.wantErr)
+			}
+			// The existing definitions are kept
+			if op := doc.Paths.Value("/api/projects/").Get; op == nil || op.OperationID != "projects_list" {
+				t.Errorf("existing operation was overwritten: %+v", op)
+			}
+			if name := doc.Components.Schemas["Project"].Value.Properties["name"]; !name.Value.Type.Is("string") {
+				t.Errorf("existing component was overwritten: %+v", name.Value)
+			}
+		})
+	}
+}
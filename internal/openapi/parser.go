@@ -2,49 +2,146 @@ package openapi
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
 // Parser handles OpenAPI schema parsing
 type Parser struct {
-	doc *openapi3.T
+	doc        *openapi3.T
+	operations map[string]OperationInfo // Operations indexed by operation ID
 }
 
-// NewParser creates a new OpenAPI parser
-func NewParser(schemaPath string) (*Parser, error) {
+// OperationInfo locates an operation within the schema
+type OperationInfo struct {
+	Operation *openapi3.Operation
+	Path      string
+	Method    string
+}
+
+// NewParser creates a new OpenAPI parser. Schemas are local paths or URLs; remote documents,
+// and documents they reference with relative paths, are fetched through the schema cache.
+// Additional documents (e.g., plugin fragments) are merged into the first one.
+func NewParser(fetch FetchOptions, schemaLocation string, extraLocations ...string) (*Parser, error) {
+	reader := &schemaReader{fetch: fetch, documents: make(map[string][]byte)}
 	loader := openapi3.NewLoader()
 	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = reader.read
 
-	doc, err := loader.LoadFromFile(schemaPath)
+	doc, err := loadDocument(loader, schemaLocation)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load OpenAPI schema: %w", err)
 	}
 
+	for _, location := range extraLocations {
+		fragment, err := loadDocument(loader, location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load OpenAPI schema %s: %w", location, err)
+		}
+		if err := mergeDocument(doc, fragment, location); err != nil {
+			return nil, fmt.Errorf("failed to merge OpenAPI schema: %w", err)
+		}
+	}
+
+	// Components referenced from other files are copied into the schema under unique names
+	if len(reader.documents) > 1+len(extraLocations) {
+		internalizeRefs(loader.Context, doc)
+	}
+
 	// Validate the document (skip example validation to allow upstream schema issues)
 	if err := doc.Validate(loader.Context, openapi3.DisableExamplesValidation()); err != nil {
 		return nil, fmt.Errorf("invalid OpenAPI schema: %w", err)
 	}
 
-	return &Parser{doc: doc}, nil
+	return &Parser{doc: doc, operations: indexOperations(doc)}, nil
 }
 
-// GetOperation retrieves an operation by its operation ID
-func (p *Parser) GetOperation(operationID string) (*openapi3.Operation, string, string, error) {
-	for path, pathItem := range p.doc.Paths.Map() {
+// loadDocument loads the document at a local path or URL
+func loadDocument(loader *openapi3.Loader, location string) (*openapi3.T, error) {
+	if !IsRemote(location) {
+		return loader.LoadFromFile(location)
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema URL: %w", err)
+	}
+	return loader.LoadFromURI(u)
+}
+
+// indexOperations maps each operation ID to its operation, path and method
+func indexOperations(doc *openapi3.T) map[string]OperationInfo {
+	operations := make(map[string]OperationInfo)
+	for path, pathItem := range doc.Paths.Map() {
 		for method, op := range pathItem.Operations() {
-			if op.OperationID == operationID {
-				return op, path, method, nil
+			if op.OperationID != "" {
+				operations[op.OperationID] = OperationInfo{Operation: op, Path: path, Method: method}
 			}
 		}
 	}
-	return nil, "", "", fmt.Errorf("operation not found: %s", operationID)
+	return operations
+}
+
+// GetOperation retrieves an operation by its operation ID
+func (p *Parser) GetOperation(operationID string) (*openapi3.Operation, string, string, error) {
+	info, ok := p.operations[operationID]
+	if !ok {
+		return nil, "", "", fmt.Errorf("operation not found: %s", operationID)
+	}
+	return info.Operation, info.Path, info.Method, nil
+}
+
+// LookupOperation returns the operation, path and method for an operation ID
+func (p *Parser) LookupOperation(operationID string) (OperationInfo, bool) {
+	info, ok := p.operations[operationID]
+	return info, ok
+}
+
+// OperationPath returns the path of an operation
+func (p *Parser) OperationPath(operationID string) (string, bool) {
+	info, ok := p.operations[operationID]
+	return info.Path, ok
+}
+
+// OperationIDs returns the IDs of all operations of the schema, sorted
+func (p *Parser) OperationIDs() []string {
+	ids := make([]string, 0, len(p.operations))
+	for id := range p.operations {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
 }
 
+// RetrievePaths returns the paths retrieving a single object of a top-level collection by UUID
+// ("/api/projects/{uuid}/"), sorted
+func (p *Parser) RetrievePaths() []string {
+	seen := make(map[string]bool)
+	for _, info := range p.operations {
+		if info.Method == http.MethodGet && retrievePathPattern.MatchString(info.Path) {
+			seen[info.Path] = true
+		}
+	}
+	paths := make([]string, 0, len(seen))
+	for path := range seen {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// retrievePathPattern matches paths of objects of a top-level collection identified by UUID
+var retrievePathPattern = regexp.MustCompile(`^/api/[a-z0-9-]+/\{uuid\}/$`)
+
 // ValidateOperationExists checks if an operation ID exists in the schema
 func (p *Parser) ValidateOperationExists(operationID string) error {
-	_, _, _, err := p.GetOperation(operationID)
-	return err
+	if _, ok := p.operations[operationID]; !ok {
+		return fmt.Errorf("operation not found: %s", operationID)
+	}
+	return nil
 }
 
 // GetSchema retrieves a schema by its name from Components.Schemas
@@ -66,15 +163,32 @@ func (p *Parser) GetOperationRequestSchema(operationID string) (*openapi3.Schema
 		return nil, fmt.Errorf("operation %s has no request body", operationID)
 	}
 
-	// Look for application/json content
-	content := op.RequestBody.Value.Content.Get("application/json")
+	// Look for JSON content, then for a multipart form
+	content := jsonMediaType(op.RequestBody.Value.Content)
+	if content == nil {
+		content = op.RequestBody.Value.Content.Get("multipart/form-data")
+	}
 	if content == nil {
-		return nil, fmt.Errorf("operation %s has no application/json request body", operationID)
+		return nil, fmt.Errorf("operation %s has no JSON or multipart/form-data request body (available media types: %s)",
+			operationID, describeMediaTypes(mediaTypeNames(op.RequestBody.Value.Content)))
 	}
 
 	return content.Schema, nil
 }
 
+// AcceptsRequestContentType reports whether an operation accepts a request body of the given media type.
+// Any JSON-compatible media type is accepted for application/json.
+func (p *Parser) AcceptsRequestContentType(operationID, contentType string) bool {
+	op, _, _, err := p.GetOperation(operationID)
+	if err != nil || op.RequestBody == nil || op.RequestBody.Value == nil {
+		return false
+	}
+	if contentType == "application/json" {
+		return jsonMediaType(op.RequestBody.Value.Content) != nil
+	}
+	return op.RequestBody.Value.Content.Get(contentType) != nil
+}
+
 // GetOperationResponseSchema returns the success response schema for an operation
 func (p *Parser) GetOperationResponseSchema(operationID string) (*openapi3.SchemaRef, error) {
 	op, _, _, err := p.GetOperation(operationID)
@@ -82,18 +196,94 @@ func (p *Parser) GetOperationResponseSchema(operationID string) (*openapi3.Schem
 		return nil, err
 	}
 
-	// Try 200, 201, 204 status codes
-	for _, code := range []string{"200", "201", "204"} {
+	// Try 200, 201, 202, 204 status codes
+	var available []string
+	for _, code := range []string{"200", "201", "202", "204"} {
 		resp := op.Responses.Status(StringToInt(code))
 		if resp != nil && resp.Value != nil {
-			content := resp.Value.Content.Get("application/json")
+			content := jsonMediaType(resp.Value.Content)
 			if content != nil && content.Schema != nil {
 				return content.Schema, nil
 			}
+			available = append(available, mediaTypeNames(resp.Value.Content)...)
+		}
+	}
+
+	return nil, fmt.Errorf("operation %s has no success response with JSON content (available media types: %s)",
+		operationID, describeMediaTypes(available))
+}
+
+// GetOperationMediaTypes returns the JSON media types of the request body and of the success response of
+// an operation, the ones whose schemas GetOperationRequestSchema and GetOperationResponseSchema return.
+// Either is empty when the operation has no such JSON content.
+func (p *Parser) GetOperationMediaTypes(operationID string) (request, response string) {
+	op, _, _, err := p.GetOperation(operationID)
+	if err != nil {
+		return "", ""
+	}
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		request = jsonMediaTypeName(op.RequestBody.Value.Content)
+	}
+	for _, code := range []string{"200", "201", "202", "204"} {
+		resp := op.Responses.Status(StringToInt(code))
+		if resp == nil || resp.Value == nil {
+			continue
+		}
+		if name := jsonMediaTypeName(resp.Value.Content); name != "" && resp.Value.Content[name].Schema != nil {
+			return request, name
+		}
+	}
+	return request, ""
+}
+
+// GetOperationResponseHeaders returns the canonical names of the headers documented on the
+// success responses of an operation, sorted
+func (p *Parser) GetOperationResponseHeaders(operationID string) []string {
+	op, _, _, err := p.GetOperation(operationID)
+	if err != nil || op.Responses == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var headers []string
+	for _, code := range []string{"200", "201", "202", "204"} {
+		resp := op.Responses.Status(StringToInt(code))
+		if resp == nil || resp.Value == nil {
+			continue
+		}
+		for name := range resp.Value.Headers {
+			name = http.CanonicalHeaderKey(name)
+			if !seen[name] {
+				seen[name] = true
+				headers = append(headers, name)
+			}
 		}
 	}
+	sort.Strings(headers)
+	return headers
+}
 
-	return nil, fmt.Errorf("operation %s has no success response with application/json content", operationID)
+// IsAsyncOperation reports whether an operation only answers 202 Accepted, i.e. its result
+// is produced by an asynchronous task
+func (p *Parser) IsAsyncOperation(operationID string) bool {
+	op, _, _, err := p.GetOperation(operationID)
+	if err != nil || op.Responses == nil {
+		return false
+	}
+	return op.Responses.Status(202) != nil && op.Responses.Status(200) == nil && op.Responses.Status(201) == nil
+}
+
+// IsOrderOperation reports whether an asynchronous operation is completed by a marketplace order,
+// i.e. the body of its 202 Accepted response declares an order_uuid
+func (p *Parser) IsOrderOperation(operationID string) bool {
+	if !p.IsAsyncOperation(operationID) {
+		return false
+	}
+	schema, err := p.GetOperationResponseSchema(operationID)
+	if err != nil || schema.Value == nil {
+		return false
+	}
+	return schema.Value.Properties["order_uuid"] != nil
 }
 
 // StringToInt is a helper to convert string status codes to int
@@ -101,11 +291,132 @@ func StringToInt(s string) int {
 	codes := map[string]int{
 		"200": 200,
 		"201": 201,
+		"202": 202,
 		"204": 204,
 	}
 	return codes[s]
 }
 
+// PathParameter describes a path parameter of an operation
+type PathParameter struct {
+	Name        string // Name as written in the path template, e.g. "uuid"
+	Type        string // OpenAPI type of the parameter schema, "string" when not declared
+	Required    bool   // Whether the parameter is marked required (always true for valid schemas)
+	Description string // Parameter description, empty if not documented
+}
+
+// pathTemplateParam matches the parameters of a path template, e.g. {uuid}
+var pathTemplateParam = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// GetOperationPathParams returns the path parameters of an operation in the order they appear
+// in its path. Operation parameters override parameters declared on the path item; parameters
+// of the template that are not declared are reported as required strings.
+func (p *Parser) GetOperationPathParams(operationID string) ([]PathParameter, error) {
+	info, ok := p.operations[operationID]
+	if !ok {
+		return nil, fmt.Errorf("operation not found: %s", operationID)
+	}
+
+	declared := make(map[string]*openapi3.Parameter)
+	var paramRefs openapi3.Parameters
+	if pathItem := p.doc.Paths.Value(info.Path); pathItem != nil {
+		paramRefs = append(paramRefs, pathItem.Parameters...)
+	}
+	paramRefs = append(paramRefs, info.Operation.Parameters...)
+	for _, ref := range paramRefs {
+		if ref != nil && ref.Value != nil && ref.Value.In == openapi3.ParameterInPath {
+			declared[ref.Value.Name] = ref.Value
+		}
+	}
+
+	var params []PathParameter
+	for _, match := range pathTemplateParam.FindAllStringSubmatch(info.Path, -1) {
+		param := PathParameter{Name: match[1], Type: "string", Required: true}
+		if value, ok := declared[param.Name]; ok {
+			param.Required = value.Required
+			param.Description = value.Description
+			if value.Schema != nil && value.Schema.Value != nil && value.Schema.Value.Type != nil && len(*value.Schema.Value.Type) > 0 {
+				param.Type = (*value.Schema.Value.Type)[0]
+			}
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+// bulkCreateSuffixes are appended to a collection's base operation ID by its bulk create operation
+var bulkCreateSuffixes = []string{"_bulk_create", "_create_bulk"}
+
+// FindBulkCreateOperation returns the ID of the bulk create operation of the collection with the
+// given base operation ID, e.g. "marketplace_course_accounts_create_bulk"
+func (p *Parser) FindBulkCreateOperation(baseOperationID string) (string, bool) {
+	for _, suffix := range bulkCreateSuffixes {
+		operationID := baseOperationID + suffix
+		if _, err := p.GetBulkItemsProperty(operationID); err == nil {
+			return operationID, true
+		}
+	}
+	return "", false
+}
+
+// GetBulkItemsProperty returns the request body property of a bulk operation that lists the
+// objects to create. The request must have exactly one array of objects property, and the
+// operation must answer with an array.
+func (p *Parser) GetBulkItemsProperty(operationID string) (string, error) {
+	requestSchema, err := p.GetOperationRequestSchema(operationID)
+	if err != nil {
+		return "", err
+	}
+	responseSchema, err := p.GetOperationResponseSchema(operationID)
+	if err != nil {
+		return "", err
+	}
+	if responseSchema.Value == nil || !responseSchema.Value.Type.Is(openapi3.TypeArray) {
+		return "", fmt.Errorf("operation %s does not answer with an array", operationID)
+	}
+
+	var names []string
+	if requestSchema.Value != nil {
+		for name, prop := range requestSchema.Value.Properties {
+			if prop == nil || prop.Value == nil || !prop.Value.Type.Is(openapi3.TypeArray) {
+				continue
+			}
+			if items := prop.Value.Items; items != nil && items.Value != nil && (items.Value.Type.Is(openapi3.TypeObject) || len(items.Value.Properties) > 0) {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	if len(names) != 1 {
+		return "", fmt.Errorf("operation %s must have exactly one array of objects in its request body, found %d", operationID, len(names))
+	}
+	return names[0], nil
+}
+
+// ErrorStatusCodes are the client error responses whose schemas are captured for typed errors
+var ErrorStatusCodes = []int{400, 403, 404, 409}
+
+// GetOperationErrorResponseSchemas returns the JSON schemas of an operation's documented
+// client error responses keyed by status code. Responses without a JSON schema are left out.
+func (p *Parser) GetOperationErrorResponseSchemas(operationID string) map[int]*openapi3.SchemaRef {
+	op, _, _, err := p.GetOperation(operationID)
+	if err != nil || op.Responses == nil {
+		return nil
+	}
+
+	schemas := make(map[int]*openapi3.SchemaRef)
+	for _, code := range ErrorStatusCodes {
+		resp := op.Responses.Status(code)
+		if resp == nil || resp.Value == nil {
+			continue
+		}
+		if content := jsonMediaType(resp.Value.Content); content != nil && content.Schema != nil {
+			schemas[code] = content.Schema
+		}
+	}
+	return schemas
+}
+
 // Document returns the underlying OpenAPI document
 func (p *Parser) Document() *openapi3.T {
 	return p.doc
@@ -0,0 +1,100 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// loadTestDocument loads an OpenAPI document given as YAML
+func loadTestDocument(t *testing.T, spec string) *openapi3.T {
+	t.Helper()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	if err != nil {
+		t.Fatalf("LoadFromData failed: %v", err)
+	}
+	return doc
+}
+
+// newTestParser builds a parser for an OpenAPI document given as YAML
+func newTestParser(t *testing.T, spec string) *Parser {
+	t.Helper()
+	doc := loadTestDocument(t, spec)
+	return &Parser{doc: doc, operations: indexOperations(doc)}
+}
+
+const asyncSpec = `
+openapi: 3.0.3
+info: {title: test, version: "1"}
+paths:
+  /api/volumes/:
+    post:
+      operationId: volumes_create
+      responses:
+        "202":
+          description: Accepted
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  uuid: {type: string}
+  /api/instances/:
+    post:
+      operationId: instances_create
+      responses:
+        "202":
+          description: Accepted
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  order_uuid: {type: string}
+                  resource_uuid: {type: string}
+  /api/projects/:
+    post:
+      operationId: projects_create
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  uuid: {type: string}
+        "202":
+          description: Accepted
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  order_uuid: {type: string}
+`
+
+func TestIsAsyncOperation(t *testing.T) {
+	p := newTestParser(t, asyncSpec)
+
+	tests := []struct {
+		operationID string
+		wantAsync   bool
+		wantOrder   bool
+	}{
+		{"volumes_create", true, false},
+		{"instances_create", true, true},
+		{"projects_create", false, false}, // Also answers 201 Created
+		{"unknown_create", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.operationID, func(t *testing.T) {
+			if got := p.IsAsyncOperation(tt.operationID); got != tt.wantAsync {
+				t.Errorf("IsAsyncOperation() = %v, want %v", got, tt.wantAsync)
+			}
+			if got := p.IsOrderOperation(tt.operationID); got != tt.wantOrder {
+				t.Errorf("IsOrderOperation() = %v, want %v", got, tt.wantOrder)
+			}
+		})
+	}
+}
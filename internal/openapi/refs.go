@@ -0,0 +1,155 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// schemaReader reads documents for the loader. Remote documents, including siblings referenced
+// with relative paths from a remote schema, are fetched through the schema cache.
+type schemaReader struct {
+	fetch     FetchOptions
+	documents map[string][]byte // Normalized documents by location, so each one is read once
+}
+
+// read returns the document at location, normalized to OpenAPI 3.0 semantics
+func (r *schemaReader) read(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+	if data, ok := r.documents[location.String()]; ok {
+		return data, nil
+	}
+
+	var data []byte
+	var err error
+	if IsRemote(location.String()) {
+		var cached string
+		if cached, err = FetchSchema(location.String(), r.fetch); err == nil {
+			data, err = os.ReadFile(cached)
+		}
+	} else {
+		data, err = openapi3.DefaultReadFromURI(loader, location)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if data, err = normalizeDocument(data); err != nil {
+		return nil, err
+	}
+	r.documents[location.String()] = data
+	return data, nil
+}
+
+// internalizeRefs moves components referenced from other documents into the components of doc
+// and points the references at them. A component keeps its own name unless another component
+// already uses it, in which case the name is prefixed with the name of its document, and then
+// numbered, until it is unique. Components are visited in sorted order, so names are stable.
+func internalizeRefs(ctx context.Context, doc *openapi3.T) {
+	assigned := make(map[string]string) // Collection and referenced location mapped to the component name
+	taken := make(map[string]bool)      // Collection and component names in use
+
+	if doc.Components != nil {
+		for name, schema := range doc.Components.Schemas {
+			taken["schemas/"+name] = true
+			// A root component that only references another document keeps its name for that location
+			if schema != nil && isExternal(schema.Ref) && schema.RefPath() != nil {
+				assigned["schemas "+schema.RefPath().String()] = name
+			}
+		}
+		for _, collection := range []struct {
+			name  string
+			names []string
+		}{
+			{"parameters", componentKeys(doc.Components.Parameters)},
+			{"headers", componentKeys(doc.Components.Headers)},
+			{"requestBodies", componentKeys(doc.Components.RequestBodies)},
+			{"responses", componentKeys(doc.Components.Responses)},
+			{"securitySchemes", componentKeys(doc.Components.SecuritySchemes)},
+			{"examples", componentKeys(doc.Components.Examples)},
+			{"links", componentKeys(doc.Components.Links)},
+			{"callbacks", componentKeys(doc.Components.Callbacks)},
+		} {
+			for _, name := range collection.names {
+				taken[collection.name+"/"+name] = true
+			}
+		}
+	}
+
+	doc.InternalizeRefs(ctx, func(doc *openapi3.T, ref openapi3.ComponentRef) string {
+		if name, found := openapi3.ReferencesComponentInRootDocument(doc, ref); found {
+			return path.Base(name)
+		}
+		location := ref.RefPath()
+		key := ref.CollectionName() + " " + location.String()
+		if name, ok := assigned[key]; ok {
+			return name
+		}
+
+		base := componentName(location)
+		name := base
+		if taken[ref.CollectionName()+"/"+name] {
+			base = documentName(location) + base
+			name = base
+			for i := 2; taken[ref.CollectionName()+"/"+name]; i++ {
+				name = fmt.Sprintf("%s%d", base, i)
+			}
+		}
+		assigned[key] = name
+		taken[ref.CollectionName()+"/"+name] = true
+		return name
+	})
+}
+
+// isExternal reports whether a reference points into another document
+func isExternal(ref string) bool {
+	return ref != "" && !strings.HasPrefix(ref, "#")
+}
+
+// componentKeys returns the names of a component map
+func componentKeys[V any](components map[string]V) []string {
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	return names
+}
+
+// componentName returns the name a referenced component is stored under: the last segment
+// of the reference fragment, or the document name for references to a whole document
+func componentName(location *url.URL) string {
+	if location.Fragment != "" {
+		return openapi3.InvalidIdentifierCharRegExp.ReplaceAllString(path.Base(location.Fragment), "_")
+	}
+	return documentName(location)
+}
+
+// documentName converts the file name of a document into a component name prefix,
+// e.g. "shared-types.yaml" becomes "SharedTypes"
+func documentName(location *url.URL) string {
+	file := path.Base(location.Path)
+	file = strings.TrimSuffix(file, path.Ext(file))
+
+	var b strings.Builder
+	upper := true
+	for _, r := range file {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upper {
+				r = unicode.ToUpper(r)
+			}
+			b.WriteRune(r)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	if b.Len() == 0 {
+		return "External"
+	}
+	return b.String()
+}
@@ -0,0 +1,49 @@
+package openapi
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestInternalizeRefs(t *testing.T) {
+	p, err := NewParser(FetchOptions{}, "testdata/refs/main.yaml")
+	if err != nil {
+		t.Fatalf("NewParser() error = %v", err)
+	}
+	schemas := p.Document().Components.Schemas
+
+	var names []string
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	// Tag of common.yaml collides with Tag of the main document and is prefixed with its file name
+	if got, want := strings.Join(names, ","), "Address,CommonTag,Owner,Project,Tag"; got != want {
+		t.Fatalf("components = %s, want %s", got, want)
+	}
+
+	project := schemas["Project"].Value.Properties
+	tests := []struct {
+		name    string
+		ref     string
+		wantRef string
+		field   string
+	}{
+		{"local component", project["tag"].Ref, "#/components/schemas/Tag", "name"},
+		{"colliding component", project["shared_tag"].Ref, "#/components/schemas/CommonTag", "key"},
+		{"root component referencing another document", project["owner"].Ref, "#/components/schemas/Owner", "name"},
+		{"nested relative reference", schemas["Owner"].Value.Properties["address"].Ref, "#/components/schemas/Address", "city"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.ref != tt.wantRef {
+				t.Errorf("ref = %s, want %s", tt.ref, tt.wantRef)
+			}
+			name := strings.TrimPrefix(tt.wantRef, "#/components/schemas/")
+			if schemas[name].Value.Properties[tt.field] == nil {
+				t.Errorf("component %s has no property %s", name, tt.field)
+			}
+		})
+	}
+}
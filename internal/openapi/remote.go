@@ -0,0 +1,90 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FetchOptions controls how remote OpenAPI schemas are downloaded and cached
+type FetchOptions struct {
+	CacheDir   string // Directory holding downloaded schemas
+	AuthHeader string // Authorization header value sent with the request (optional)
+	Refresh    bool   // Ignore the cached copy and download the schema again
+}
+
+// IsRemote reports whether a schema location is an HTTP(S) URL
+func IsRemote(location string) bool {
+	return strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "http://")
+}
+
+// FetchSchema returns a local path for the schema at location. Local paths are returned as-is;
+// URLs are downloaded into the cache directory and revalidated with their ETag on later runs.
+// If the server cannot be reached, a previously cached copy is used.
+func FetchSchema(location string, opts FetchOptions) (string, error) {
+	if !IsRemote(location) {
+		return location, nil
+	}
+
+	sum := sha256.Sum256([]byte(location))
+	key := hex.EncodeToString(sum[:8])
+	schemaPath := filepath.Join(opts.CacheDir, key+".yaml")
+	etagPath := filepath.Join(opts.CacheDir, key+".etag")
+
+	cached := false
+	if _, err := os.Stat(schemaPath); err == nil {
+		cached = true
+	}
+
+	req, err := http.NewRequest(http.MethodGet, location, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid schema URL: %w", err)
+	}
+	if opts.AuthHeader != "" {
+		req.Header.Set("Authorization", opts.AuthHeader)
+	}
+	if cached && !opts.Refresh {
+		if etag, err := os.ReadFile(etagPath); err == nil && len(etag) > 0 {
+			req.Header.Set("If-None-Match", string(etag))
+		}
+	}
+
+	client := &http.Client{Timeout: 2 * time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		if cached && !opts.Refresh {
+			fmt.Printf("Warning: failed to fetch OpenAPI schema, using cached copy: %v\n", err)
+			return schemaPath, nil
+		}
+		return "", fmt.Errorf("failed to fetch OpenAPI schema: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached:
+		return schemaPath, nil
+	case resp.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("failed to fetch OpenAPI schema: %s returned %s", location, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read OpenAPI schema: %w", err)
+	}
+	if err := os.MkdirAll(opts.CacheDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create schema cache: %w", err)
+	}
+	if err := os.WriteFile(schemaPath, body, 0644); err != nil {
+		return "", fmt.Errorf("failed to cache OpenAPI schema: %w", err)
+	}
+	if err := os.WriteFile(etagPath, []byte(resp.Header.Get("ETag")), 0644); err != nil {
+		return "", fmt.Errorf("failed to cache OpenAPI schema: %w", err)
+	}
+	return schemaPath, nil
+}
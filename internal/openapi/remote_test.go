@@ -0,0 +1,98 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+const remoteSchema = "openapi: 3.0.3\n"
+
+func TestFetchSchema(t *testing.T) {
+	var requests, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("Authorization") != "Token secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(remoteSchema))
+	}))
+	defer server.Close()
+
+	opts := FetchOptions{CacheDir: t.TempDir(), AuthHeader: "Token secret"}
+	location := server.URL + "/api/schema/"
+
+	path, err := FetchSchema(location, opts)
+	if err != nil {
+		t.Fatalf("FetchSchema() error = %v", err)
+	}
+	if content, _ := os.ReadFile(path); string(content) != remoteSchema {
+		t.Errorf("cached schema = %q, want %q", content, remoteSchema)
+	}
+
+	// A second fetch revalidates the cached copy with its ETag
+	again, err := FetchSchema(location, opts)
+	if err != nil {
+		t.Fatalf("FetchSchema() error = %v", err)
+	}
+	if again != path || notModified != 1 {
+		t.Errorf("FetchSchema() = %s with %d not modified responses, want %s with 1", again, notModified, path)
+	}
+
+	// Refreshing ignores the ETag and downloads the schema again
+	opts.Refresh = true
+	if _, err := FetchSchema(location, opts); err != nil {
+		t.Fatalf("FetchSchema() error = %v", err)
+	}
+	if requests != 3 || notModified != 1 {
+		t.Errorf("got %d requests with %d not modified responses, want 3 with 1", requests, notModified)
+	}
+}
+
+func TestFetchSchemaOffline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(remoteSchema))
+	}))
+	location := server.URL + "/api/schema/"
+	cacheDir := t.TempDir()
+
+	path, err := FetchSchema(location, FetchOptions{CacheDir: cacheDir})
+	if err != nil {
+		t.Fatalf("FetchSchema() error = %v", err)
+	}
+	server.Close()
+
+	// Without the server, the cached copy is used
+	offline, err := FetchSchema(location, FetchOptions{CacheDir: cacheDir})
+	if err != nil {
+		t.Fatalf("FetchSchema() offline error = %v", err)
+	}
+	if offline != path {
+		t.Errorf("FetchSchema() offline = %s, want cached %s", offline, path)
+	}
+
+	// Refreshing requires the server
+	if _, err := FetchSchema(location, FetchOptions{CacheDir: cacheDir, Refresh: true}); err == nil {
+		t.Error("FetchSchema() with refresh succeeded without a server")
+	}
+
+	// Without the server and without a cached copy, there is no schema
+	if _, err := FetchSchema(location, FetchOptions{CacheDir: t.TempDir()}); err == nil {
+		t.Error("FetchSchema() succeeded without a server or cache")
+	}
+}
+
+func TestFetchSchemaLocal(t *testing.T) {
+	path, err := FetchSchema("waldur_api.yaml", FetchOptions{CacheDir: t.TempDir()})
+	if err != nil || path != "waldur_api.yaml" {
+		t.Errorf("FetchSchema() = %s, %v, want the local path unchanged", path, err)
+	}
+}
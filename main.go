@@ -4,6 +4,8 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/waldur/terraform-provider-waldur-generator/internal/config"
 	"github.com/waldur/terraform-provider-waldur-generator/internal/generator"
@@ -12,6 +14,8 @@ import (
 
 func main() {
 	configPath := flag.String("config", "config.yaml", "Path to configuration file")
+	features := flag.String("features", "", "Comma-separated list of features enabling gated resources (e.g., beta)")
+	refreshSchema := flag.Bool("refresh-schema", false, "Download a remote OpenAPI schema even if a cached copy exists")
 	flag.Parse()
 
 	// Load configuration
@@ -25,12 +29,45 @@ func main() {
 		log.Fatalf("Invalid configuration: %v", err)
 	}
 
+	// Drop resources gated behind disabled features
+	var enabledFeatures []string
+	for _, f := range strings.Split(*features, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			enabledFeatures = append(enabledFeatures, f)
+		}
+	}
+	cfg.FilterFeatures(enabledFeatures)
+
+	// Remote OpenAPI schemas are downloaded into the local cache
+	fetchOpts := openapi.FetchOptions{
+		CacheDir: cfg.Generator.SchemaCacheDir,
+		Refresh:  *refreshSchema,
+	}
+	if cfg.Generator.OpenAPIAuthEnv != "" {
+		fetchOpts.AuthHeader = os.Getenv(cfg.Generator.OpenAPIAuthEnv)
+	}
+
+	// schema-diff old.yaml new.yaml reports the changes affecting configured resources instead of generating
+	if flag.Arg(0) == "schema-diff" {
+		if flag.NArg() != 3 {
+			log.Fatalf("Usage: %s [flags] schema-diff old.yaml new.yaml", os.Args[0])
+		}
+		runSchemaDiff(cfg, fetchOpts, flag.Arg(1), flag.Arg(2))
+		return
+	}
+
 	// Parse OpenAPI schema
-	parser, err := openapi.NewParser(cfg.Generator.OpenAPISchema)
+	parser, err := openapi.NewParser(fetchOpts, cfg.Generator.OpenAPISchema, cfg.Generator.OpenAPISchemas...)
 	if err != nil {
 		log.Fatalf("Error parsing OpenAPI schema: %v", err)
 	}
 
+	// coverage reports how much of the schema the configuration uses instead of generating
+	if flag.Arg(0) == "coverage" {
+		printCoverage(generator.Coverage(cfg, parser))
+		return
+	}
+
 	// Create generator
 	gen := generator.New(cfg, parser)
 
@@ -50,3 +87,49 @@ func main() {
 	fmt.Println("  2. go mod tidy")
 	fmt.Println("  3. go build")
 }
+
+// runSchemaDiff prints, per configured resource, the changes between two OpenAPI schemas
+func runSchemaDiff(cfg *config.Config, fetchOpts openapi.FetchOptions, oldSchema, newSchema string) {
+	oldParser, err := openapi.NewParser(fetchOpts, oldSchema)
+	if err != nil {
+		log.Fatalf("Error parsing OpenAPI schema %s: %v", oldSchema, err)
+	}
+	newParser, err := openapi.NewParser(fetchOpts, newSchema)
+	if err != nil {
+		log.Fatalf("Error parsing OpenAPI schema %s: %v", newSchema, err)
+	}
+
+	diffs := generator.SchemaDiff(cfg, oldParser, newParser)
+	if len(diffs) == 0 {
+		fmt.Println("No changes affecting configured resources")
+		return
+	}
+	for _, diff := range diffs {
+		fmt.Printf("%s:\n", diff.Resource)
+		for _, change := range diff.Changes {
+			fmt.Printf("  %s\n", change)
+		}
+	}
+}
+
+// printCoverage prints the overall and per-tag coverage of the schema and the uncovered collections
+func printCoverage(report generator.CoverageReport) {
+	fmt.Printf("Operations: %d/%d (%.1f%%)\n", report.Operations.Covered, report.Operations.Total, report.Operations.Percent())
+	fmt.Printf("Component schemas: %d/%d (%.1f%%)\n", report.Schemas.Covered, report.Schemas.Total, report.Schemas.Percent())
+
+	width := 0
+	for _, tag := range report.Tags {
+		width = max(width, len(tag.Tag))
+	}
+	fmt.Println("\nOperations by tag:")
+	for _, tag := range report.Tags {
+		fmt.Printf("  %-*s  %d/%d (%.1f%%)\n", width, tag.Tag, tag.Operations.Covered, tag.Operations.Total, tag.Operations.Percent())
+	}
+
+	if len(report.Candidates) > 0 {
+		fmt.Println("\nUncovered collections with list, create, retrieve and destroy operations:")
+		for _, candidate := range report.Candidates {
+			fmt.Printf("  %s\n", candidate)
+		}
+	}
+}